@@ -267,11 +267,17 @@ func start(c *cli.Context) error {
 	e.GET("/bot/is-vacation-mode", wrapper.IsVacationModeHandler)
 	e.GET("/bot/user-infos", wrapper.GetUserInfosHandler)
 	e.GET("/bot/character-class", wrapper.GetCharacterClassHandler)
+	e.GET("/bot/character-class/selection", wrapper.GetCharacterClassSelectionHandler)
+	e.POST("/bot/character-class/select/:characterClass", wrapper.SelectCharacterClassHandler)
+	e.POST("/bot/character-class/deselect", wrapper.DeselectCharacterClassHandler)
+	e.GET("/bot/alliance-class/selection", wrapper.GetAllianceClassSelectionHandler)
+	e.POST("/bot/alliance-class/select/:allianceClass", wrapper.SelectAllianceClassHandler)
 	e.GET("/bot/has-commander", wrapper.HasCommanderHandler)
 	e.GET("/bot/has-admiral", wrapper.HasAdmiralHandler)
 	e.GET("/bot/has-engineer", wrapper.HasEngineerHandler)
 	e.GET("/bot/has-geologist", wrapper.HasGeologistHandler)
 	e.GET("/bot/has-technocrat", wrapper.HasTechnocratHandler)
+	e.GET("/bot/officers", wrapper.GetOfficersHandler)
 	e.POST("/bot/send-message", wrapper.SendMessageHandler)
 	e.GET("/bot/fleets", wrapper.GetFleetsHandler)
 	e.GET("/bot/fleets/slots", wrapper.GetSlotsHandler)
@@ -279,32 +285,52 @@ func start(c *cli.Context) error {
 	e.GET("/bot/espionage-report/:msgid", wrapper.GetEspionageReportHandler)
 	e.GET("/bot/espionage-report/:galaxy/:system/:position", wrapper.GetEspionageReportForHandler)
 	e.GET("/bot/espionage-report", wrapper.GetEspionageReportMessagesHandler)
+	e.GET("/bot/espionage-actions", wrapper.GetEspionageActionMessagesHandler)
+	e.GET("/bot/harvest-reports", wrapper.GetHarvestReportsHandler)
 	e.POST("/bot/delete-report/:messageID", wrapper.DeleteMessageHandler)
 	e.POST("/bot/delete-all-espionage-reports", wrapper.DeleteEspionageMessagesHandler)
 	e.POST("/bot/delete-all-reports/:tabIndex", wrapper.DeleteMessagesFromTabHandler)
+	e.GET("/bot/messages/:tabIndex", wrapper.GetMessagesHandler)
+	e.POST("/bot/messages/:tabIndex/:messageID/read", wrapper.MarkMessageReadHandler)
+	e.POST("/bot/messages/favorite/:messageID", wrapper.SetMessageFavoriteHandler)
 	e.GET("/bot/attacks", wrapper.GetAttacksHandler)
 	e.GET("/bot/get-auction", wrapper.GetAuctionHandler)
 	e.POST("/bot/do-auction", wrapper.DoAuctionHandler)
 	e.GET("/bot/galaxy-infos/:galaxy/:system", wrapper.GalaxyInfosHandler)
 	e.GET("/bot/get-research", wrapper.GetResearchHandler)
 	e.GET("/bot/buy-offer-of-the-day", wrapper.BuyOfferOfTheDayHandler)
+	e.GET("/bot/celestials/:celestialID/trader/resources", wrapper.GetResourceTraderHandler)
+	e.POST("/bot/celestials/:celestialID/trader/resources", wrapper.ExchangeResourcesHandler)
+	e.GET("/bot/celestials/:celestialID/trader/scrap", wrapper.GetScrapMerchantHandler)
+	e.POST("/bot/celestials/:celestialID/trader/scrap", wrapper.ScrapUnitsHandler)
+	e.POST("/bot/celestials/:celestialID/trader/scrap/bargain", wrapper.BargainScrapHandler)
 	e.GET("/bot/price/:ogameID/:nbr", wrapper.GetPriceHandler)
 	e.GET("/bot/requirements/:ogameID", wrapper.GetRequirementsHandler)
+	e.GET("/bot/requirements/:ogameID/celestials/:celestialID", wrapper.GetUnlockPlanHandler)
 	e.GET("/bot/moons", wrapper.GetMoonsHandler)
 	e.GET("/bot/moons/:moonID", wrapper.GetMoonHandler)
 	e.GET("/bot/moons/:galaxy/:system/:position", wrapper.GetMoonByCoordHandler)
 	e.GET("/bot/celestials/:celestialID/items", wrapper.GetCelestialItemsHandler)
 	e.GET("/bot/celestials/:celestialID/items/:itemRef/activate", wrapper.ActivateCelestialItemHandler)
+	e.POST("/bot/celestials/:celestialID/items/:itemRef/buy", wrapper.BuyCelestialItemHandler)
+	e.GET("/bot/celestials/:celestialID/planet-layer", wrapper.GetPlanetLayerHandler)
+	e.POST("/bot/celestials/:celestialID/rename", wrapper.RenameCelestialHandler)
 	e.GET("/bot/celestials/:celestialID/techs", wrapper.TechsHandler)
 	e.GET("/bot/planets", wrapper.GetPlanetsHandler)
 	e.GET("/bot/planets/:planetID", wrapper.GetPlanetHandler)
 	e.GET("/bot/planets/:galaxy/:system/:position", wrapper.GetPlanetByCoordHandler)
 	e.GET("/bot/planets/:planetID/resources-details", wrapper.GetResourcesDetailsHandler)
+	e.GET("/bot/planets/:planetID/resources-productions", wrapper.GetResourcesProductionsHandler)
+	e.GET("/bot/planets/:planetID/upgrade-advices", wrapper.GetUpgradeAdvicesHandler)
 	e.GET("/bot/planets/:planetID/resource-settings", wrapper.GetResourceSettingsHandler)
 	e.POST("/bot/planets/:planetID/resource-settings", wrapper.SetResourceSettingsHandler)
 	e.GET("/bot/planets/:planetID/resources-buildings", wrapper.GetResourcesBuildingsHandler)
 	e.GET("/bot/planets/:planetID/lifeform-buildings", wrapper.GetLfBuildingsHandler)
 	e.GET("/bot/planets/:planetID/lifeform-techs", wrapper.GetLfResearchHandler)
+	e.GET("/bot/planets/:planetID/lifeform-bonuses", wrapper.GetLfBonusesHandler)
+	e.GET("/bot/planets/:planetID/lifeform-settings", wrapper.GetLfSettingsHandler)
+	e.POST("/bot/planets/:planetID/lifeform-settings/species/:lifeformType", wrapper.SelectLfSpeciesHandler)
+	e.POST("/bot/planets/:planetID/lifeform-settings/research-slots/:slot/:ogameID", wrapper.SetLfResearchSlotHandler)
 	e.GET("/bot/planets/:planetID/defence", wrapper.GetDefenseHandler)
 	e.GET("/bot/planets/:planetID/ships", wrapper.GetShipsHandler)
 	e.GET("/bot/planets/:planetID/facilities", wrapper.GetFacilitiesHandler)
@@ -323,6 +349,8 @@ func start(c *cli.Context) error {
 	e.GET("/bot/planets/:planetID/resources", wrapper.GetResourcesHandler)
 	e.POST("/bot/planets/:planetID/send-fleet", wrapper.SendFleetHandler)
 	e.POST("/bot/planets/:planetID/send-ipm", wrapper.SendIPMHandler)
+	e.GET("/bot/planets/:planetID/relocation", wrapper.GetRelocationStatusHandler)
+	e.POST("/bot/planets/:planetID/relocate", wrapper.RelocatePlanetHandler)
 	e.GET("/bot/moons/:moonID/phalanx/:galaxy/:system/:position", wrapper.PhalanxHandler)
 	e.POST("/bot/moons/:moonID/jump-gate", wrapper.JumpGateHandler)
 	e.GET("/game/allianceInfo.php", wrapper.GetAlliancePageContentHandler) // Example: //game/allianceInfo.php?allianceId=500127
@@ -0,0 +1,515 @@
+// Package chatClient implements the OGame socket.io chat protocol.
+// v7 servers speak socket.io v0.9, v8+ servers speak socket.io v3 (engine.io v4).
+package chatClient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/utils"
+	"golang.org/x/net/websocket"
+)
+
+// Version socket.io protocol spoken by the chat server
+type Version int
+
+const (
+	// V7 socket.io v0.9, used by ogame v7
+	V7 Version = iota + 7
+	// V8 socket.io v3, used by ogame v8 and later
+	V8
+)
+
+// ErrAuthorizationFailed returned when the chat server refused the session
+var ErrAuthorizationFailed = errors.New("failed to connect to chat")
+
+// ErrClosed returned when using a closed client
+var ErrClosed = errors.New("chat client closed")
+
+// ErrNotConnected returned when emitting before the websocket is open
+var ErrNotConnected = errors.New("chat client not connected")
+
+// Client OGame chat client.
+// Callbacks must be registered before calling Run.
+type Client struct {
+	sync.Mutex
+	baseURL    string // https://host:port
+	session    string
+	version    Version
+	httpClient *http.Client
+	conn       *websocket.Conn
+	counter    int64
+	closed     bool
+	closeCh    chan struct{}
+
+	chatCallbacks       []func(ogame.ChatMsg)
+	auctioneerCallbacks []func(any)
+	presenceCallbacks   []func(PresenceEvent)
+	eventCallbacks      []func(Event)
+	rawCallbacks        []func([]byte)
+	connectedCallbacks  []func()
+	errorCallbacks      []func(error)
+}
+
+// New creates a chat client for the server at baseURL (eg: https://s1-en.ogame.gameforge.com:19000)
+func New(baseURL, session string, version Version) *Client {
+	c := new(Client)
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	c.session = session
+	c.version = version
+	c.httpClient = &http.Client{}
+	c.counter = 1
+	c.closeCh = make(chan struct{})
+	return c
+}
+
+// SetHTTPClient set the http client used for the socket.io handshake
+func (c *Client) SetHTTPClient(client *http.Client) *Client {
+	c.httpClient = client
+	return c
+}
+
+// SetSession set the ogame session used to authorize on the chat, eg: after a new login
+func (c *Client) SetSession(session string) *Client {
+	c.Lock()
+	defer c.Unlock()
+	c.session = session
+	return c
+}
+
+// OnChatMessage register a callback called for every chat message received
+func (c *Client) OnChatMessage(clb func(ogame.ChatMsg)) *Client {
+	c.chatCallbacks = append(c.chatCallbacks, clb)
+	return c
+}
+
+// OnAuctioneer register a callback called for every auctioneer update.
+// The packet is one of the ogame.Auctioneer* types, or the raw payload if it is unknown.
+func (c *Client) OnAuctioneer(clb func(any)) *Client {
+	c.auctioneerCallbacks = append(c.auctioneerCallbacks, clb)
+	return c
+}
+
+// OnPresence register a callback called when a player goes online/offline
+func (c *Client) OnPresence(clb func(PresenceEvent)) *Client {
+	c.presenceCallbacks = append(c.presenceCallbacks, clb)
+	return c
+}
+
+// OnEvent register a callback called for the events that have no typed callback
+func (c *Client) OnEvent(clb func(Event)) *Client {
+	c.eventCallbacks = append(c.eventCallbacks, clb)
+	return c
+}
+
+// OnRaw register a callback called with every packet received, before it is parsed
+func (c *Client) OnRaw(clb func([]byte)) *Client {
+	c.rawCallbacks = append(c.rawCallbacks, clb)
+	return c
+}
+
+// OnConnected register a callback called when the chat server accepted the session
+func (c *Client) OnConnected(clb func()) *Client {
+	c.connectedCallbacks = append(c.connectedCallbacks, clb)
+	return c
+}
+
+// OnError register a callback called for the non-fatal errors (unknown packets, refused session...)
+func (c *Client) OnError(clb func(error)) *Client {
+	c.errorCallbacks = append(c.errorCallbacks, clb)
+	return c
+}
+
+// Run connects to the chat server and dispatches the packets received until the connection is lost or Close is called.
+// Returns nil if the client was closed. Run can be called again to reconnect.
+func (c *Client) Run() error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+	wssURL, err := c.handshake()
+	if err != nil {
+		return err
+	}
+	origin := c.baseURL + "/"
+	conn, err := websocket.Dial(wssURL, "", origin)
+	if err != nil {
+		return fmt.Errorf("failed to dial websocket: %w", err)
+	}
+	c.Lock()
+	if c.closed {
+		c.Unlock()
+		_ = conn.Close()
+		return nil
+	}
+	c.conn = conn
+	c.Unlock()
+	defer func() {
+		c.Lock()
+		c.conn = nil
+		c.Unlock()
+		_ = conn.Close()
+	}()
+	if c.version == V8 {
+		if err := c.send("2probe"); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-c.closeCh:
+			return nil
+		default:
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			return err
+		}
+		var buf string
+		if err := websocket.Message.Receive(conn, &buf); err != nil {
+			if c.isClosed() {
+				return nil
+			} else if strings.HasSuffix(err.Error(), "i/o timeout") {
+				continue
+			}
+			return err
+		}
+		for _, clb := range c.rawCallbacks {
+			clb([]byte(buf))
+		}
+		c.handle(buf)
+	}
+}
+
+// Close disconnects the client, Run returns once closed
+func (c *Client) Close() {
+	c.Lock()
+	defer c.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.closeCh)
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+// Reconnect asks the server to reconnect the chat namespace, used after a new login.
+// Returns false if the websocket is not open.
+func (c *Client) Reconnect() bool {
+	var msg string
+	if c.version == V8 {
+		msg = "40/" + ChatNamespace + ","
+	} else {
+		msg = "1::/" + ChatNamespace
+	}
+	return c.send(msg) == nil
+}
+
+// Emit sends an event to the server in the given namespace
+func (c *Client) Emit(namespace, name string, args ...any) error {
+	msg, err := c.encodeEvent(namespace, name, 0, args...)
+	if err != nil {
+		return err
+	}
+	return c.send(msg)
+}
+
+// handshake gets the socket.io session id and returns the websocket url to dial
+func (c *Client) handshake() (string, error) {
+	var pollURL string
+	if c.version == V8 {
+		pollURL = c.baseURL + "/socket.io/?EIO=4&transport=polling&t=" + yeast(time.Now().UnixNano()/1000000)
+	} else {
+		pollURL = c.baseURL + "/socket.io/1/?t=" + utils.FI64(time.Now().UnixNano()/int64(time.Millisecond))
+	}
+	req, err := http.NewRequest(http.MethodGet, pollURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get socket.io token: %w", err)
+	}
+	defer resp.Body.Close()
+	by, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to get socket.io token: %w", err)
+	}
+	wsBaseURL := "ws" + strings.TrimPrefix(c.baseURL, "http")
+	if c.version == V8 {
+		m := regexp.MustCompile(`"sid":"([^"]+)"`).FindSubmatch(by)
+		if len(m) != 2 {
+			return "", errors.New("failed to get websocket sid")
+		}
+		return wsBaseURL + "/socket.io/?EIO=4&transport=websocket&sid=" + string(m[1]), nil
+	}
+	token := strings.Split(string(by), ":")[0]
+	if token == "" {
+		return "", errors.New("failed to get socket.io token")
+	}
+	return wsBaseURL + "/socket.io/1/websocket/" + token, nil
+}
+
+func (c *Client) handle(buf string) {
+	var f frame
+	if c.version == V8 {
+		f = decodeFrame(buf)
+	} else {
+		f = decodeFrameV7(buf)
+	}
+	switch f.kind {
+	case openFrame:
+		if c.version == V8 {
+			_ = c.send("5")
+			_ = c.send("40/" + ChatNamespace + ",")
+			_ = c.send("40/" + AuctioneerNamespace + ",")
+		} else {
+			_ = c.send("1::/" + ChatNamespace)
+			_ = c.send("1::/" + AuctioneerNamespace)
+		}
+	case pingFrame:
+		if c.version == V8 {
+			_ = c.send("3")
+		} else {
+			_ = c.send("2::")
+		}
+	case connectFrame:
+		if f.namespace == ChatNamespace {
+			c.authorize()
+		}
+	case ackFrame:
+		if f.namespace != ChatNamespace || len(f.args) == 0 {
+			return
+		}
+		var accepted bool
+		_ = json.Unmarshal(f.args[0], &accepted)
+		if accepted {
+			for _, clb := range c.connectedCallbacks {
+				clb()
+			}
+		} else {
+			c.emitError(ErrAuthorizationFailed)
+		}
+	case eventFrame:
+		c.dispatch(f)
+	default:
+		c.emitError(fmt.Errorf("unknown message received: %s", buf))
+	}
+}
+
+func (c *Client) dispatch(f frame) {
+	if f.namespace == AuctioneerNamespace {
+		pck := parseAuctioneerEvent(f.name, f.args, f.raw)
+		for _, clb := range c.auctioneerCallbacks {
+			clb(pck)
+		}
+		return
+	}
+	if f.namespace == ChatNamespace && f.name == "chat" {
+		for _, arg := range f.args {
+			var chatMsg ogame.ChatMsg
+			if err := json.Unmarshal(arg, &chatMsg); err != nil {
+				c.emitError(fmt.Errorf("unable to unmarshal chat payload: %w", err))
+				continue
+			}
+			for _, clb := range c.chatCallbacks {
+				clb(chatMsg)
+			}
+		}
+		return
+	}
+	if f.namespace == ChatNamespace {
+		if presence, ok := presenceFromEvent(f.name, f.args); ok {
+			for _, clb := range c.presenceCallbacks {
+				clb(presence)
+			}
+			return
+		}
+	}
+	evt := Event{Namespace: f.namespace, Name: f.name, Args: f.args}
+	for _, clb := range c.eventCallbacks {
+		clb(evt)
+	}
+}
+
+func (c *Client) authorize() {
+	c.Lock()
+	ackID := c.counter
+	c.counter++
+	session := c.session
+	c.Unlock()
+	msg, err := c.encodeEvent(ChatNamespace, "authorize", ackID, session)
+	if err != nil {
+		c.emitError(err)
+		return
+	}
+	_ = c.send(msg)
+}
+
+// encodeEvent encodes an event packet, an ackID of 0 means no acknowledgement is requested
+func (c *Client) encodeEvent(namespace, name string, ackID int64, args ...any) (string, error) {
+	var ack string
+	if ackID > 0 {
+		ack = utils.FI64(ackID)
+	}
+	if c.version == V8 {
+		payload, err := json.Marshal(append([]any{name}, args...))
+		if err != nil {
+			return "", err
+		}
+		return "42/" + namespace + "," + ack + string(payload), nil
+	}
+	if args == nil {
+		args = []any{}
+	}
+	payload, err := json.Marshal(struct {
+		Name string `json:"name"`
+		Args []any  `json:"args"`
+	}{name, args})
+	if err != nil {
+		return "", err
+	}
+	if ack != "" {
+		ack += "+"
+	}
+	return "5:" + ack + ":/" + namespace + ":" + string(payload), nil
+}
+
+func (c *Client) send(msg string) error {
+	c.Lock()
+	conn := c.conn
+	c.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return websocket.Message.Send(conn, msg)
+}
+
+func (c *Client) isClosed() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.closed
+}
+
+func (c *Client) emitError(err error) {
+	for _, clb := range c.errorCallbacks {
+		clb(err)
+	}
+}
+
+// decodeFrame decodes a socket.io v3 packet
+//
+// 3probe
+// 2
+// 40/chat,{"sid":"..."}
+// 42/chat,["chat",{...}]
+// 43/chat,1[true]
+func decodeFrame(buf string) frame {
+	switch buf {
+	case "3probe":
+		return frame{kind: openFrame}
+	case "2":
+		return frame{kind: pingFrame}
+	}
+	if len(buf) < 3 || buf[0] != '4' || buf[2] != '/' {
+		return frame{}
+	}
+	parts := strings.SplitN(buf[3:], ",", 2)
+	f := frame{namespace: parts[0]}
+	if len(parts) == 2 {
+		f.raw = parts[1]
+	}
+	switch buf[1] {
+	case '0':
+		f.kind = connectFrame
+	case '2', '3':
+		payload := f.raw
+		idx := strings.IndexAny(payload, "[{")
+		if idx > 0 {
+			f.ackID, _ = strconv.ParseInt(payload[:idx], 10, 64)
+			payload = payload[idx:]
+		}
+		f.raw = payload
+		if buf[1] == '3' {
+			f.kind = ackFrame
+			_ = json.Unmarshal([]byte(payload), &f.args)
+			return f
+		}
+		name, args, ok := decodeEventPayload(payload)
+		if !ok {
+			return frame{}
+		}
+		f.kind, f.name, f.args = eventFrame, name, args
+	default:
+		return frame{}
+	}
+	return f
+}
+
+// decodeFrameV7 decodes a socket.io v0.9 packet, "type:id:endpoint:data"
+//
+// 1::
+// 2::
+// 1::/chat
+// 5::/chat:{"name":"chat","args":[{...}]}
+// 6::/chat:1+[true]
+func decodeFrameV7(buf string) frame {
+	parts := strings.SplitN(buf, ":", 4)
+	if len(parts) < 3 {
+		return frame{}
+	}
+	f := frame{namespace: strings.TrimPrefix(parts[2], "/")}
+	if len(parts) == 4 {
+		f.raw = parts[3]
+	}
+	switch parts[0] {
+	case "1":
+		if f.namespace == "" {
+			f.kind = openFrame
+		} else {
+			f.kind = connectFrame
+		}
+	case "2":
+		f.kind = pingFrame
+	case "5":
+		name, args, ok := decodeEventPayloadV7(f.raw)
+		if !ok {
+			return frame{}
+		}
+		f.kind, f.name, f.args = eventFrame, name, args
+	case "6":
+		ack := strings.SplitN(f.raw, "+", 2)
+		if len(ack) != 2 {
+			return frame{}
+		}
+		f.kind = ackFrame
+		f.ackID, _ = strconv.ParseInt(ack[0], 10, 64)
+		_ = json.Unmarshal([]byte(ack[1]), &f.args)
+	default:
+		return frame{}
+	}
+	return f
+}
+
+// Socket IO v3 timestamp encoding
+// https://github.com/unshiftio/yeast/blob/28d15f72fc5a4273592bc209056c328a54e2b522/index.js#L17
+// fmt.Println(yeast(time.Now().UnixNano() / 1000000))
+func yeast(num int64) (encoded string) {
+	alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_"
+	length := int64(len(alphabet))
+	for num > 0 {
+		encoded = string(alphabet[int(num%length)]) + encoded
+		num = int64(math.Floor(float64(num / length)))
+	}
+	return
+}
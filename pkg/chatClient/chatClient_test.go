@@ -0,0 +1,312 @@
+package chatClient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+func TestYeast(t *testing.T) {
+	assert.Equal(t, "", yeast(0))
+	assert.Equal(t, "10", yeast(64))
+	assert.Equal(t, "10_", yeast(64*64+63))
+}
+
+func TestDecodeFrame(t *testing.T) {
+	assert.Equal(t, openFrame, decodeFrame("3probe").kind)
+	assert.Equal(t, pingFrame, decodeFrame("2").kind)
+	assert.Equal(t, unknownFrame, decodeFrame("something").kind)
+
+	f := decodeFrame(`40/chat,{"sid":"abc"}`)
+	assert.Equal(t, connectFrame, f.kind)
+	assert.Equal(t, "chat", f.namespace)
+
+	f = decodeFrame(`43/chat,12[true]`)
+	assert.Equal(t, ackFrame, f.kind)
+	assert.Equal(t, int64(12), f.ackID)
+	assert.Equal(t, 1, len(f.args))
+	assert.Equal(t, "true", string(f.args[0]))
+
+	f = decodeFrame(`42/chat,["chat",{"senderId":1,"text":"hi"}]`)
+	assert.Equal(t, eventFrame, f.kind)
+	assert.Equal(t, "chat", f.namespace)
+	assert.Equal(t, "chat", f.name)
+	assert.Equal(t, `{"senderId":1,"text":"hi"}`, string(f.args[0]))
+}
+
+func TestDecodeFrameV7(t *testing.T) {
+	assert.Equal(t, openFrame, decodeFrameV7("1::").kind)
+	assert.Equal(t, pingFrame, decodeFrameV7("2::").kind)
+	assert.Equal(t, unknownFrame, decodeFrameV7("something").kind)
+
+	f := decodeFrameV7("1::/chat")
+	assert.Equal(t, connectFrame, f.kind)
+	assert.Equal(t, "chat", f.namespace)
+
+	f = decodeFrameV7(`6::/chat:3+[false]`)
+	assert.Equal(t, ackFrame, f.kind)
+	assert.Equal(t, int64(3), f.ackID)
+	assert.Equal(t, "false", string(f.args[0]))
+
+	f = decodeFrameV7(`5::/auctioneer:{"name":"timeLeft","args":["a:b"]}`)
+	assert.Equal(t, eventFrame, f.kind)
+	assert.Equal(t, "auctioneer", f.namespace)
+	assert.Equal(t, "timeLeft", f.name)
+	assert.Equal(t, `"a:b"`, string(f.args[0]))
+}
+
+func TestEncodeEvent(t *testing.T) {
+	c := New("https://127.0.0.1:19000", "sess", V8)
+	msg, _ := c.encodeEvent("chat", "authorize", 1, "sess")
+	assert.Equal(t, `42/chat,1["authorize","sess"]`, msg)
+	msg, _ = c.encodeEvent("chat", "typing", 0)
+	assert.Equal(t, `42/chat,["typing"]`, msg)
+
+	c = New("https://127.0.0.1:19000", "sess", V7)
+	msg, _ = c.encodeEvent("chat", "authorize", 1, "sess")
+	assert.Equal(t, `5:1+:/chat:{"name":"authorize","args":["sess"]}`, msg)
+	msg, _ = c.encodeEvent("chat", "typing", 0)
+	assert.Equal(t, `5::/chat:{"name":"typing","args":[]}`, msg)
+}
+
+func TestPresenceFromEvent(t *testing.T) {
+	f := decodeFrame(`42/chat,["playerOnline",123]`)
+	p, ok := presenceFromEvent(f.name, f.args)
+	assert.True(t, ok)
+	assert.Equal(t, PresenceEvent{PlayerID: 123, Online: true}, p)
+
+	f = decodeFrame(`42/chat,["playerOffline",{"playerId":456}]`)
+	p, ok = presenceFromEvent(f.name, f.args)
+	assert.True(t, ok)
+	assert.Equal(t, PresenceEvent{PlayerID: 456, Online: false}, p)
+
+	_, ok = presenceFromEvent("chat", f.args)
+	assert.False(t, ok)
+}
+
+func TestParseAuctioneerEvent(t *testing.T) {
+	f := decodeFrame(`42/auctioneer,["new bid",{"player":{"id":219657,"name":"Payback","link":"https://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"},"sum":5000,"price":6000,"bids":5,"auctionId":"42894"}]`)
+	pck := parseAuctioneerEvent(f.name, f.args, f.raw)
+	newBid, ok := pck.(ogame.AuctioneerNewBid)
+	assert.True(t, ok)
+	assert.Equal(t, int64(42894), newBid.AuctionID)
+	assert.Equal(t, int64(5000), newBid.Sum)
+	assert.Equal(t, int64(6000), newBid.Price)
+	assert.Equal(t, int64(5), newBid.Bids)
+	assert.Equal(t, int64(219657), newBid.Player.ID)
+	assert.Equal(t, "Payback", newBid.Player.Name)
+
+	f = decodeFrameV7(`5::/auctioneer:{"name":"timeLeft","args":["<span style=\"color:#FFA500;\"><b>approx. 10m</b></span> remaining until the auction ends"]}`)
+	assert.Equal(t, ogame.AuctioneerTimeRemaining{Approx: 600}, parseAuctioneerEvent(f.name, f.args, f.raw))
+
+	f = decodeFrameV7(`5::/auctioneer:{"name":"timeLeft","args":["Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">598</span>"]}`)
+	assert.Equal(t, ogame.AuctioneerNextAuction{Secs: 598}, parseAuctioneerEvent(f.name, f.args, f.raw))
+
+	f = decodeFrame(`42/auctioneer,["new auction",{"info":"<span style=\"color:#99CC00;\"><b>approx. 35m</b></span> remaining until the auction ends","auctionId":42895}]`)
+	assert.Equal(t, ogame.AuctioneerNewAuction{AuctionID: 42895, Approx: 2100}, parseAuctioneerEvent(f.name, f.args, f.raw))
+
+	f = decodeFrame(`42/auctioneer,["auction finished",{"sum":5000,"player":{"id":219657,"name":"Payback"},"bids":5,"time":"08:42"}]`)
+	finished, ok := parseAuctioneerEvent(f.name, f.args, f.raw).(ogame.AuctioneerAuctionFinished)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5000), finished.Sum)
+	assert.Equal(t, int64(5), finished.Bids)
+	assert.Equal(t, "Payback", finished.Player.Name)
+
+	f = decodeFrame(`42/auctioneer,["unknown",1]`)
+	assert.Equal(t, `["unknown",1]`, parseAuctioneerEvent(f.name, f.args, f.raw))
+}
+
+// fakeServer in-process stand-in for the OGame socket.io server.
+// script is called with every packet received from the client, and returns the packets to send back.
+func fakeServer(t *testing.T, version Version, script func(msg string) []string) *httptest.Server {
+	wsHandler := websocket.Handler(func(ws *websocket.Conn) {
+		if version == V7 {
+			_ = websocket.Message.Send(ws, "1::")
+		}
+		for {
+			var msg string
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				return
+			}
+			for _, out := range script(msg) {
+				if err := websocket.Message.Send(ws, out); err != nil {
+					return
+				}
+			}
+		}
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/socket.io/", func(w http.ResponseWriter, r *http.Request) {
+		if version == V8 && r.URL.Query().Get("transport") == "polling" {
+			_, _ = w.Write([]byte(`0{"sid":"abc123","upgrades":["websocket"],"pingInterval":25000,"pingTimeout":20000}`))
+			return
+		}
+		if version == V7 && r.URL.Path == "/socket.io/1/" {
+			_, _ = w.Write([]byte(`token123:60:60:websocket,htmlfile,xhr-polling,jsonp-polling`))
+			return
+		}
+		if version == V8 {
+			assert.Equal(t, "abc123", r.URL.Query().Get("sid"))
+		} else {
+			assert.Equal(t, "/socket.io/1/websocket/token123", r.URL.Path)
+		}
+		wsHandler.ServeHTTP(w, r)
+	})
+	return httptest.NewServer(mux)
+}
+
+type received struct {
+	chatMsgs  chan ogame.ChatMsg
+	auctions  chan any
+	presences chan PresenceEvent
+	events    chan Event
+	emitted   chan string
+}
+
+func newReceived() *received {
+	return &received{
+		chatMsgs:  make(chan ogame.ChatMsg, 10),
+		auctions:  make(chan any, 10),
+		presences: make(chan PresenceEvent, 10),
+		events:    make(chan Event, 10),
+		emitted:   make(chan string, 10),
+	}
+}
+
+func runClient(t *testing.T, c *Client, r *received) {
+	c.OnChatMessage(func(msg ogame.ChatMsg) { r.chatMsgs <- msg }).
+		OnAuctioneer(func(pck any) { r.auctions <- pck }).
+		OnPresence(func(p PresenceEvent) { r.presences <- p }).
+		OnEvent(func(e Event) { r.events <- e }).
+		OnConnected(func() { assert.NoError(t, c.Emit("chat", "typing", 123)) })
+	done := make(chan error, 1)
+	go func() { done <- c.Run() }()
+
+	select {
+	case msg := <-r.chatMsgs:
+		assert.Equal(t, int64(1), msg.SenderID)
+		assert.Equal(t, "Bob", msg.SenderName)
+		assert.Equal(t, "hello", msg.Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("chat message not received")
+	}
+	select {
+	case pck := <-r.auctions:
+		assert.Equal(t, ogame.AuctioneerNextAuction{Secs: 117}, pck)
+	case <-time.After(5 * time.Second):
+		t.Fatal("auctioneer packet not received")
+	}
+	select {
+	case p := <-r.presences:
+		assert.Equal(t, PresenceEvent{PlayerID: 2, Online: true}, p)
+	case <-time.After(5 * time.Second):
+		t.Fatal("presence not received")
+	}
+	select {
+	case e := <-r.events:
+		assert.Equal(t, "chat", e.Namespace)
+		assert.Equal(t, "something", e.Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+	select {
+	case msg := <-r.emitted:
+		assert.Contains(t, msg, "typing")
+	case <-time.After(5 * time.Second):
+		t.Fatal("emitted message not received")
+	}
+
+	c.Close()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not stop")
+	}
+	assert.ErrorIs(t, c.Run(), ErrClosed)
+}
+
+func TestClientV8(t *testing.T) {
+	r := newReceived()
+	srv := fakeServer(t, V8, func(msg string) []string {
+		switch {
+		case msg == "2probe":
+			return []string{"3probe"}
+		case msg == "40/chat,":
+			return []string{`40/chat,{"sid":"chatSid"}`}
+		case msg == "40/auctioneer,":
+			return []string{`40/auctioneer,{"sid":"auctioneerSid"}`}
+		case msg == `42/chat,1["authorize","sess"]`:
+			return []string{
+				"2",
+				`43/chat,1[true]`,
+				`42/chat,["chat",{"senderId":1,"senderName":"Bob","associationId":0,"text":"hello","id":10,"date":1621546373039}]`,
+				`42/auctioneer,["timeLeft","Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">117</span>"]`,
+				`42/chat,["playerOnline",2]`,
+				`42/chat,["something",{}]`,
+			}
+		case strings.HasPrefix(msg, `42/chat,["typing"`):
+			r.emitted <- msg
+		}
+		return nil
+	})
+	defer srv.Close()
+	runClient(t, New(srv.URL, "sess", V8), r)
+}
+
+func TestClientV7(t *testing.T) {
+	r := newReceived()
+	srv := fakeServer(t, V7, func(msg string) []string {
+		switch {
+		case msg == "1::/chat":
+			return []string{"1::/chat"}
+		case msg == "1::/auctioneer":
+			return []string{"1::/auctioneer"}
+		case msg == `5:1+:/chat:{"name":"authorize","args":["sess"]}`:
+			return []string{
+				"2::",
+				`6::/chat:1+[true]`,
+				`5::/chat:{"name":"chat","args":[{"senderId":1,"senderName":"Bob","associationId":0,"text":"hello","id":10,"date":1621546373039}]}`,
+				`5::/auctioneer:{"name":"timeLeft","args":["Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">117</span>"]}`,
+				`5::/chat:{"name":"playerOnline","args":[2]}`,
+				`5::/chat:{"name":"something","args":[{}]}`,
+			}
+		case strings.HasPrefix(msg, `5::/chat:{"name":"typing"`):
+			r.emitted <- msg
+		}
+		return nil
+	})
+	defer srv.Close()
+	runClient(t, New(srv.URL, "sess", V7), r)
+}
+
+func TestClientAuthorizationFailed(t *testing.T) {
+	srv := fakeServer(t, V8, func(msg string) []string {
+		switch msg {
+		case "2probe":
+			return []string{"3probe"}
+		case "40/chat,":
+			return []string{`40/chat,{"sid":"chatSid"}`}
+		case `42/chat,1["authorize","sess"]`:
+			return []string{`43/chat,1[false]`}
+		}
+		return nil
+	})
+	defer srv.Close()
+	errCh := make(chan error, 1)
+	c := New(srv.URL, "sess", V8).OnError(func(err error) { errCh <- err })
+	go func() { _ = c.Run() }()
+	defer c.Close()
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, ErrAuthorizationFailed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("error not received")
+	}
+}
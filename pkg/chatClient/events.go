@@ -0,0 +1,176 @@
+package chatClient
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
+// Namespaces the OGame socket.io server uses
+const (
+	ChatNamespace       = "chat"
+	AuctioneerNamespace = "auctioneer"
+)
+
+// Event a socket.io event that has no typed representation
+type Event struct {
+	Namespace string
+	Name      string
+	Args      []json.RawMessage
+}
+
+// PresenceEvent a buddy/alliance member went online or offline
+type PresenceEvent struct {
+	PlayerID int64
+	Online   bool
+}
+
+// frame a decoded socket.io packet, independently of the protocol version
+type frame struct {
+	kind      frameKind
+	namespace string
+	ackID     int64
+	name      string
+	args      []json.RawMessage
+	raw       string // the event payload, without the socket.io envelope
+}
+
+type frameKind int
+
+const (
+	unknownFrame frameKind = iota
+	openFrame              // engine.io handshake done ("3probe" / "1::")
+	pingFrame
+	connectFrame // namespace connected
+	eventFrame
+	ackFrame
+)
+
+// decodeEventPayload decodes a socket.io v3+ event payload: ["name", arg1, arg2...]
+func decodeEventPayload(payload string) (name string, args []json.RawMessage, ok bool) {
+	var arr []json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &arr); err != nil || len(arr) == 0 {
+		return "", nil, false
+	}
+	if err := json.Unmarshal(arr[0], &name); err != nil {
+		return "", nil, false
+	}
+	return name, arr[1:], true
+}
+
+// decodeEventPayloadV7 decodes a socket.io v0.9 event payload: {"name":"name","args":[arg1, arg2...]}
+func decodeEventPayloadV7(payload string) (name string, args []json.RawMessage, ok bool) {
+	var out struct {
+		Name string            `json:"name"`
+		Args []json.RawMessage `json:"args"`
+	}
+	if err := json.Unmarshal([]byte(payload), &out); err != nil || out.Name == "" {
+		return "", nil, false
+	}
+	return out.Name, out.Args, true
+}
+
+// presenceFromEvent returns the presence event for the "playerOnline"/"playerOffline" chat events.
+// The player is either given as an id or as an object with a playerId field.
+func presenceFromEvent(name string, args []json.RawMessage) (PresenceEvent, bool) {
+	var online bool
+	switch name {
+	case "playerOnline":
+		online = true
+	case "playerOffline":
+	default:
+		return PresenceEvent{}, false
+	}
+	if len(args) == 0 {
+		return PresenceEvent{}, false
+	}
+	var playerID int64
+	if err := json.Unmarshal(args[0], &playerID); err != nil {
+		var obj struct {
+			PlayerID int64 `json:"playerId"`
+		}
+		if err := json.Unmarshal(args[0], &obj); err != nil {
+			return PresenceEvent{}, false
+		}
+		playerID = obj.PlayerID
+	}
+	return PresenceEvent{PlayerID: playerID, Online: online}, true
+}
+
+// parseAuctioneerEvent converts an auctioneer event to the ogame Auctioneer* types.
+// Returns raw when the event is unknown.
+//
+// timeLeft: ["<span style=\"color:#99CC00;\"><b>approx. 30m</b></span> remaining until the auction ends"] // every minute
+// timeLeft: ["Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">117</span>"]
+// new bid: [{"player":{"id":219657,"name":"Payback","link":"..."},"sum":5000,"price":6000,"bids":5,"auctionId":"42894"}]
+// new auction: [{"info":"<span style=\"color:#99CC00;\"><b>approx. 35m</b></span> remaining until the auction ends","item":{...},"oldAuction":{...},"auctionId":42895}]
+// auction finished: [{"sum":5000,"player":{"id":219657,"name":"Payback","link":"..."},"bids":5,"info":"...","time":"08:42"}]
+func parseAuctioneerEvent(name string, args []json.RawMessage, raw string) any {
+	if len(args) == 0 {
+		return raw
+	}
+	var arg any
+	if err := json.Unmarshal(args[0], &arg); err != nil {
+		return raw
+	}
+	switch name {
+	case "new bid":
+		if firstArg, ok := arg.(map[string]any); ok {
+			auctionID := utils.DoParseI64(utils.DoCastStr(firstArg["auctionId"]))
+			pck := ogame.AuctioneerNewBid{
+				Sum:       int64(utils.DoCastF64(firstArg["sum"])),
+				Price:     int64(utils.DoCastF64(firstArg["price"])),
+				Bids:      int64(utils.DoCastF64(firstArg["bids"])),
+				AuctionID: auctionID,
+			}
+			if player, ok := firstArg["player"].(map[string]any); ok {
+				pck.Player.ID = int64(utils.DoCastF64(player["id"]))
+				pck.Player.Name = utils.DoCastStr(player["name"])
+				pck.Player.Link = utils.DoCastStr(player["link"])
+			}
+			return pck
+		}
+	case "timeLeft":
+		if timeLeftMsg, ok := arg.(string); ok {
+			if strings.Contains(timeLeftMsg, "color:") {
+				doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
+				txt := regexp.MustCompile(`\d+`).FindString(doc.Find("b").Text())
+				return ogame.AuctioneerTimeRemaining{Approx: utils.DoParseI64(txt) * 60}
+			} else if strings.Contains(timeLeftMsg, "nextAuction") {
+				doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
+				txt := regexp.MustCompile(`\d+`).FindString(doc.Find("span").Text())
+				return ogame.AuctioneerNextAuction{Secs: utils.DoParseI64(txt)}
+			}
+		}
+	case "new auction":
+		if firstArg, ok := arg.(map[string]any); ok {
+			pck := ogame.AuctioneerNewAuction{
+				AuctionID: int64(utils.DoCastF64(firstArg["auctionId"])),
+			}
+			if infoMsg, ok := firstArg["info"].(string); ok {
+				doc, _ := goquery.NewDocumentFromReader(strings.NewReader(infoMsg))
+				txt := regexp.MustCompile(`\d+`).FindString(doc.Find("b").Text())
+				pck.Approx = utils.DoParseI64(txt) * 60
+			}
+			return pck
+		}
+	case "auction finished":
+		if firstArg, ok := arg.(map[string]any); ok {
+			pck := ogame.AuctioneerAuctionFinished{
+				Sum:  int64(utils.DoCastF64(firstArg["sum"])),
+				Bids: int64(utils.DoCastF64(firstArg["bids"])),
+			}
+			if player, ok := firstArg["player"].(map[string]any); ok {
+				pck.Player.ID = int64(utils.DoCastF64(player["id"]))
+				pck.Player.Name = utils.DoCastStr(player["name"])
+				pck.Player.Link = utils.DoCastStr(player["link"])
+			}
+			return pck
+		}
+	}
+	return raw
+}
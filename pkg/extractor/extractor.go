@@ -25,6 +25,7 @@ type FullPageExtractorBytes interface {
 	ExtractLifeformEnabled(pageHTML []byte) bool
 	ExtractMoon(pageHTML []byte, v any) (ogame.Moon, error)
 	ExtractMoons(pageHTML []byte) []ogame.Moon
+	ExtractOfficers(pageHTML []byte) ogame.Officers
 	ExtractOGameTimestampFromBytes(pageHTML []byte) int64
 	ExtractOgameTimestamp(pageHTML []byte) int64
 	ExtractPlanet(pageHTML []byte, v any) (ogame.Planet, error)
@@ -52,6 +53,7 @@ type FullPageExtractorDoc interface {
 	ExtractMoonFromDoc(doc *goquery.Document, v any) (ogame.Moon, error)
 	ExtractMoonsFromDoc(doc *goquery.Document) []ogame.Moon
 	ExtractOGameSessionFromDoc(doc *goquery.Document) string
+	ExtractOfficersFromDoc(doc *goquery.Document) ogame.Officers
 	ExtractOgameTimestampFromDoc(doc *goquery.Document) int64
 	ExtractPlanetFromDoc(doc *goquery.Document, v any) (ogame.Planet, error)
 	ExtractPlanetIDFromDoc(doc *goquery.Document) (ogame.CelestialID, error)
@@ -81,11 +83,13 @@ type OverviewExtractorBytes interface {
 	ExtractOverviewProduction(pageHTML []byte) ([]ogame.Quantifiable, int64, error)
 	ExtractOverviewShipSumCountdownFromBytes(pageHTML []byte) int64
 	ExtractUserInfos(pageHTML []byte) (ogame.UserInfos, error)
+	ExtractRelocation(pageHTML []byte) (ogame.PlanetRelocation, error)
 }
 
 type OverviewExtractorDoc interface {
 	ExtractOverviewProductionFromDoc(doc *goquery.Document) ([]ogame.Quantifiable, error)
 	ExtractCharacterClassFromDoc(doc *goquery.Document) (ogame.CharacterClass, error)
+	ExtractRelocationFromDoc(doc *goquery.Document) (ogame.PlanetRelocation, error)
 }
 
 type OverviewExtractorBytesDoc interface {
@@ -302,13 +306,47 @@ type EspionageReportExtractorBytesDoc interface {
 	EspionageReportExtractorDoc
 }
 
+// ChatExtractorBytes chat page, conversations list and history of the open conversation
+type ChatExtractorBytes interface {
+	ExtractConversations(pageHTML []byte) []ogame.Conversation
+	ExtractConversationMessages(pageHTML []byte, ownPlayerID int64) []ogame.ConversationMessage
+	ExtractChatBarConversations(pageHTML []byte, ownPlayerID int64) []ogame.Conversation
+}
+
+type ChatExtractorDoc interface {
+	ExtractConversationsFromDoc(doc *goquery.Document) []ogame.Conversation
+	ExtractConversationMessagesFromDoc(doc *goquery.Document, ownPlayerID int64) []ogame.ConversationMessage
+	ExtractChatBarConversationsFromDoc(doc *goquery.Document, ownPlayerID int64) []ogame.Conversation
+}
+
+type ChatExtractorBytesDoc interface {
+	ChatExtractorBytes
+	ChatExtractorDoc
+}
+
+// MessagesExtractorBytes ajax page that display the messages of any tab
+type MessagesExtractorBytes interface {
+	ExtractMessages(pageHTML []byte, tabID ogame.MessagesTabID) ([]ogame.Message, int64)
+}
+
+type MessagesExtractorDoc interface {
+	ExtractMessagesFromDoc(doc *goquery.Document, tabID ogame.MessagesTabID) ([]ogame.Message, int64)
+}
+
+type MessagesExtractorBytesDoc interface {
+	MessagesExtractorBytes
+	MessagesExtractorDoc
+}
+
 // MessagesEspionageReportExtractorBytes ajax page that display all espionage reports summaries
 type MessagesEspionageReportExtractorBytes interface {
 	ExtractEspionageReportMessageIDs(pageHTML []byte) ([]ogame.EspionageReportSummary, int64)
+	ExtractEspionageActionMessages(pageHTML []byte) ([]ogame.EspionageActionMessage, int64)
 }
 
 type MessagesEspionageReportExtractorDoc interface {
 	ExtractEspionageReportMessageIDsFromDoc(doc *goquery.Document) ([]ogame.EspionageReportSummary, int64)
+	ExtractEspionageActionMessagesFromDoc(doc *goquery.Document) ([]ogame.EspionageActionMessage, int64)
 }
 
 type MessagesEspionageReportExtractorBytesDoc interface {
@@ -316,9 +354,24 @@ type MessagesEspionageReportExtractorBytesDoc interface {
 	MessagesEspionageReportExtractorDoc
 }
 
+// MessagesHarvestReportExtractorBytes ajax page that display the "other" fleet messages (harvest reports)
+type MessagesHarvestReportExtractorBytes interface {
+	ExtractHarvestReports(pageHTML []byte) ([]ogame.HarvestReport, int64, error)
+}
+
+type MessagesHarvestReportExtractorDoc interface {
+	ExtractHarvestReportsFromDoc(doc *goquery.Document) ([]ogame.HarvestReport, int64, error)
+}
+
+type MessagesHarvestReportExtractorBytesDoc interface {
+	MessagesHarvestReportExtractorBytes
+	MessagesHarvestReportExtractorDoc
+}
+
 // MessagesExpeditionExtractorBytes ajax page that display all expedition messages
 type MessagesExpeditionExtractorBytes interface {
 	ExtractExpeditionMessages(pageHTML []byte) ([]ogame.ExpeditionMessage, int64, error)
+	ExtractExpeditionOutcome(content string) ogame.ExpeditionOutcome
 }
 
 type MessagesExpeditionExtractorDoc interface {
@@ -354,6 +407,62 @@ type TraderImportExportExtractorDoc interface {
 	ExtractOfferOfTheDayFromDoc(doc *goquery.Document) (price int64, importToken string, planetResources ogame.PlanetResources, multiplier ogame.Multiplier, err error)
 }
 
+// TraderResourcesExtractorBytes ajax page Merchant -> Resources
+type TraderResourcesExtractorBytes interface {
+	ExtractResourceTrader(pageHTML []byte) (ogame.ResourceTrader, error)
+}
+
+type TraderResourcesExtractorDoc interface {
+	ExtractResourceTraderFromDoc(doc *goquery.Document) (ogame.ResourceTrader, error)
+}
+
+type TraderResourcesExtractorBytesDoc interface {
+	TraderResourcesExtractorBytes
+	TraderResourcesExtractorDoc
+}
+
+// TraderScrapExtractorBytes ajax page Merchant -> Scrap Merchant
+type TraderScrapExtractorBytes interface {
+	ExtractScrapMerchant(pageHTML []byte) (ogame.ScrapMerchant, error)
+}
+
+type TraderScrapExtractorDoc interface {
+	ExtractScrapMerchantFromDoc(doc *goquery.Document) (ogame.ScrapMerchant, error)
+}
+
+type TraderScrapExtractorBytesDoc interface {
+	TraderScrapExtractorBytes
+	TraderScrapExtractorDoc
+}
+
+// CharacterClassSelectionExtractorBytes page ingame component characterclassselection
+type CharacterClassSelectionExtractorBytes interface {
+	ExtractCharacterClassSelection(pageHTML []byte) (ogame.CharacterClassSelection, error)
+}
+
+type CharacterClassSelectionExtractorDoc interface {
+	ExtractCharacterClassSelectionFromDoc(doc *goquery.Document) (ogame.CharacterClassSelection, error)
+}
+
+type CharacterClassSelectionExtractorBytesDoc interface {
+	CharacterClassSelectionExtractorBytes
+	CharacterClassSelectionExtractorDoc
+}
+
+// AllianceClassSelectionExtractorBytes ajax tab of the alliance page, Alliance Class
+type AllianceClassSelectionExtractorBytes interface {
+	ExtractAllianceClassSelection(pageHTML []byte) (ogame.AllianceClassSelection, error)
+}
+
+type AllianceClassSelectionExtractorDoc interface {
+	ExtractAllianceClassSelectionFromDoc(doc *goquery.Document) (ogame.AllianceClassSelection, error)
+}
+
+type AllianceClassSelectionExtractorBytesDoc interface {
+	AllianceClassSelectionExtractorBytes
+	AllianceClassSelectionExtractorDoc
+}
+
 // FetchTechsExtractorBytes ajax page fetchTechs
 type FetchTechsExtractorBytes interface {
 	ExtractTechs(pageHTML []byte) (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error)
@@ -437,6 +546,20 @@ type LfResearchExtractorBytesDoc interface {
 	LfResearchExtractorDoc
 }
 
+// LfSettingsExtractorBytes page ingame component lfsettings
+type LfSettingsExtractorBytes interface {
+	ExtractLfSettings(pageHTML []byte) (ogame.LfSettings, error)
+}
+
+type LfSettingsExtractorDoc interface {
+	ExtractLfSettingsFromDoc(doc *goquery.Document) (ogame.LfSettings, error)
+}
+
+type LfSettingsExtractorBytesDoc interface {
+	LfSettingsExtractorBytes
+	LfSettingsExtractorDoc
+}
+
 // ResourcesBuildingsExtractorBytes supplies page
 type ResourcesBuildingsExtractorBytes interface {
 	ExtractResourcesBuildings(pageHTML []byte) (ogame.ResourcesBuildings, error)
@@ -458,8 +581,14 @@ type PremiumExtractorBytes interface {
 	ExtractPremiumToken(pageHTML []byte, days int64) (token string, err error)
 }
 
+// PlanetLayerExtractorBytes ajax page planetlayer
+type PlanetLayerExtractorBytes interface {
+	ExtractPlanetLayer(pageHTML []byte) (ogame.PlanetLayer, error)
+}
+
 type PlanetLayerExtractorDoc interface {
 	ExtractAbandonInformation(doc *goquery.Document) (abandonToken string, token string)
+	ExtractPlanetLayerFromDoc(doc *goquery.Document) (ogame.PlanetLayer, error)
 }
 
 type TechnologyDetailsExtractorBytes interface {
@@ -486,6 +615,9 @@ type Extractor interface {
 	GetLifeformEnabled() bool
 	SetLifeformEnabled(lifeformEnabled bool)
 
+	AllianceClassSelectionExtractorBytesDoc
+	CharacterClassSelectionExtractorBytesDoc
+	ChatExtractorBytesDoc
 	DefensesExtractorBytesDoc
 	EspionageReportExtractorBytesDoc
 	EventListExtractorBytesDoc
@@ -495,9 +627,12 @@ type Extractor interface {
 	HighscoreExtractorBytesDoc
 	LfBuildingsExtractorBytesDoc
 	LfResearchExtractorBytesDoc
+	LfSettingsExtractorBytesDoc
 	MessagesCombatReportExtractorBytesDoc
+	MessagesExtractorBytesDoc
 	MessagesEspionageReportExtractorBytesDoc
 	MessagesExpeditionExtractorBytesDoc
+	MessagesHarvestReportExtractorBytesDoc
 	MissileAttackLayerExtractorBytesDoc
 	MovementExtractorBytesDoc
 	OverviewExtractorBytesDoc
@@ -507,6 +642,8 @@ type Extractor interface {
 	ResourcesSettingsExtractorBytesDoc
 	ShipyardExtractorBytesDoc
 	TechnologyDetailsExtractorBytesDoc
+	TraderResourcesExtractorBytesDoc
+	TraderScrapExtractorBytesDoc
 
 	BuffActivationExtractorBytes
 	DestroyRocketsExtractorBytes
@@ -518,6 +655,7 @@ type Extractor interface {
 	JumpGateLayerExtractorBytes
 	MessagesMarketplaceExtractorBytes
 	PhalanxExtractorBytes
+	PlanetLayerExtractorBytes
 	PremiumExtractorBytes
 	TraderAuctioneerExtractorBytes
 	TraderImportExportExtractorBytes
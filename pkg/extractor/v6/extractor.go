@@ -97,6 +97,61 @@ func (e *Extractor) ExtractExpeditionMessagesFromDoc(doc *goquery.Document) ([]o
 	panic("implement me")
 }
 
+// ExtractExpeditionOutcome ...
+func (e *Extractor) ExtractExpeditionOutcome(content string) ogame.ExpeditionOutcome {
+	panic("implement me")
+}
+
+// ExtractHarvestReports ...
+func (e *Extractor) ExtractHarvestReports(pageHTML []byte) ([]ogame.HarvestReport, int64, error) {
+	panic("implement me")
+}
+
+// ExtractHarvestReportsFromDoc ...
+func (e *Extractor) ExtractHarvestReportsFromDoc(doc *goquery.Document) ([]ogame.HarvestReport, int64, error) {
+	panic("implement me")
+}
+
+// ExtractResourceTrader ...
+func (e *Extractor) ExtractResourceTrader(pageHTML []byte) (ogame.ResourceTrader, error) {
+	panic("implement me")
+}
+
+// ExtractResourceTraderFromDoc ...
+func (e *Extractor) ExtractResourceTraderFromDoc(doc *goquery.Document) (ogame.ResourceTrader, error) {
+	panic("implement me")
+}
+
+// ExtractScrapMerchant ...
+func (e *Extractor) ExtractScrapMerchant(pageHTML []byte) (ogame.ScrapMerchant, error) {
+	panic("implement me")
+}
+
+// ExtractScrapMerchantFromDoc ...
+func (e *Extractor) ExtractScrapMerchantFromDoc(doc *goquery.Document) (ogame.ScrapMerchant, error) {
+	panic("implement me")
+}
+
+// ExtractCharacterClassSelection ...
+func (e *Extractor) ExtractCharacterClassSelection(pageHTML []byte) (ogame.CharacterClassSelection, error) {
+	panic("implement me")
+}
+
+// ExtractCharacterClassSelectionFromDoc ...
+func (e *Extractor) ExtractCharacterClassSelectionFromDoc(doc *goquery.Document) (ogame.CharacterClassSelection, error) {
+	panic("implement me")
+}
+
+// ExtractAllianceClassSelection ...
+func (e *Extractor) ExtractAllianceClassSelection(pageHTML []byte) (ogame.AllianceClassSelection, error) {
+	panic("implement me")
+}
+
+// ExtractAllianceClassSelectionFromDoc ...
+func (e *Extractor) ExtractAllianceClassSelectionFromDoc(doc *goquery.Document) (ogame.AllianceClassSelection, error) {
+	panic("implement me")
+}
+
 // ExtractTearDownButtonEnabled ...
 func (e *Extractor) ExtractTearDownButtonEnabled(pageHTML []byte) bool {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -292,6 +347,36 @@ func (e *Extractor) ExtractEspionageReportMessageIDs(pageHTML []byte) ([]ogame.E
 	return e.ExtractEspionageReportMessageIDsFromDoc(doc)
 }
 
+// ExtractConversations ...
+func (e *Extractor) ExtractConversations(pageHTML []byte) []ogame.Conversation {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractConversationsFromDoc(doc)
+}
+
+// ExtractConversationMessages ...
+func (e *Extractor) ExtractConversationMessages(pageHTML []byte, ownPlayerID int64) []ogame.ConversationMessage {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractConversationMessagesFromDoc(doc, ownPlayerID)
+}
+
+// ExtractChatBarConversations ...
+func (e *Extractor) ExtractChatBarConversations(pageHTML []byte, ownPlayerID int64) []ogame.Conversation {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractChatBarConversationsFromDoc(doc, ownPlayerID)
+}
+
+// ExtractMessages ...
+func (e *Extractor) ExtractMessages(pageHTML []byte, tabID ogame.MessagesTabID) ([]ogame.Message, int64) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractMessagesFromDoc(doc, tabID)
+}
+
+// ExtractEspionageActionMessages ...
+func (e *Extractor) ExtractEspionageActionMessages(pageHTML []byte) ([]ogame.EspionageActionMessage, int64) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractEspionageActionMessagesFromDoc(doc)
+}
+
 // ExtractCombatReportMessagesSummary ...
 func (e *Extractor) ExtractCombatReportMessagesSummary(pageHTML []byte) ([]ogame.CombatReportSummary, int64) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -364,6 +449,24 @@ func (e *Extractor) ExtractTechnocrat(pageHTML []byte) bool {
 	return e.ExtractTechnocratFromDoc(doc)
 }
 
+// ExtractOfficers ...
+func (e *Extractor) ExtractOfficers(pageHTML []byte) ogame.Officers {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractOfficersFromDoc(doc)
+}
+
+// ExtractPlanetLayer ...
+func (e *Extractor) ExtractPlanetLayer(pageHTML []byte) (ogame.PlanetLayer, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractPlanetLayerFromDoc(doc)
+}
+
+// ExtractRelocation ...
+func (e *Extractor) ExtractRelocation(pageHTML []byte) (ogame.PlanetRelocation, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractRelocationFromDoc(doc)
+}
+
 // ExtractOGameSession ...
 func (e *Extractor) ExtractOGameSession(pageHTML []byte) string {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -516,6 +619,31 @@ func (e *Extractor) ExtractEspionageReportMessageIDsFromDoc(doc *goquery.Documen
 	return extractEspionageReportMessageIDsFromDoc(doc)
 }
 
+// ExtractConversationsFromDoc ...
+func (e *Extractor) ExtractConversationsFromDoc(doc *goquery.Document) []ogame.Conversation {
+	return extractConversationsFromDoc(doc, e.GetLocation())
+}
+
+// ExtractConversationMessagesFromDoc ...
+func (e *Extractor) ExtractConversationMessagesFromDoc(doc *goquery.Document, ownPlayerID int64) []ogame.ConversationMessage {
+	return extractConversationMessagesFromDoc(doc, ownPlayerID, e.GetLocation())
+}
+
+// ExtractChatBarConversationsFromDoc ...
+func (e *Extractor) ExtractChatBarConversationsFromDoc(doc *goquery.Document, ownPlayerID int64) []ogame.Conversation {
+	return extractChatBarConversationsFromDoc(doc, ownPlayerID, e.GetLocation())
+}
+
+// ExtractMessagesFromDoc ...
+func (e *Extractor) ExtractMessagesFromDoc(doc *goquery.Document, tabID ogame.MessagesTabID) ([]ogame.Message, int64) {
+	return extractMessagesFromDoc(doc, tabID, e.GetLocation())
+}
+
+// ExtractEspionageActionMessagesFromDoc ...
+func (e *Extractor) ExtractEspionageActionMessagesFromDoc(doc *goquery.Document) ([]ogame.EspionageActionMessage, int64) {
+	return extractEspionageActionMessagesFromDoc(doc, e.GetLocation())
+}
+
 // ExtractCombatReportMessagesFromDoc ...
 func (e *Extractor) ExtractCombatReportMessagesFromDoc(doc *goquery.Document) ([]ogame.CombatReportSummary, int64) {
 	return extractCombatReportMessagesFromDoc(doc)
@@ -741,11 +869,26 @@ func (e *Extractor) ExtractTechnocratFromDoc(doc *goquery.Document) bool {
 	return extractTechnocratFromDoc(doc)
 }
 
+// ExtractOfficersFromDoc ...
+func (e *Extractor) ExtractOfficersFromDoc(doc *goquery.Document) ogame.Officers {
+	return extractOfficersFromDoc(doc)
+}
+
 // ExtractAbandonInformation ...
 func (e *Extractor) ExtractAbandonInformation(doc *goquery.Document) (string, string) {
 	return extractAbandonInformation(doc)
 }
 
+// ExtractPlanetLayerFromDoc ...
+func (e *Extractor) ExtractPlanetLayerFromDoc(doc *goquery.Document) (ogame.PlanetLayer, error) {
+	return extractPlanetLayerFromDoc(doc)
+}
+
+// ExtractRelocationFromDoc ...
+func (e *Extractor) ExtractRelocationFromDoc(doc *goquery.Document) (ogame.PlanetRelocation, error) {
+	return extractRelocationFromDoc(doc)
+}
+
 // </ Extract from doc> -------------------------------------------------------
 
 // <Works with []byte only> ---------------------------------------------------
@@ -913,3 +1056,13 @@ func (e *Extractor) ExtractLfResearch(pageHTML []byte) (ogame.LfResearches, erro
 func (e *Extractor) ExtractLfResearchFromDoc(doc *goquery.Document) (ogame.LfResearches, error) {
 	panic("not implemented")
 }
+
+// ExtractLfSettings ...
+func (e *Extractor) ExtractLfSettings(pageHTML []byte) (ogame.LfSettings, error) {
+	panic("not implemented")
+}
+
+// ExtractLfSettingsFromDoc ...
+func (e *Extractor) ExtractLfSettingsFromDoc(doc *goquery.Document) (ogame.LfSettings, error) {
+	panic("not implemented")
+}
@@ -928,6 +928,95 @@ func TestExtractEspionageReportMessageIDsLootPercentage(t *testing.T) {
 	assert.Equal(t, 0.5, msgs[2].LootPercentage)
 }
 
+func TestExtractConversations(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/chat_synthetic.html")
+	conversations := NewExtractor().ExtractConversations(pageHTMLBytes)
+	assert.Equal(t, 3, len(conversations))
+	assert.Equal(t, int64(100247), conversations[0].PlayerID)
+	assert.Equal(t, "Jalmag", conversations[0].Name)
+	assert.True(t, conversations[0].Unread)
+	assert.Empty(t, conversations[0].Messages)
+	assert.Equal(t, "Stop spying me or I crash you", conversations[0].Preview.Text)
+	assert.Equal(t, time.Date(2019, 12, 10, 18, 27, 46, 0, time.UTC), conversations[0].Preview.Date.UTC())
+	assert.False(t, conversations[1].Unread)
+	assert.Equal(t, int64(5012), conversations[2].AssociationID)
+	assert.True(t, conversations[2].IsAlliance())
+}
+
+func TestExtractConversationMessages(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/chat_synthetic.html")
+	msgs := NewExtractor().ExtractConversationMessages(pageHTMLBytes, 123)
+	assert.Equal(t, 3, len(msgs))
+	assert.Equal(t, ogame.ConversationMessage{ID: 4120, SenderID: 100247, SenderName: "Jalmag", Text: "Hi, are you in an alliance?",
+		Date: time.Date(2019, 12, 10, 18, 20, 3, 0, time.UTC)}, msgs[0])
+	assert.Equal(t, int64(123), msgs[1].SenderID)
+	assert.Equal(t, "Yes, NOVA", msgs[1].Text)
+}
+
+func TestExtractChatBarConversations(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/unversioned/overview_boosters.html")
+	conversations := NewExtractor().ExtractChatBarConversations(pageHTMLBytes, 106734)
+	assert.Equal(t, 2, len(conversations))
+
+	alliance := conversations[0]
+	assert.True(t, alliance.IsAlliance())
+	assert.Equal(t, int64(205), alliance.AssociationID)
+	assert.Equal(t, "Alliance Chat", alliance.Name)
+	assert.False(t, alliance.Unread)
+	assert.Equal(t, 10, len(alliance.Messages)) // The system message is skipped
+	assert.Equal(t, ogame.ConversationMessage{ID: 83882, SenderName: "CroTo", Text: "they both lost their fleets",
+		Date: time.Date(2019, 2, 23, 23, 8, 54, 0, time.UTC)}, alliance.Messages[0])
+
+	player := conversations[1]
+	assert.Equal(t, int64(107009), player.PlayerID)
+	assert.Equal(t, "Constable Telesto", player.Name)
+	assert.Equal(t, ogame.ConversationMessage{ID: 349453, SenderID: 106734, SenderName: "Commodore Nomad", Text: "sup",
+		Date: time.Date(2019, 2, 28, 20, 32, 46, 0, time.UTC)}, player.Messages[0])
+	assert.Equal(t, int64(107009), player.Messages[1].SenderID)
+	assert.Equal(t, "ok", player.Messages[1].Text)
+}
+
+func TestExtractMessages(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/v7.2/en/expedition_messages.html")
+	msgs, nbPages := NewExtractor().ExtractMessages(pageHTMLBytes, 22)
+	assert.Equal(t, int64(10), nbPages)
+	assert.Equal(t, 10, len(msgs))
+	assert.Equal(t, int64(11199359), msgs[0].ID)
+	assert.Equal(t, ogame.MessagesTabID(22), msgs[0].TabID)
+	assert.Equal(t, "Expedition Result [1:8:16]", msgs[0].Title)
+	assert.Equal(t, "Fleet Command", msgs[0].Sender)
+	assert.Equal(t, time.Date(2020, 4, 22, 0, 12, 6, 0, time.UTC), msgs[0].CreatedAt.UTC())
+	assert.False(t, msgs[0].IsRead)
+	assert.False(t, msgs[0].IsFavorite)
+	assert.Contains(t, msgs[0].Content, "We came across the remains of a previous expedition!")
+	assert.True(t, msgs[9].IsRead)
+}
+
+func TestExtractEspionageActionMessages(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/v7.5.2/en/spy_reports.html")
+	msgs, _ := NewExtractor().ExtractEspionageActionMessages(pageHTMLBytes)
+	assert.Equal(t, 6, len(msgs))
+	assert.Equal(t, int64(13887851), msgs[0].ID)
+	assert.Equal(t, ogame.Coordinate{6, 115, 7, ogame.MoonType}, msgs[0].Origin)
+	assert.Equal(t, "Moon", msgs[0].OriginName)
+	assert.Equal(t, "FriskyQT", msgs[0].PlayerName)
+	assert.Equal(t, int64(110067), msgs[0].PlayerID)
+	assert.Equal(t, ogame.Coordinate{4, 116, 1, ogame.PlanetType}, msgs[0].Target)
+	assert.Equal(t, int64(0), msgs[0].CounterEspionageChance)
+	assert.Equal(t, time.Date(2020, 11, 13, 20, 3, 34, 0, time.UTC), msgs[0].CreatedAt.UTC())
+	assert.Equal(t, ogame.Coordinate{4, 117, 9, ogame.MoonType}, msgs[1].Target)
+	assert.Equal(t, int64(47), msgs[1].CounterEspionageChance)
+	assert.Equal(t, "BuyingDEUT 25 15 1", msgs[4].OriginName)
+	assert.Equal(t, "Heretic", msgs[4].PlayerName)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("../../../samples/unversioned/messages.html")
+	msgs, _ = NewExtractor().ExtractEspionageActionMessages(pageHTMLBytes)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, ogame.Coordinate{4, 116, 8, ogame.PlanetType}, msgs[0].Origin)
+	assert.Equal(t, ogame.Coordinate{4, 117, 9, ogame.PlanetType}, msgs[0].Target)
+	assert.Equal(t, int64(10), msgs[0].CounterEspionageChance)
+}
+
 func TestExtractCombatReportMessages(t *testing.T) {
 	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/unversioned/combat_reports_msgs.html")
 	msgs, _ := NewExtractor().ExtractCombatReportMessagesSummary(pageHTMLBytes)
@@ -2528,3 +2617,77 @@ func TestExtractOGameSession(t *testing.T) {
 	session = NewExtractor().ExtractOGameSession(pageHTMLBytes)
 	assert.Equal(t, "c1626ce8228ac5986e3808a7d42d4afc764c1b68", session)
 }
+
+func TestExtractOfficers(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/unversioned/overview_with_moon.html")
+	officers := NewExtractor().ExtractOfficers(pageHTMLBytes)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.CommanderOfficer, Active: true, Remaining: 4 * 24 * time.Hour}, officers.Commander)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.TechnocratOfficer, Active: true, Remaining: 4 * 24 * time.Hour}, officers.Technocrat)
+	assert.True(t, officers.CommandingStaff().Active)
+	assert.True(t, officers.CommandingStaffBonus)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("../../../samples/unversioned/overview_active_queue2.html")
+	officers = NewExtractor().ExtractOfficers(pageHTMLBytes)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.CommanderOfficer, Active: true, Remaining: 2 * 24 * time.Hour}, officers.Commander)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.GeologistOfficer}, officers.Geologist)
+	assert.False(t, officers.CommandingStaff().Active)
+	assert.False(t, officers.CommandingStaffBonus)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("../../../samples/v7.2/de/movement.html")
+	officers = NewExtractor().ExtractOfficers(pageHTMLBytes)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.GeologistOfficer, Active: true, Remaining: 788 * 24 * time.Hour}, officers.Geologist)
+	assert.Equal(t, ogame.OfficerStatus{Type: ogame.AdmiralOfficer, Active: true, Remaining: 788 * 24 * time.Hour}, officers.Admiral)
+	assert.True(t, officers.CommandingStaffBonus)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("../../../samples/v9.0.4/en/overview.html")
+	officers = NewExtractor().ExtractOfficers(pageHTMLBytes)
+	assert.False(t, officers.Geologist.Active)
+}
+
+func TestExtractOfficerRemaining(t *testing.T) {
+	assert.Equal(t, 4*24*time.Hour, extractOfficerRemaining("Hire Commander|Still active for more than 4 days"))
+	assert.Equal(t, 788*24*time.Hour, extractOfficerRemaining("Commander anheuern|Noch mehr als 788 Tage aktiv"))
+	assert.Equal(t, 5*time.Hour, extractOfficerRemaining("Commander anheuern|Noch mehr als 5 Stunden aktiv"))
+	assert.Equal(t, 5*time.Hour, extractOfficerRemaining("Recruter commandant|Encore actif pendant plus de 5 heures"))
+	assert.Equal(t, 3*24*time.Hour, extractOfficerRemaining("Recruter commandant|Encore actif pendant plus de 3 jours"))
+	assert.Equal(t, 30*time.Minute, extractOfficerRemaining("Contratar comandante|Todavía activo durante más de 30 minutos"))
+	assert.Equal(t, time.Duration(0), extractOfficerRemaining("Hire Commander"))
+}
+
+func TestExtractPlanetLayer(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/unversioned/abandon_form.html")
+	layer, err := NewExtractor().ExtractPlanetLayer(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "Colony", layer.Name)
+	assert.Equal(t, "6de20971bcd59555542cca9af8bfdac1", layer.AbandonToken)
+	assert.Equal(t, "e0f93d90d986990f1f84c00a245a9a34", layer.Token)
+
+	_, err = NewExtractor().ExtractPlanetLayer([]byte(`<div></div>`))
+	assert.Error(t, err)
+}
+
+func TestExtractRelocation(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/v9.0.2/en/overview_all_queues.html")
+	relocation, err := NewExtractor().ExtractRelocation(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, ogame.PlanetRelocation{Possible: true, Cost: 240000}, relocation)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("../../../samples/unversioned/moon_overview.html")
+	relocation, err = NewExtractor().ExtractRelocation(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.False(t, relocation.Possible)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("testdata/overview_relocation_synthetic.html")
+	relocation, err = NewExtractor().ExtractRelocation(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, ogame.PlanetRelocation{
+		Possible:    true,
+		Cost:        240000,
+		InProgress:  true,
+		Destination: ogame.Coordinate{Galaxy: 3, System: 117, Position: 8, Type: ogame.PlanetType},
+		Countdown:   84123 * time.Second,
+	}, relocation)
+
+	_, err = NewExtractor().ExtractRelocation([]byte(`<div></div>`))
+	assert.Error(t, err)
+}
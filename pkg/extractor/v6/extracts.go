@@ -630,6 +630,153 @@ func extractEspionageReportMessageIDsFromDoc(doc *goquery.Document) ([]ogame.Esp
 	return msgs, nbPage
 }
 
+// extractConversationsFromDoc extracts the conversations list of the chat page, with the last message of each as preview.
+// The selectors were written without a capture of the chat page and are not verified.
+func extractConversationsFromDoc(doc *goquery.Document, location *time.Location) []ogame.Conversation {
+	out := make([]ogame.Conversation, 0)
+	doc.Find("ul.playerlist li.playerlist_item").Each(func(i int, s *goquery.Selection) {
+		c := ogame.Conversation{}
+		c.PlayerID = utils.DoParseI64(s.AttrOr("data-playerid", "0"))
+		c.AssociationID = utils.DoParseI64(s.AttrOr("data-associationid", "0"))
+		if c.PlayerID == 0 && c.AssociationID == 0 {
+			return
+		}
+		c.Name = strings.TrimSpace(s.Find(".playername").Text())
+		c.Unread = s.HasClass("msg_new")
+		if text := strings.TrimSpace(s.Find(".msg_content").Text()); text != "" {
+			msg := ogame.ConversationMessage{Text: text}
+			msg.Date, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+			c.Preview = msg
+		}
+		out = append(out, c)
+	})
+	return out
+}
+
+// extractConversationMessagesFromDoc extracts the history of the conversation open in the chat page, oldest first.
+// Our own messages have the "odd" class. In an alliance conversation the other senders are only known by name.
+// The selectors were written without a capture of the chat page and are not verified.
+func extractConversationMessagesFromDoc(doc *goquery.Document, ownPlayerID int64, location *time.Location) []ogame.ConversationMessage {
+	out := make([]ogame.ConversationMessage, 0)
+	chat := doc.Find("ul.largeChat")
+	partnerID := utils.DoParseI64(chat.AttrOr("data-playerid", "0"))
+	chat.Find("li.chat_msg").Each(func(i int, s *goquery.Selection) {
+		id, err := utils.ParseI64(s.AttrOr("data-chat-id", ""))
+		if err != nil {
+			return
+		}
+		msg := ogame.ConversationMessage{ID: id}
+		msg.SenderID = partnerID
+		if s.HasClass("odd") {
+			msg.SenderID = ownPlayerID
+		}
+		msg.SenderName = strings.TrimSpace(s.Find(".playername").Text())
+		msg.Text = strings.TrimSpace(s.Find(".msg_content").Text())
+		msg.Date, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		out = append(out, msg)
+	})
+	return out
+}
+
+// extractChatBarConversationsFromDoc extracts the conversations of the chat bar found at the bottom of the ingame pages,
+// player and alliance conversations with their last messages, oldest first. Our own messages have the "odd" class,
+// in an alliance conversation the other senders are only known by name. System messages are skipped.
+func extractChatBarConversationsFromDoc(doc *goquery.Document, ownPlayerID int64, location *time.Location) []ogame.Conversation {
+	out := make([]ogame.Conversation, 0)
+	doc.Find("#chatBar li.chat_bar_list_item").Each(func(i int, s *goquery.Selection) {
+		c := ogame.Conversation{}
+		c.PlayerID = utils.DoParseI64(s.AttrOr("data-playerid", "0"))
+		c.AssociationID = utils.DoParseI64(s.AttrOr("data-associationid", "0"))
+		if c.PlayerID == 0 && c.AssociationID == 0 {
+			return
+		}
+		c.Name = strings.TrimSpace(s.Find(".cb_playername").Text())
+		c.Unread = utils.DoParseI64(s.Find(".new_msg_count").AttrOr("data-new-messages", "0")) > 0
+		c.Messages = make([]ogame.ConversationMessage, 0)
+		s.Find("ul.chat li.chat_msg").Each(func(i int, m *goquery.Selection) {
+			id, err := utils.ParseI64(m.AttrOr("data-chat-id", ""))
+			if err != nil || m.HasClass("sys_msg") {
+				return
+			}
+			msg := ogame.ConversationMessage{ID: id}
+			msg.SenderID = c.PlayerID
+			if m.HasClass("odd") {
+				msg.SenderID = ownPlayerID
+			}
+			msg.SenderName = strings.TrimSpace(m.Find(".msg_title").Text())
+			msg.Text = strings.TrimSpace(m.Find(".msg_content").Text())
+			msg.Date, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(m.Find(".msg_date").Text()), location)
+			c.Messages = append(c.Messages, msg)
+		})
+		out = append(out, c)
+	})
+	return out
+}
+
+func extractMessagesFromDoc(doc *goquery.Document, tabID ogame.MessagesTabID, location *time.Location) ([]ogame.Message, int64) {
+	msgs := make([]ogame.Message, 0)
+	nbPage := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"))
+	doc.Find("li.msg").Each(func(i int, s *goquery.Selection) {
+		id, err := utils.ParseI64(s.AttrOr("data-msg-id", ""))
+		if err != nil {
+			return
+		}
+		msg := ogame.Message{ID: id, TabID: tabID}
+		msg.Title = strings.TrimSpace(s.Find(".msg_title").Text())
+		msg.Sender = strings.TrimSpace(s.Find(".msg_sender").Text())
+		msg.Content = strings.TrimSpace(s.Find(".msg_content").Text())
+		msg.CreatedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		msg.IsRead = !s.HasClass("msg_new")
+		msg.IsFavorite = s.Find(".icon_favorited").Size() > 0
+		msgs = append(msgs, msg)
+	})
+	return msgs, nbPage
+}
+
+// extractEspionageActionMessagesFromDoc extracts the "foreign fleet sighted" messages from the espionage tab,
+// the espionage reports are ignored.
+func extractEspionageActionMessagesFromDoc(doc *goquery.Document, location *time.Location) ([]ogame.EspionageActionMessage, int64) {
+	msgs := make([]ogame.EspionageActionMessage, 0)
+	nbPage := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"))
+	extractCoordFromLink := func(link *goquery.Selection) (ogame.Coordinate, string) {
+		txt := strings.TrimSpace(link.Text())
+		coord := ExtractCoord(txt)
+		coord.Type = ogame.PlanetType
+		if link.Find("figure").HasClass("moon") {
+			coord.Type = ogame.MoonType
+		}
+		name := strings.TrimSpace(regexp.MustCompile(`\[\d+:\d+:\d+]`).ReplaceAllString(txt, ""))
+		return coord, name
+	}
+	doc.Find("li.msg").Each(func(i int, s *goquery.Selection) {
+		defText := s.Find("span.espionageDefText")
+		if defText.Size() == 0 {
+			return
+		}
+		id, err := utils.ParseI64(s.AttrOr("data-msg-id", ""))
+		if err != nil {
+			return
+		}
+		msg := ogame.EspionageActionMessage{ID: id}
+		links := defText.Find("a")
+		msg.Origin, msg.OriginName = extractCoordFromLink(links.First())
+		msg.Target, _ = extractCoordFromLink(links.Last())
+		player := defText.Find("span.player")
+		msg.PlayerName = strings.TrimSpace(player.Text())
+		m := regexp.MustCompile(`data-playerId="(\d+)"`).FindStringSubmatch(player.AttrOr("title", ""))
+		if len(m) == 2 {
+			msg.PlayerID = utils.DoParseI64(m[1])
+		}
+		m = regexp.MustCompile(`(\d+)\s*%`).FindStringSubmatch(defText.Text())
+		if len(m) == 2 {
+			msg.CounterEspionageChance = utils.DoParseI64(m[1])
+		}
+		msg.CreatedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		msgs = append(msgs, msg)
+	})
+	return msgs, nbPage
+}
+
 func extractCombatReportMessagesFromDoc(doc *goquery.Document) ([]ogame.CombatReportSummary, int64) {
 	msgs := make([]ogame.CombatReportSummary, 0)
 	nbPage := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"))
@@ -1402,12 +1549,109 @@ func extractTechnocratFromDoc(doc *goquery.Document) bool {
 	return doc.Find("div#officers a.technocrat").HasClass("on")
 }
 
+// officerRemainingRgx matches the remaining time in the tooltip of an active officer,
+// eg: "Hire Commander|Still active for more than 4 days", "Commander anheuern|Noch mehr als 788 Tage aktiv"
+var officerRemainingRgx = regexp.MustCompile(`(\d+)\s*([^\d\s<]*)`)
+
+// officerRemainingUnits prefixes of the time units used in the officers tooltip, for every language.
+// Minutes are checked first so "min" never matches an hour or day word.
+var officerRemainingUnits = []struct {
+	prefixes []string
+	unit     time.Duration
+}{
+	{[]string{"min", "perc", "dakika", "минут", "λεπτ", "分"}, time.Minute},
+	{[]string{"hour", "stunde", "heure", "hora", "ora", "ore", "óra", "uur", "godzin", "hodin", "hodín", "sat", "saat", "tim", "час", "ώρ", "小時", "小时", "時間"}, time.Hour},
+	{[]string{"day", "tag", "jour", "día", "dia", "giorn", "dag", "dni", "dzie", "dny", "dní", "dn", "den", "dan", "nap", "gün", "ден", "дн", "ημέρ", "μέρ", "天", "日"}, 24 * time.Hour},
+}
+
+// extractOfficerRemaining returns the remaining time of an officer from its tooltip.
+// An unknown unit is read as hours, so the officer is renewed too early rather than too late.
+func extractOfficerRemaining(title string) time.Duration {
+	parts := strings.SplitN(title, "|", 2)
+	if len(parts) != 2 {
+		return 0
+	}
+	m := officerRemainingRgx.FindStringSubmatch(parts[1])
+	if len(m) != 3 {
+		return 0
+	}
+	nbr := time.Duration(utils.DoParseI64(m[1]))
+	unit := strings.ToLower(m[2])
+	for _, u := range officerRemainingUnits {
+		for _, prefix := range u.prefixes {
+			if strings.HasPrefix(unit, prefix) {
+				return nbr * u.unit
+			}
+		}
+	}
+	return nbr * time.Hour
+}
+
+func extractOfficersFromDoc(doc *goquery.Document) ogame.Officers {
+	officer := func(typ ogame.OfficerType, class string) ogame.OfficerStatus {
+		s := doc.Find("div#officers a." + class)
+		status := ogame.OfficerStatus{Type: typ, Active: s.HasClass("on")}
+		if status.Active {
+			status.Remaining = extractOfficerRemaining(s.AttrOr("title", ""))
+		}
+		return status
+	}
+	return ogame.Officers{
+		Commander:            officer(ogame.CommanderOfficer, "commander"),
+		Admiral:              officer(ogame.AdmiralOfficer, "admiral"),
+		Engineer:             officer(ogame.EngineerOfficer, "engineer"),
+		Geologist:            officer(ogame.GeologistOfficer, "geologist"),
+		Technocrat:           officer(ogame.TechnocratOfficer, "technocrat"),
+		CommandingStaffBonus: doc.Find("div#officers").HasClass("all"),
+	}
+}
+
 func extractAbandonInformation(doc *goquery.Document) (string, string) {
 	abandonToken := doc.Find("form#planetMaintenanceDelete input[name=abandon]").AttrOr("value", "")
 	token := doc.Find("form#planetMaintenanceDelete input[name=token]").AttrOr("value", "")
 	return abandonToken, token
 }
 
+// extractPlanetLayerFromDoc parses the rename/abandon overlay (samples/unversioned/abandon_form.html).
+// The rename form only holds the new name, the token is the one of the abandon form.
+func extractPlanetLayerFromDoc(doc *goquery.Document) (ogame.PlanetLayer, error) {
+	res := ogame.PlanetLayer{}
+	if doc.Find("form#planetMaintenance").Size() == 0 {
+		return res, errors.New("planet layer not found")
+	}
+	res.Name = strings.TrimSpace(doc.Find("td#giveupName").Text())
+	res.AbandonToken, res.Token = extractAbandonInformation(doc)
+	return res, nil
+}
+
+// extractRelocationFromDoc parses the relocation link of the overview (div#planetOptions).
+// A moon only displays an inactive relocation icon, a planet displays the relocation link whose tooltip gives the cost.
+// No capture shows a reserved relocation or a cooldown: the countdown selectors are not verified.
+func extractRelocationFromDoc(doc *goquery.Document) (ogame.PlanetRelocation, error) {
+	res := ogame.PlanetRelocation{}
+	options := doc.Find("div#planetOptions")
+	if options.Size() == 0 {
+		return res, errors.New("planet options not found")
+	}
+	start := options.Find("div.planetMoveStart")
+	res.Possible = start.Size() > 0 && options.Find(".planetMoveInactive").Size() == 0
+	// The cost is the only number with a thousands separator of the tooltip ("... charged 240.000 Dark Matter ...")
+	if m := regexp.MustCompile(`\d{1,3}(?:[.,\x{00a0} ]\d{3})+`).FindString(start.Find("a").AttrOr("title", "")); m != "" {
+		res.Cost = utils.ParseInt(strings.NewReplacer("\u00a0", "", " ", "").Replace(m))
+	}
+	parseDuration := func(s *goquery.Selection) time.Duration {
+		return time.Duration(utils.DoParseI64(s.AttrOr("data-duration", "0"))) * time.Second
+	}
+	res.Cooldown = parseDuration(options.Find(".planetMoveCooldown .countdown"))
+	if progress := options.Find(".planetMoveActive"); progress.Size() > 0 {
+		res.InProgress = true
+		res.Destination = ExtractCoord(progress.Find("a").First().Text())
+		res.Destination.Type = ogame.PlanetType
+		res.Countdown = parseDuration(progress.Find(".countdown"))
+	}
+	return res, nil
+}
+
 func extractPlanetCoordinate(pageHTML []byte) (ogame.Coordinate, error) {
 	m := regexp.MustCompile(`<meta name="ogame-planet-coordinates" content="(\d+):(\d+):(\d+)"/>`).FindSubmatch(pageHTML)
 	if len(m) == 0 {
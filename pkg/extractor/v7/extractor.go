@@ -42,6 +42,12 @@ func (e Extractor) ExtractExpeditionMessages(pageHTML []byte) ([]ogame.Expeditio
 	return e.ExtractExpeditionMessagesFromDoc(doc)
 }
 
+// ExtractHarvestReports ...
+func (e Extractor) ExtractHarvestReports(pageHTML []byte) ([]ogame.HarvestReport, int64, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractHarvestReportsFromDoc(doc)
+}
+
 // ExtractMarketplaceMessages ...
 func (e Extractor) ExtractMarketplaceMessages(pageHTML []byte) ([]ogame.MarketplaceMessage, int64, error) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -164,7 +170,17 @@ func (e Extractor) ExtractDefenseFromDoc(doc *goquery.Document) (ogame.DefensesI
 
 // ExtractExpeditionMessagesFromDoc ...
 func (e Extractor) ExtractExpeditionMessagesFromDoc(doc *goquery.Document) ([]ogame.ExpeditionMessage, int64, error) {
-	return extractExpeditionMessagesFromDoc(doc, e.GetLocation())
+	return extractExpeditionMessagesFromDoc(doc, e.GetLocation(), e.GetLanguage())
+}
+
+// ExtractHarvestReportsFromDoc ...
+func (e Extractor) ExtractHarvestReportsFromDoc(doc *goquery.Document) ([]ogame.HarvestReport, int64, error) {
+	return extractHarvestReportsFromDoc(doc, e.GetLocation(), e.GetLanguage())
+}
+
+// ExtractExpeditionOutcome ...
+func (e Extractor) ExtractExpeditionOutcome(content string) ogame.ExpeditionOutcome {
+	return extractExpeditionOutcome(content, e.GetLanguage())
 }
 
 // ExtractMarketplaceMessagesFromDoc ...
@@ -216,3 +232,25 @@ func (e Extractor) ExtractCancelResearchInfos(pageHTML []byte) (token string, te
 func (e Extractor) ExtractCharacterClassFromDoc(doc *goquery.Document) (ogame.CharacterClass, error) {
 	return extractCharacterClassFromDoc(doc)
 }
+
+// ExtractCharacterClassSelection ...
+func (e Extractor) ExtractCharacterClassSelection(pageHTML []byte) (ogame.CharacterClassSelection, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractCharacterClassSelectionFromDoc(doc)
+}
+
+// ExtractCharacterClassSelectionFromDoc ...
+func (e Extractor) ExtractCharacterClassSelectionFromDoc(doc *goquery.Document) (ogame.CharacterClassSelection, error) {
+	return extractCharacterClassSelectionFromDoc(doc)
+}
+
+// ExtractAllianceClassSelection ...
+func (e Extractor) ExtractAllianceClassSelection(pageHTML []byte) (ogame.AllianceClassSelection, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractAllianceClassSelectionFromDoc(doc)
+}
+
+// ExtractAllianceClassSelectionFromDoc ...
+func (e Extractor) ExtractAllianceClassSelectionFromDoc(doc *goquery.Document) (ogame.AllianceClassSelection, error) {
+	return extractAllianceClassSelectionFromDoc(doc)
+}
@@ -1,10 +1,13 @@
 package v7
 
 import (
+	"bytes"
+	"github.com/PuerkitoBio/goquery"
 	"github.com/alaingilbert/clockwork"
 	"github.com/alaingilbert/ogame/pkg/ogame"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 )
@@ -221,6 +224,109 @@ func TestExtractExpeditionMessages(t *testing.T) {
 	assert.Equal(t, ogame.Coordinate{1, 8, 16, ogame.PlanetType}, msgs[0].Coordinate)
 	assert.Equal(t, `We came across the remains of a previous expedition! Our technicians will try to get some of the ships to work again.<br/><br/>The following ships are now part of the fleet:<br/>Espionage Probe: 1880<br/>Light Fighter: 161<br/>Small Cargo: 156`,
 		msgs[0].Content)
+	assert.Equal(t, ogame.ShipsFound, msgs[0].Outcome.Type)
+	assert.Equal(t, ogame.ShipsInfos{EspionageProbe: 1880, LightFighter: 161, SmallCargo: 156}, msgs[0].Outcome.Ships)
+	assert.Equal(t, ogame.ResourcesFound, msgs[1].Outcome.Type)
+	assert.Equal(t, ogame.Resources{Metal: 900000}, msgs[1].Outcome.Resources)
+	assert.Equal(t, ogame.NothingFound, msgs[2].Outcome.Type)
+	assert.Equal(t, ogame.PiratesAttack, msgs[3].Outcome.Type)
+	assert.Equal(t, ogame.ShipsFound, msgs[4].Outcome.Type)
+	assert.Equal(t, ogame.ShipsInfos{EspionageProbe: 578, SmallCargo: 1270, LightFighter: 10}, msgs[4].Outcome.Ships)
+	assert.Equal(t, ogame.PiratesAttack, msgs[5].Outcome.Type)
+	assert.Equal(t, ogame.DarkMatterFound, msgs[6].Outcome.Type)
+	assert.Equal(t, int64(371), msgs[6].Outcome.DarkMatter)
+	assert.Equal(t, ogame.NothingFound, msgs[7].Outcome.Type)
+	assert.Equal(t, ogame.ShipsFound, msgs[8].Outcome.Type)
+	assert.Equal(t, ogame.ShipsInfos{LightFighter: 149, LargeCargo: 50, EspionageProbe: 1625, SmallCargo: 7}, msgs[8].Outcome.Ships)
+	assert.Equal(t, ogame.PiratesAttack, msgs[9].Outcome.Type)
+}
+
+func TestExtractHarvestReports(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/harvest_messages_synthetic.html")
+	e := NewExtractor()
+	e.SetLocation(time.FixedZone("OGT", 3600))
+	msgs, nbPages, err := e.ExtractHarvestReports(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), nbPages)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, int64(11203410), msgs[0].ID)
+	assert.Equal(t, time.Date(2020, 4, 22, 0, 34, 52, 0, time.UTC), msgs[0].CreatedAt.UTC())
+	assert.Equal(t, ogame.Coordinate{1, 8, 16, ogame.DebrisType}, msgs[0].Coordinate)
+	assert.Equal(t, ogame.ShipsInfos{Pathfinder: 3}, msgs[0].Ships)
+	assert.Equal(t, int64(30000), msgs[0].Capacity)
+	assert.Equal(t, ogame.Resources{Metal: 125000, Crystal: 48500}, msgs[0].Debris)
+	assert.Equal(t, ogame.Resources{Metal: 21000, Crystal: 9000}, msgs[0].Harvested)
+	assert.Equal(t, ogame.Coordinate{1, 9, 7, ogame.DebrisType}, msgs[1].Coordinate)
+	assert.Equal(t, ogame.ShipsInfos{Recycler: 12}, msgs[1].Ships)
+	assert.Equal(t, int64(240000), msgs[1].Capacity)
+	assert.Equal(t, ogame.Resources{Metal: 61200, Crystal: 33000, Deuterium: 1500}, msgs[1].Debris)
+	assert.Equal(t, ogame.Resources{Metal: 61200, Crystal: 33000, Deuterium: 1500}, msgs[1].Harvested)
+
+	// No report for this language, nothing is guessed
+	e.SetLanguage("fr")
+	msgs, _, err = e.ExtractHarvestReports(pageHTMLBytes)
+	assert.ErrorIs(t, err, ogame.ErrUnsupportedLanguage)
+	assert.Equal(t, 0, len(msgs))
+}
+
+func TestExtractExpeditionOutcome(t *testing.T) {
+	e := NewExtractor()
+	out := e.ExtractExpeditionOutcome(`Your expedition fleet had an unfriendly first contact with an unknown species.`)
+	assert.Equal(t, ogame.AliensAttack, out.Type)
+	out = e.ExtractExpeditionOutcome(`The only thing left from the expedition was the following radio transmission: Zzzrrt Oh no! Krrrzzzzt.`)
+	assert.Equal(t, ogame.BlackHole, out.Type)
+	out = e.ExtractExpeditionOutcome(`Your expedition went into a sector full of particle storms. The expedition is going to return with a big delay.`)
+	assert.Equal(t, ogame.LateReturn, out.Type)
+	out = e.ExtractExpeditionOutcome(`An unexpected back coupling in the energy spools of the engines hastened the expeditions return, it returns home earlier than expected.`)
+	assert.Equal(t, ogame.EarlyReturn, out.Type)
+	out = e.ExtractExpeditionOutcome(`A fleeing fleet left an item behind.<br/><br/><a href="#" data-item-ref="de922af379061263a56d7204d1c395cefcfb7d75">Gold Metal Booster</a> has been added to the inventory.`)
+	assert.Equal(t, ogame.ItemFound, out.Type)
+	assert.Equal(t, "Gold Metal Booster", out.Item)
+
+	out = e.ExtractExpeditionOutcome(`Your expedition found something we cannot classify.`)
+	assert.Equal(t, ogame.UnknownExpeditionOutcome, out.Type)
+
+	// Languages without a messages sample only recognize ships and resources, the rest is unknown
+	e.SetLanguage("fr")
+	out = e.ExtractExpeditionOutcome(`Les vaisseaux suivants font maintenant partie de la flotte :<br/><br/>Chasseur léger: 12<br/>Grand transporteur: 3`)
+	assert.Equal(t, ogame.ShipsFound, out.Type)
+	assert.Equal(t, ogame.ShipsInfos{LightFighter: 12, LargeCargo: 3}, out.Ships)
+	out = e.ExtractExpeditionOutcome(`Votre expédition a découvert un petit astéroïde.<br/><br/>Cristal 12.345 ont été capturés.`)
+	assert.Equal(t, ogame.ResourcesFound, out.Type)
+	assert.Equal(t, ogame.Resources{Crystal: 12345}, out.Resources)
+	out = e.ExtractExpeditionOutcome(`Votre flotte d'expédition a eu un premier contact peu amical avec une espèce inconnue.`)
+	assert.Equal(t, ogame.UnknownExpeditionOutcome, out.Type)
+}
+
+func TestExpeditionKeywordsResourcesBar(t *testing.T) {
+	samples := map[string]string{"ba": "v7.5.1", "fi": "v7.2", "hu": "v7.2", "si": "v7.2"}
+	for lang, kw := range expeditionKeywordsByLang {
+		version, ok := samples[lang]
+		if !ok {
+			version = "v7.1"
+		}
+		pageHTMLBytes, err := ioutil.ReadFile("../../../samples/" + version + "/" + lang + "/shipyard.html")
+		if !assert.NoError(t, err, lang) {
+			continue
+		}
+		doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTMLBytes))
+		names := make(map[string]string)
+		for _, res := range []string{"metal", "crystal", "deuterium", "darkmatter"} {
+			title := doc.Find("li#"+res+"_box").AttrOr("title", "")
+			names[res] = normalizeExpeditionText(strings.Split(title, "|")[0])
+		}
+		assert.True(t, containsAny(names["metal"], kw.metal), lang)
+		assert.True(t, containsAny(names["crystal"], kw.crystal), lang)
+		assert.True(t, containsAny(names["deuterium"], kw.deuterium), lang)
+		assert.True(t, containsAny(names["darkmatter"], kw.darkMatter), lang)
+
+		e := NewExtractor()
+		e.SetLanguage(lang)
+		out := e.ExtractExpeditionOutcome(lang + `<br/><br/>` + names["deuterium"] + ` 1.234`)
+		assert.Equal(t, ogame.Resources{Deuterium: 1234}, out.Resources, lang)
+		out = e.ExtractExpeditionOutcome(lang + `<br/><br/>` + names["darkmatter"] + ` 750`)
+		assert.Equal(t, int64(750), out.DarkMatter, lang)
+	}
 }
 
 func TestExtractGalaxyExpeditionDebrisDM(t *testing.T) {
@@ -319,3 +425,27 @@ func TestGetConstructionsV7(t *testing.T) {
 	assert.Equal(t, ogame.EnergyTechnologyID, researchID)
 	assert.Equal(t, int64(271), researchCountdown)
 }
+
+func TestExtractCharacterClassSelection(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/characterclassselection_synthetic.html")
+	selection, err := NewExtractor().ExtractCharacterClassSelection(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, ogame.General, selection.Current)
+	assert.Equal(t, "4f1c9b2e7a3d5e6f8091a2b3c4d5e6f7", selection.Token)
+	assert.Equal(t, 3, len(selection.Options))
+	assert.Equal(t, ogame.CharacterClassOption{Class: ogame.Collector, Name: "Collector", Price: 500000}, selection.Options[0])
+	option, _ := selection.Option(ogame.General)
+	assert.True(t, option.Selected)
+	assert.Equal(t, int64(0), option.Price)
+}
+
+func TestExtractAllianceClassSelection(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/allianceClassSelection_synthetic.html")
+	selection, err := NewExtractor().ExtractAllianceClassSelection(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, ogame.Trader, selection.Current)
+	assert.True(t, selection.CanChange)
+	assert.Equal(t, "0a1b2c3d4e5f60718293a4b5c6d7e8f9", selection.Token)
+	option, _ := selection.Option(ogame.Researcher)
+	assert.Equal(t, ogame.AllianceClassOption{Class: ogame.Researcher, Name: "Researchers", Price: 300000}, option)
+}
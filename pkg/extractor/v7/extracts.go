@@ -7,12 +7,17 @@ import (
 	"github.com/alaingilbert/ogame/pkg/extractor/v6"
 	"github.com/alaingilbert/ogame/pkg/ogame"
 	"github.com/alaingilbert/ogame/pkg/utils"
+	"html"
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/alaingilbert/clockwork"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 func GetNbr(doc *goquery.Document, name string) int64 {
@@ -26,7 +31,7 @@ func getNbrShips(doc *goquery.Document, name string) int64 {
 }
 
 func extractPremiumToken(pageHTML []byte, days int64) (token string, err error) {
-	rgx := regexp.MustCompile(`\?page=premium&buynow=1&type=\d&days=` + utils.FI64(days) + `&token=(\w+)`)
+	rgx := regexp.MustCompile(`\?page=premium&buynow=1&type=\d+&days=` + utils.FI64(days) + `&token=(\w+)`)
 	m := rgx.FindSubmatch(pageHTML)
 	if len(m) < 2 {
 		return "", errors.New("unable to find token")
@@ -711,7 +716,62 @@ func extractCharacterClassFromDoc(doc *goquery.Document) (ogame.CharacterClass,
 	return 0, errors.New("character class not found")
 }
 
-func extractExpeditionMessagesFromDoc(doc *goquery.Document, location *time.Location) ([]ogame.ExpeditionMessage, int64, error) {
+func extractClassSelectionToken(doc *goquery.Document) (string, error) {
+	m := regexp.MustCompile(`var token\s?=\s?"([^"]*)";`).FindStringSubmatch(doc.Find("script").Text())
+	if len(m) != 2 {
+		return "", errors.New("failed to find token")
+	}
+	return m[1], nil
+}
+
+// No capture of the character class selection page is in the samples: these selectors are not verified.
+func extractCharacterClassSelectionFromDoc(doc *goquery.Document) (ogame.CharacterClassSelection, error) {
+	res := ogame.CharacterClassSelection{}
+	if doc.Find("div#characterclassselection").Size() == 0 {
+		return res, errors.New("character class selection not found")
+	}
+	doc.Find("div.characterclass[data-character-class-id]").Each(func(i int, s *goquery.Selection) {
+		option := ogame.CharacterClassOption{
+			Class:    ogame.CharacterClass(utils.DoParseI64(s.AttrOr("data-character-class-id", ""))),
+			Name:     strings.TrimSpace(s.Find(".characterClassName").Text()),
+			Price:    utils.DoParseI64(s.Find("button[data-price]").AttrOr("data-price", "0")),
+			Selected: s.HasClass("selected"),
+		}
+		if option.Selected {
+			res.Current = option.Class
+		}
+		res.Options = append(res.Options, option)
+	})
+	var err error
+	res.Token, err = extractClassSelectionToken(doc)
+	return res, err
+}
+
+// No capture of the alliance class selection tab is in the samples: these selectors are not verified.
+func extractAllianceClassSelectionFromDoc(doc *goquery.Document) (ogame.AllianceClassSelection, error) {
+	res := ogame.AllianceClassSelection{}
+	if doc.Find("div#allianceclassselection").Size() == 0 {
+		return res, errors.New("alliance class selection not found")
+	}
+	doc.Find("div.allianceclass[data-alliance-class-id]").Each(func(i int, s *goquery.Selection) {
+		option := ogame.AllianceClassOption{
+			Class:    ogame.AllianceClass(utils.DoParseI64(s.AttrOr("data-alliance-class-id", ""))),
+			Name:     strings.TrimSpace(s.Find(".allianceClassName").Text()),
+			Price:    utils.DoParseI64(s.Find("button[data-price]").AttrOr("data-price", "0")),
+			Selected: s.HasClass("selected"),
+		}
+		if option.Selected {
+			res.Current = option.Class
+		}
+		res.Options = append(res.Options, option)
+	})
+	// Selection buttons are only displayed to the alliance founder
+	res.CanChange = doc.Find("button.allianceclass_select_button").Size() > 0
+	res.Token, _ = extractClassSelectionToken(doc)
+	return res, nil
+}
+
+func extractExpeditionMessagesFromDoc(doc *goquery.Document, location *time.Location, lang string) ([]ogame.ExpeditionMessage, int64, error) {
 	msgs := make([]ogame.ExpeditionMessage, 0)
 	nbPage := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"))
 	doc.Find("li.msg").Each(func(i int, s *goquery.Selection) {
@@ -723,6 +783,7 @@ func extractExpeditionMessagesFromDoc(doc *goquery.Document, location *time.Loca
 				msg.Coordinate.Type = ogame.PlanetType
 				msg.Content, _ = s.Find("span.msg_content").Html()
 				msg.Content = strings.TrimSpace(msg.Content)
+				msg.Outcome = extractExpeditionOutcome(msg.Content, lang)
 				msgs = append(msgs, msg)
 			}
 		}
@@ -730,6 +791,62 @@ func extractExpeditionMessagesFromDoc(doc *goquery.Document, location *time.Loca
 	return msgs, nbPage, nil
 }
 
+// harvestReportRgxByLang harvest report content per server language.
+// The report has no markup around its values, each value is matched by its label in the sentence.
+// Only english is listed, its sentence is not verified against a real report (testdata/harvest_messages_synthetic.html),
+// the other languages return ogame.ErrUnsupportedLanguage.
+var harvestReportRgxByLang = map[string]*regexp.Regexp{
+	"en": regexp.MustCompile(`^Your ([\d.,]+) (.+) have a total cargo capacity of ([\d.,]+)\. ` +
+		`At the target ([\d.,]+) Metal, ([\d.,]+) Crystal and ([\d.,]+) Deuterium are floating in space\. ` +
+		`You have harvested ([\d.,]+) Metal, ([\d.,]+) Crystal and ([\d.,]+) Deuterium\.$`),
+}
+
+// extractHarvestReportsFromDoc extracts the harvest reports from the "other" fleet messages tab.
+// The coordinate comes from the title link, the other messages of the tab (fleet returns...) don't match the report content.
+func extractHarvestReportsFromDoc(doc *goquery.Document, location *time.Location, lang string) ([]ogame.HarvestReport, int64, error) {
+	msgs := make([]ogame.HarvestReport, 0)
+	nbPage := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"))
+	rgx, ok := harvestReportRgxByLang[lang]
+	if !ok {
+		return msgs, nbPage, ogame.ErrUnsupportedLanguage
+	}
+	doc.Find("li.msg").Each(func(i int, s *goquery.Selection) {
+		id, err := utils.ParseI64(s.AttrOr("data-msg-id", ""))
+		if err != nil {
+			return
+		}
+		titleLink := s.Find(".msg_title a")
+		if titleLink.Size() == 0 {
+			return
+		}
+		content := strings.Join(strings.Fields(s.Find("span.msg_content").Text()), " ")
+		m := rgx.FindStringSubmatch(content)
+		if len(m) != 10 {
+			return
+		}
+		msg := ogame.HarvestReport{ID: id}
+		msg.CreatedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		msg.Coordinate = v6.ExtractCoord(titleLink.Text())
+		msg.Coordinate.Type = ogame.DebrisType
+		msg.Capacity = utils.ParseInt(m[3])
+		msg.Debris = ogame.Resources{Metal: utils.ParseInt(m[4]), Crystal: utils.ParseInt(m[5]), Deuterium: utils.ParseInt(m[6])}
+		msg.Harvested = ogame.Resources{Metal: utils.ParseInt(m[7]), Crystal: utils.ParseInt(m[8]), Deuterium: utils.ParseInt(m[9])}
+		if shipID := extractHarvestShipID(m[2]); shipID.IsShip() {
+			msg.Ships.Set(shipID, utils.ParseInt(m[1]))
+		}
+		msgs = append(msgs, msg)
+	})
+	return msgs, nbPage, nil
+}
+
+// extractHarvestShipID finds the ship of a harvest report from its name, the name can be plural
+func extractHarvestShipID(name string) ogame.ID {
+	if id := ogame.ShipName2ID(name); id.IsShip() {
+		return id
+	}
+	return ogame.ShipName2ID(strings.TrimSuffix(name, "s"))
+}
+
 func extractMarketplaceMessagesFromDoc(doc *goquery.Document, location *time.Location) ([]ogame.MarketplaceMessage, int64, error) {
 	msgs := make([]ogame.MarketplaceMessage, 0)
 	tab := utils.DoParseI64(doc.Find("ul.pagination li").Last().AttrOr("data-tab", ""))
@@ -760,3 +877,150 @@ func extractMarketplaceMessagesFromDoc(doc *goquery.Document, location *time.Loc
 	})
 	return msgs, nbPage, nil
 }
+
+// expeditionKeywords phrases used to classify expedition messages, per server language.
+// Keywords are compared once lowercased and stripped of their accents.
+type expeditionKeywords struct {
+	metal      []string
+	crystal    []string
+	deuterium  []string
+	darkMatter []string
+	pirates    []string
+	aliens     []string
+	blackHole  []string
+	early      []string
+	late       []string
+	nothing    []string
+}
+
+// expeditionKeywordsByLang the events phrases come from the only real expedition messages we have (samples/v7.2/en/expedition_messages.html).
+// For the other languages, the resources names are the ones of the resources bar of the <lang>/shipyard.html samples,
+// so messages are classified when ships, resources, dark matter or an item are found, every other event is unknown.
+var expeditionKeywordsByLang = map[string]expeditionKeywords{
+	"en": {metal: []string{"metal"}, crystal: []string{"crystal"}, deuterium: []string{"deuterium"}, darkMatter: []string{"dark matter"},
+		pirates:   []string{"space pirates", "some pirates", "primitive barbarians"},
+		aliens:    []string{"unknown species", "exotic looking ships"},
+		blackHole: []string{"black hole", "zzzrrt"},
+		early:     []string{"earlier than expected", "hastened the expeditions return"},
+		late:      []string{"return with a big delay", "will take longer"},
+		nothing:   []string{"without any results", "cannot continue in these conditions", "empty handed"}},
+	"ar": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deuterio"}, darkMatter: []string{"Materia Oscura"}},
+	"ba": {metal: []string{"Metal"}, crystal: []string{"Kristal"}, deuterium: []string{"Deuterij"}, darkMatter: []string{"Crna Materija"}},
+	"br": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deutério"}, darkMatter: []string{"Matéria Negra"}},
+	"cz": {metal: []string{"Kov"}, crystal: []string{"Krystaly"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Temná Hmota"}},
+	"de": {metal: []string{"Metall"}, crystal: []string{"Kristall"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Dunkle Materie"}},
+	"dk": {metal: []string{"Metal"}, crystal: []string{"Krystal"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Mørk Materie"}},
+	"es": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deuterio"}, darkMatter: []string{"Materia Oscura"}},
+	"fi": {metal: []string{"Metalli"}, crystal: []string{"Kristalli"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Pimeä Materia"}},
+	"fr": {metal: []string{"Métal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deutérium"}, darkMatter: []string{"Antimatière"}},
+	"gr": {metal: []string{"Μέταλλο"}, crystal: []string{"Κρύσταλλο"}, deuterium: []string{"Δευτέριο"}, darkMatter: []string{"Αντιύλη"}},
+	"hr": {metal: []string{"Metal"}, crystal: []string{"Kristal"}, deuterium: []string{"Deuterij"}, darkMatter: []string{"Crna Materija"}},
+	"hu": {metal: []string{"Fém"}, crystal: []string{"Kristály"}, deuterium: []string{"Deutérium"}, darkMatter: []string{"Sötét Anyag"}},
+	"it": {metal: []string{"Metallo"}, crystal: []string{"Cristallo"}, deuterium: []string{"Deuterio"}, darkMatter: []string{"Materia Oscura"}},
+	"jp": {metal: []string{"メタル"}, crystal: []string{"クリスタル"}, deuterium: []string{"デューテリウム"}, darkMatter: []string{"ダークマター"}},
+	"mx": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deuterio"}, darkMatter: []string{"Materia Oscura"}},
+	"nl": {metal: []string{"Metaal"}, crystal: []string{"Kristal"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Donkere Materie"}},
+	"no": {metal: []string{"Metall"}, crystal: []string{"Krystall"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Mørkt Materiale"}},
+	"pl": {metal: []string{"Metal"}, crystal: []string{"Kryształ"}, deuterium: []string{"Deuter"}, darkMatter: []string{"Antymateria"}},
+	"pt": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deutério"}, darkMatter: []string{"Matéria Negra"}},
+	"ro": {metal: []string{"Metal"}, crystal: []string{"Cristal"}, deuterium: []string{"Deuteriu"}, darkMatter: []string{"Materie Întunecată"}},
+	"ru": {metal: []string{"Металл"}, crystal: []string{"Кристалл"}, deuterium: []string{"Дейтерий"}, darkMatter: []string{"Темная Материя"}},
+	"si": {metal: []string{"Metal"}, crystal: []string{"Kristal"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Črna materija"}},
+	"sk": {metal: []string{"Kovy"}, crystal: []string{"Kryštály"}, deuterium: []string{"Deutérium"}, darkMatter: []string{"Temná hmota"}},
+	"tr": {metal: []string{"Metal"}, crystal: []string{"Kristal"}, deuterium: []string{"Deuterium"}, darkMatter: []string{"Karanlık Madde"}},
+	"tw": {metal: []string{"金屬"}, crystal: []string{"晶體"}, deuterium: []string{"重氫"}, darkMatter: []string{"暗物質"}},
+}
+
+var expeditionLineBreakRgx = regexp.MustCompile(`(?i)<br\s*/?>`)
+var expeditionTagsRgx = regexp.MustCompile(`<[^>]*>`)
+var expeditionNumberRgx = regexp.MustCompile(`\d[\d.,]*`)
+
+func normalizeExpeditionText(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	s, _, _ = transform.String(t, s)
+	return strings.ToLower(s)
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, k := range keywords {
+		if k != "" && strings.Contains(s, normalizeExpeditionText(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractExpeditionOutcome classifies an expedition message content and extracts the quantities found.
+// Ships are recognized using the ships names of every languages, resources and keywords use the server language.
+// A message that matches nothing is reported as UnknownExpeditionOutcome.
+func extractExpeditionOutcome(content, lang string) (out ogame.ExpeditionOutcome) {
+	kw := expeditionKeywordsByLang[lang]
+	rawLines := expeditionLineBreakRgx.Split(content, -1)
+	lines := make([]string, 0, len(rawLines))
+	for _, l := range rawLines {
+		l = strings.TrimSpace(html.UnescapeString(expeditionTagsRgx.ReplaceAllString(l, "")))
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	// Quantities are on the lines following the description
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		number := expeditionNumberRgx.FindString(line)
+		if number == "" {
+			continue
+		}
+		nbr := utils.ParseInt(number)
+		label := strings.TrimSpace(strings.Replace(line, number, "", 1))
+		if idx := strings.LastIndex(label, ":"); idx != -1 && idx == len(label)-1 {
+			label = label[:idx]
+		}
+		if shipID := ogame.ShipName2ID(label); shipID.IsShip() {
+			out.Type = ogame.ShipsFound
+			out.Ships.Set(shipID, out.Ships.ByID(shipID)+nbr)
+			continue
+		}
+		normalized := normalizeExpeditionText(label)
+		switch {
+		case containsAny(normalized, kw.darkMatter):
+			out.Type = ogame.DarkMatterFound
+			out.DarkMatter += nbr
+		case containsAny(normalized, kw.metal):
+			out.Type = ogame.ResourcesFound
+			out.Resources.Metal += nbr
+		case containsAny(normalized, kw.crystal):
+			out.Type = ogame.ResourcesFound
+			out.Resources.Crystal += nbr
+		case containsAny(normalized, kw.deuterium):
+			out.Type = ogame.ResourcesFound
+			out.Resources.Deuterium += nbr
+		}
+	}
+	if out.Type != ogame.UnknownExpeditionOutcome {
+		return
+	}
+
+	doc, _ := goquery.NewDocumentFromReader(strings.NewReader(content))
+	text := normalizeExpeditionText(strings.Join(lines, " "))
+	if itemLink := doc.Find("a[href*='item'], a[data-item-ref], a[ref]"); itemLink.Length() > 0 {
+		out.Type = ogame.ItemFound
+		out.Item = strings.TrimSpace(itemLink.First().Text())
+		return
+	}
+	switch {
+	case containsAny(text, kw.blackHole):
+		out.Type = ogame.BlackHole
+	case containsAny(text, kw.aliens):
+		out.Type = ogame.AliensAttack
+	case containsAny(text, kw.pirates):
+		out.Type = ogame.PiratesAttack
+	case containsAny(text, kw.late):
+		out.Type = ogame.LateReturn
+	case containsAny(text, kw.early):
+		out.Type = ogame.EarlyReturn
+	case containsAny(text, kw.nothing):
+		out.Type = ogame.NothingFound
+	}
+	return
+}
@@ -73,6 +73,15 @@ func TestExtractBuffActivation(t *testing.T) {
 	token, items, _ := NewExtractor().ExtractBuffActivation(pageHTMLBytes)
 	assert.Equal(t, "081876002bf5791011097597836d3f5c", token)
 	assert.Equal(t, 31, len(items))
+	item, _ := ogame.ItemCatalog(items).ByRef("05294270032e5dc968672425ab5611998c409166")
+	assert.Equal(t, "Gold Metal Booster", item.Name)
+	assert.Equal(t, int64(25000), item.Costs)
+	assert.Equal(t, int64(604800), item.Duration)
+	assert.True(t, item.Buyable)
+	assert.Equal(t, 0.3, item.GetEffect().Production.Metal)
+	item, _ = ogame.ItemCatalog(items).ByRef("77eff880829027daf23b755e14820a60c4c6fd93")
+	assert.Equal(t, int64(500000), item.Costs)
+	assert.Equal(t, 3, len(ogame.ItemCatalog(items).ByKind(ogame.BuildingsTimeItem)))
 }
 
 func TestExtractDMCosts(t *testing.T) {
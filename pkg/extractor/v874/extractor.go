@@ -44,3 +44,25 @@ func (e *Extractor) ExtractBuffActivation(pageHTML []byte) (string, []ogame.Item
 func (e *Extractor) ExtractBuffActivationFromDoc(doc *goquery.Document) (string, []ogame.Item, error) {
 	return extractBuffActivationFromDoc(doc)
 }
+
+// ExtractResourceTrader ...
+func (e *Extractor) ExtractResourceTrader(pageHTML []byte) (ogame.ResourceTrader, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractResourceTraderFromDoc(doc)
+}
+
+// ExtractResourceTraderFromDoc ...
+func (e *Extractor) ExtractResourceTraderFromDoc(doc *goquery.Document) (ogame.ResourceTrader, error) {
+	return extractResourceTraderFromDoc(doc)
+}
+
+// ExtractScrapMerchant ...
+func (e *Extractor) ExtractScrapMerchant(pageHTML []byte) (ogame.ScrapMerchant, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractScrapMerchantFromDoc(doc)
+}
+
+// ExtractScrapMerchantFromDoc ...
+func (e *Extractor) ExtractScrapMerchantFromDoc(doc *goquery.Document) (ogame.ScrapMerchant, error) {
+	return extractScrapMerchantFromDoc(doc)
+}
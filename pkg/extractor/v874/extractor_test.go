@@ -1,6 +1,7 @@
 package v874
 
 import (
+	"github.com/alaingilbert/ogame/pkg/ogame"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
@@ -18,3 +19,24 @@ func TestExtractAuction(t *testing.T) {
 	res, _ := NewExtractor().ExtractAuction(pageHTMLBytes)
 	assert.Equal(t, "43576386810cdf91a833a6239f323f66", res.Token)
 }
+
+func TestExtractResourceTrader(t *testing.T) {
+	// The trader pages share the token, multiplier and planetResources script variables
+	pageHTMLBytes, _ := ioutil.ReadFile("../../../samples/v8.7.4/en/traderImportExport.html")
+	res, err := NewExtractor().ExtractResourceTrader(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "2a38193e2fa6047e1d92d2f2c71c00fd", res.Token)
+	assert.Equal(t, ogame.Multiplier{Metal: 1, Crystal: 1.5, Deuterium: 3, Honor: 100}, res.Rates)
+	assert.Equal(t, int64(921175), res.PlanetResources[33673090].Input.Metal)
+}
+
+func TestExtractScrapMerchant(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/traderScrap_synthetic.html")
+	res, err := NewExtractor().ExtractScrapMerchant(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "9f8e7d6c5b4a39281706f5e4d3c2b1a0", res.Token)
+	assert.Equal(t, int64(35), res.Percentage)
+	assert.Equal(t, int64(75), res.MaxPercentage)
+	assert.Equal(t, int64(2000), res.BargainCost)
+	assert.Equal(t, map[ogame.ID]int64{ogame.SmallCargoID: 120, ogame.EspionageProbeID: 1500, ogame.RocketLauncherID: 800}, res.Units)
+}
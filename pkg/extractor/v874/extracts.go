@@ -150,3 +150,71 @@ func extractAuctionFromDoc(doc *goquery.Document) (ogame.Auction, error) {
 
 	return auction, nil
 }
+
+// extractResourceTraderFromDoc extract the exchange rates from page "traderResources".
+// The token, multiplier and planetResources variables are the ones of the captured trader pages,
+// the tradeCost variable and span.js_trade_cost are not verified.
+func extractResourceTraderFromDoc(doc *goquery.Document) (ogame.ResourceTrader, error) {
+	trader := ogame.ResourceTrader{}
+	script := []byte(doc.Find("script").Text())
+	m := regexp.MustCompile(`var token\s?=\s?"([^"]*)";`).FindSubmatch(script)
+	if len(m) != 2 {
+		return trader, errors.New("failed to extract resource trader token")
+	}
+	trader.Token = string(m[1])
+	m = regexp.MustCompile(`var multiplier\s?=\s?({[^;]*});`).FindSubmatch(script)
+	if len(m) != 2 {
+		return trader, errors.New("failed to extract resource trader rates")
+	}
+	if err := json.Unmarshal(m[1], &trader.Rates); err != nil {
+		return trader, err
+	}
+	m = regexp.MustCompile(`var planetResources\s?=\s?({[^;]*});`).FindSubmatch(script)
+	if len(m) != 2 {
+		return trader, errors.New("failed to extract resource trader planet resources")
+	}
+	if err := json.Unmarshal(m[1], &trader.PlanetResources); err != nil {
+		return trader, err
+	}
+	m = regexp.MustCompile(`var tradeCost\s?=\s?(\d+);`).FindSubmatch(script)
+	if len(m) == 2 {
+		trader.DarkMatterCost = utils.DoParseI64(string(m[1]))
+	} else {
+		trader.DarkMatterCost = utils.ParseInt(doc.Find("span.js_trade_cost").Text())
+	}
+	return trader, nil
+}
+
+// extractScrapMerchantFromDoc extract the scrap offer from page "traderScrap".
+// No capture of this page is in the samples: only the token variable is known from the other trader pages,
+// the percentage, bargain and units selectors are not verified.
+func extractScrapMerchantFromDoc(doc *goquery.Document) (ogame.ScrapMerchant, error) {
+	merchant := ogame.ScrapMerchant{Units: make(map[ogame.ID]int64)}
+	script := doc.Find("script").Text()
+	m := regexp.MustCompile(`var token\s?=\s?"([^"]*)";`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return merchant, errors.New("failed to extract scrap merchant token")
+	}
+	merchant.Token = m[1]
+	m = regexp.MustCompile(`var scrapOfferPercentage\s?=\s?(\d+);`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return merchant, errors.New("failed to extract scrap merchant percentage")
+	}
+	merchant.Percentage = utils.DoParseI64(m[1])
+	m = regexp.MustCompile(`var scrapMaxPercentage\s?=\s?(\d+);`).FindStringSubmatch(script)
+	if len(m) == 2 {
+		merchant.MaxPercentage = utils.DoParseI64(m[1])
+	}
+	m = regexp.MustCompile(`var scrapBargainCost\s?=\s?(\d+);`).FindStringSubmatch(script)
+	if len(m) == 2 {
+		merchant.BargainCost = utils.DoParseI64(m[1])
+	}
+	doc.Find("ul.js_scrap_units li.technology").Each(func(i int, s *goquery.Selection) {
+		id := ogame.ID(utils.DoParseI64(s.AttrOr("data-technology", "0")))
+		amount := utils.DoParseI64(s.AttrOr("data-amount", "0"))
+		if id.IsValid() && amount > 0 {
+			merchant.Units[id] = amount
+		}
+	})
+	return merchant, nil
+}
@@ -147,6 +147,17 @@ func (e *Extractor) ExtractLfResearchFromDoc(doc *goquery.Document) (ogame.LfRes
 	return extractLfResearchFromDoc(doc)
 }
 
+// ExtractLfSettings ...
+func (e *Extractor) ExtractLfSettings(pageHTML []byte) (ogame.LfSettings, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractLfSettingsFromDoc(doc)
+}
+
+// ExtractLfSettingsFromDoc ...
+func (e *Extractor) ExtractLfSettingsFromDoc(doc *goquery.Document) (ogame.LfSettings, error) {
+	return extractLfSettingsFromDoc(doc)
+}
+
 // ExtractTearDownButtonEnabled ...
 func (e *Extractor) ExtractTearDownButtonEnabled(pageHTML []byte) bool {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
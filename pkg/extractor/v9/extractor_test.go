@@ -227,3 +227,22 @@ func TestExtractOverviewProduction_ships(t *testing.T) {
 	assert.Equal(t, ogame.SmallCargoID, prod[1].ID)
 	assert.Equal(t, int64(1), prod[1].Nbr)
 }
+
+func TestExtractLfSettings(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("testdata/lfsettings_synthetic.html")
+	settings, err := NewExtractor().ExtractLfSettings(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, ogame.Humans, settings.Current)
+	assert.Equal(t, "8a1e4b0c2d9f7e6a5b3c1d0e9f8a7b6c", settings.Token)
+	assert.Equal(t, 4, len(settings.Species))
+	assert.Equal(t, ogame.LfSpecies{Type: ogame.Humans, Name: "Humans", Discovered: true, Selected: true, Level: 5, Experience: 3200, NextLevelExperience: 4000}, settings.Species[0])
+	kaelesh, _ := settings.SpeciesByType(ogame.Kaelesh)
+	assert.False(t, kaelesh.Discovered)
+	assert.Equal(t, ogame.Resources{Metal: 100000, Crystal: 50000}, settings.ChangeCost)
+	assert.Equal(t, []ogame.LfResearchSlot{
+		{Slot: 1, TechID: ogame.IntergalacticEnvoysID},
+		{Slot: 2, TechID: ogame.AcousticScanningID},
+		{Slot: 3},
+		{Slot: 4, Locked: true},
+	}, settings.ResearchSlots)
+}
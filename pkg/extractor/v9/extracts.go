@@ -642,6 +642,50 @@ func extractLfBuildingsFromDoc(doc *goquery.Document) (ogame.LfBuildings, error)
 	return res, nil
 }
 
+// The lfsettings component (page=ingame&component=lfsettings) is linked from the menu of the real pages,
+// but no capture of the page itself is in the samples: these selectors are not verified.
+func extractLfSettingsFromDoc(doc *goquery.Document) (ogame.LfSettings, error) {
+	res := ogame.LfSettings{}
+	if doc.Find("div#lfsettings").Size() == 0 {
+		return res, errors.New("lifeform settings not found")
+	}
+	doc.Find("div.lifeform-item[data-lifeform-id]").Each(func(i int, s *goquery.Selection) {
+		species := ogame.LfSpecies{
+			Type:       ogame.LifeformType(utils.DoParseI64(s.AttrOr("data-lifeform-id", "0"))),
+			Name:       strings.TrimSpace(s.Find(".lifeformName").Text()),
+			Discovered: !s.HasClass("undiscovered"),
+			Selected:   s.HasClass("selected"),
+		}
+		species.Level = utils.DoParseI64(s.Find(".level").AttrOr("data-level", "0"))
+		experience := s.Find(".experience")
+		species.Experience = utils.DoParseI64(experience.AttrOr("data-experience", "0"))
+		species.NextLevelExperience = utils.DoParseI64(experience.AttrOr("data-experience-next", "0"))
+		if species.Selected {
+			res.Current = species.Type
+		}
+		res.Species = append(res.Species, species)
+	})
+	costs := doc.Find("div#lfsettingsChangeCosts ul.costs")
+	res.ChangeCost = ogame.Resources{
+		Metal:     utils.DoParseI64(costs.Find("li.metal").AttrOr("data-value", "0")),
+		Crystal:   utils.DoParseI64(costs.Find("li.crystal").AttrOr("data-value", "0")),
+		Deuterium: utils.DoParseI64(costs.Find("li.deuterium").AttrOr("data-value", "0")),
+	}
+	doc.Find("li.lfResearchSlot[data-slot]").Each(func(i int, s *goquery.Selection) {
+		res.ResearchSlots = append(res.ResearchSlots, ogame.LfResearchSlot{
+			Slot:   utils.DoParseI64(s.AttrOr("data-slot", "0")),
+			TechID: ogame.ID(utils.DoParseI64(s.AttrOr("data-technology-id", "0"))),
+			Locked: s.HasClass("locked"),
+		})
+	})
+	m := regexp.MustCompile(`var token\s?=\s?"([^"]*)";`).FindStringSubmatch(doc.Find("script").Text())
+	if len(m) != 2 {
+		return res, errors.New("failed to find token")
+	}
+	res.Token = m[1]
+	return res, nil
+}
+
 func extractLfResearchFromDoc(doc *goquery.Document) (ogame.LfResearches, error) {
 	res := ogame.LfResearches{}
 	// Can have any lifeform techs whatever current planet lifeform is, so take everything
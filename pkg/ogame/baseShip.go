@@ -8,6 +8,16 @@ type BaseShip struct {
 	FuelConsumption   int64
 }
 
+// GetBaseCargoCapacity returns ship cargo capacity without any bonus
+func (b BaseShip) GetBaseCargoCapacity() int64 {
+	return b.BaseCargoCapacity
+}
+
+// GetBaseSpeed returns ship speed without any bonus
+func (b BaseShip) GetBaseSpeed() int64 {
+	return b.BaseSpeed
+}
+
 // GetCargoCapacity returns ship cargo capacity
 func (b BaseShip) GetCargoCapacity(techs IResearches, probeRaids, isCollector, isPioneers bool) int64 {
 	if b.GetID() == EspionageProbeID && !probeRaids {
@@ -0,0 +1,52 @@
+package ogame
+
+// CharacterClassOption a class offered by the character class selection page
+type CharacterClassOption struct {
+	Class    CharacterClass
+	Name     string
+	Price    int64 // Dark matter price to activate the class, 0 when free
+	Selected bool
+}
+
+// CharacterClassSelection character class selection page (page=ingame&component=characterclassselection)
+type CharacterClassSelection struct {
+	Current CharacterClass
+	Options []CharacterClassOption
+	Token   string
+}
+
+// Option returns the option for the given class
+func (s CharacterClassSelection) Option(class CharacterClass) (CharacterClassOption, bool) {
+	for _, option := range s.Options {
+		if option.Class == class {
+			return option, true
+		}
+	}
+	return CharacterClassOption{}, false
+}
+
+// AllianceClassOption a class offered by the alliance class selection tab
+type AllianceClassOption struct {
+	Class    AllianceClass
+	Name     string
+	Price    int64 // Dark matter price to activate the class, 0 when free
+	Selected bool
+}
+
+// AllianceClassSelection alliance class selection tab of the alliance page
+type AllianceClassSelection struct {
+	Current   AllianceClass
+	Options   []AllianceClassOption
+	CanChange bool // Only the alliance founder can change the class
+	Token     string
+}
+
+// Option returns the option for the given class
+func (s AllianceClassSelection) Option(class AllianceClass) (AllianceClassOption, bool) {
+	for _, option := range s.Options {
+		if option.Class == class {
+			return option, true
+		}
+	}
+	return AllianceClassOption{}, false
+}
@@ -27,6 +27,14 @@ func TestConstants_Speed_String(t *testing.T) {
 	assert.Equal(t, "11.0", Speed(11).String())
 }
 
+func TestSpeeds(t *testing.T) {
+	assert.Equal(t, 10, len(Speeds(Collector)))
+	assert.Equal(t, TenPercent, Speeds(NoClass)[0])
+	assert.Equal(t, 20, len(Speeds(General)))
+	assert.Equal(t, FivePercent, Speeds(General)[0])
+	assert.Equal(t, HundredPercent, Speeds(General)[19])
+}
+
 func TestConstants_MissionID_String(t *testing.T) {
 	assert.Equal(t, "Attack", MissionID(1).String())
 	assert.Equal(t, "GroupedAttack", MissionID(2).String())
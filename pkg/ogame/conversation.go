@@ -0,0 +1,86 @@
+package ogame
+
+import (
+	"sort"
+	"time"
+)
+
+// ConversationMessage message of a conversation, received through the chat or loaded from the history
+type ConversationMessage struct {
+	ID         int64
+	SenderID   int64
+	SenderName string
+	Text       string
+	Date       time.Time
+}
+
+// Conversation thread with a player, or with an alliance when AssociationID is set
+type Conversation struct {
+	PlayerID      int64
+	AssociationID int64
+	Name          string
+	Unread        bool
+	Preview       ConversationMessage   // Last message shown in the conversations list, it has no ID and is not in Messages
+	Messages      []ConversationMessage // Oldest first
+}
+
+// IsAlliance returns true if the conversation is an alliance conversation
+func (c Conversation) IsAlliance() bool {
+	return c.AssociationID != 0
+}
+
+// LastMessage returns the most recent message of the conversation, the preview when no message is loaded
+func (c Conversation) LastMessage() (ConversationMessage, bool) {
+	if len(c.Messages) == 0 {
+		return c.Preview, c.Preview.Text != ""
+	}
+	return c.Messages[len(c.Messages)-1], true
+}
+
+type conversationMessageKey struct {
+	id       int64
+	senderID int64
+	date     int64
+	text     string
+}
+
+// Messages are identified by their ID, the ones without an ID by their sender, date and text
+func newConversationMessageKey(m ConversationMessage) conversationMessageKey {
+	if m.ID != 0 {
+		return conversationMessageKey{id: m.ID}
+	}
+	return conversationMessageKey{senderID: m.SenderID, date: m.Date.Unix(), text: m.Text}
+}
+
+// Add adds messages to the conversation, ignoring the ones already in it, and keeps them ordered
+func (c *Conversation) Add(msgs ...ConversationMessage) {
+	known := make(map[conversationMessageKey]struct{}, len(c.Messages))
+	for _, m := range c.Messages {
+		known[newConversationMessageKey(m)] = struct{}{}
+	}
+	for _, m := range msgs {
+		key := newConversationMessageKey(m)
+		if _, ok := known[key]; ok {
+			continue
+		}
+		known[key] = struct{}{}
+		c.Messages = append(c.Messages, m)
+	}
+	sort.SliceStable(c.Messages, func(i, j int) bool {
+		if c.Messages[i].Date.Equal(c.Messages[j].Date) {
+			return c.Messages[i].ID < c.Messages[j].ID
+		}
+		return c.Messages[i].Date.Before(c.Messages[j].Date)
+	})
+}
+
+// ConversationMessage converts a chat message to a conversation message
+func (m ChatMsg) ConversationMessage() ConversationMessage {
+	return ConversationMessage{
+		ID:         m.ID,
+		SenderID:   m.SenderID,
+		SenderName: m.SenderName,
+		Text:       m.Text,
+		Date:       time.Unix(m.Date, 0),
+	}
+}
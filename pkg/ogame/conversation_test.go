@@ -0,0 +1,38 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversation_Add(t *testing.T) {
+	now := time.Now()
+	c := Conversation{PlayerID: 1}
+	_, ok := c.LastMessage()
+	assert.False(t, ok)
+	c.Add(ConversationMessage{ID: 2, Date: now}, ConversationMessage{ID: 1, Date: now.Add(-time.Minute)})
+	c.Add(ConversationMessage{ID: 2, Date: now}, ConversationMessage{ID: 3, Date: now})
+	assert.Equal(t, 3, len(c.Messages))
+	assert.Equal(t, int64(1), c.Messages[0].ID)
+	last, ok := c.LastMessage()
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), last.ID)
+	assert.False(t, c.IsAlliance())
+
+	// Messages without an ID are identified by sender, date and text
+	noID := ConversationMessage{SenderID: 9, Text: "gg", Date: now.Add(time.Minute)}
+	c.Add(noID, noID, ConversationMessage{SenderID: 9, Text: "gg", Date: now.Add(2 * time.Minute)})
+	assert.Equal(t, 5, len(c.Messages))
+
+	preview := Conversation{Preview: ConversationMessage{Text: "hello", Date: now}}
+	last, ok = preview.LastMessage()
+	assert.True(t, ok)
+	assert.Equal(t, "hello", last.Text)
+}
+
+func TestChatMsg_ConversationMessage(t *testing.T) {
+	msg := ChatMsg{SenderID: 123, SenderName: "Bob", Text: "hi", ID: 5, Date: 1600000000}
+	assert.Equal(t, ConversationMessage{ID: 5, SenderID: 123, SenderName: "Bob", Text: "hi", Date: time.Unix(1600000000, 0)}, msg.ConversationMessage())
+}
@@ -42,3 +42,11 @@ func TestEnergyTechnology_GetLevel(t *testing.T) {
 	l := et.GetLevel(ResourcesBuildings{}, Facilities{}, Researches{EnergyTechnology: 4})
 	assert.Equal(t, int64(4), l)
 }
+
+func TestEnergyTechnologyConstructionTimeSample(t *testing.T) {
+	// samples/v9.0.2/en/lifeform/overview_all_queues.html, energy technology level 5 in the queue on s186-en (x4),
+	// halving the time "reduces by 50% of the total research time (9m 36s)". The research lab level is not in the capture,
+	// the formula only depends on (1 + research lab) * speed = 40.
+	et := newEnergyTechnology()
+	assert.Equal(t, (19*60+12)*time.Second, et.ConstructionTime(5, 4, Facilities{ResearchLab: 9}, false, false))
+}
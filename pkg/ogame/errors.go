@@ -26,6 +26,9 @@ var ErrAccountBlocked = errors.New("account is blocked")
 // ErrInvalidPlanetID returned when a planet id is invalid
 var ErrInvalidPlanetID = errors.New("invalid planet id")
 
+// ErrInvalidOgameID returned when an ogame id does not match any ogame object
+var ErrInvalidOgameID = errors.New("invalid ogame id")
+
 // ErrAllSlotsInUse returned when all slots are in use
 var ErrAllSlotsInUse = errors.New("all slots are in use")
 
@@ -44,6 +47,9 @@ var ErrDeactivateHidePictures = errors.New("deactivate 'Hide pictures in reports
 // ErrEventsBoxNotDisplayed returned when trying to get attacks from a full page without event box
 var ErrEventsBoxNotDisplayed = errors.New("eventList box is not displayed")
 
+// ErrUnsupportedLanguage returned when a message can't be parsed in the language of the server
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
 // Send fleet errors
 var (
 	ErrUnionNotFound                      = errors.New("union not found")
@@ -62,3 +68,43 @@ var (
 	ErrNoEventsRunning                    = errors.New("there are currently no events running")
 	ErrPlanetAlreadyReservedForRelocation = errors.New("this planet has already been reserved for a relocation")
 )
+
+// Shop errors
+var (
+	ErrItemNotFound   = errors.New("item not found")
+	ErrItemNotBuyable = errors.New("item is not buyable")
+)
+
+// Class selection errors
+var (
+	ErrInvalidCharacterClass = errors.New("invalid character class")
+	ErrInvalidAllianceClass  = errors.New("invalid alliance class")
+	ErrNotEnoughDarkMatter   = errors.New("not enough dark matter")
+	ErrNotAllianceFounder    = errors.New("only the alliance founder can change the alliance class")
+)
+
+// Planet management errors
+var (
+	ErrInvalidPlanetName      = errors.New("planet name must be between 2 and 20 characters")
+	ErrRelocationNotPossible  = errors.New("relocation not possible from this celestial")
+	ErrRelocationCooldown     = errors.New("relocation cooldown not over")
+	ErrRelocationInProgress   = errors.New("a relocation is already in progress for this planet")
+	ErrRelocationSamePosition = errors.New("planet is already at this position")
+)
+
+// Lifeform errors
+var (
+	ErrLifeformDisabled      = errors.New("lifeforms are not enabled on this server")
+	ErrInvalidLifeformType   = errors.New("invalid lifeform type")
+	ErrLifeformNotDiscovered = errors.New("lifeform species not discovered")
+	ErrInvalidLfResearchSlot = errors.New("invalid lifeform research slot")
+	ErrLfResearchSlotLocked  = errors.New("lifeform research slot is locked")
+	ErrNotEnoughResources    = errors.New("not enough resources")
+)
+
+// Scrap merchant errors
+var (
+	ErrScrapMaxPercentage      = errors.New("scrap merchant already at max percentage")
+	ErrScrapPercentageTooLow   = errors.New("scrap merchant percentage too low")
+	ErrScrapUnknownBargainCost = errors.New("scrap merchant bargain cost unknown")
+)
@@ -0,0 +1,87 @@
+package ogame
+
+// ExpeditionOutcomeType what happened during an expedition
+type ExpeditionOutcomeType int64
+
+// Expedition outcomes
+const (
+	UnknownExpeditionOutcome ExpeditionOutcomeType = iota
+	NothingFound
+	ResourcesFound
+	ShipsFound
+	DarkMatterFound
+	ItemFound
+	PiratesAttack
+	AliensAttack
+	EarlyReturn
+	LateReturn
+	BlackHole
+)
+
+func (o ExpeditionOutcomeType) String() string {
+	switch o {
+	case NothingFound:
+		return "nothing"
+	case ResourcesFound:
+		return "resources"
+	case ShipsFound:
+		return "ships"
+	case DarkMatterFound:
+		return "darkmatter"
+	case ItemFound:
+		return "item"
+	case PiratesAttack:
+		return "pirates"
+	case AliensAttack:
+		return "aliens"
+	case EarlyReturn:
+		return "early return"
+	case LateReturn:
+		return "late return"
+	case BlackHole:
+		return "black hole"
+	default:
+		return "unknown"
+	}
+}
+
+// ExpeditionOutcome typed result of an expedition message
+type ExpeditionOutcome struct {
+	Type       ExpeditionOutcomeType
+	Resources  Resources
+	Ships      ShipsInfos
+	DarkMatter int64
+	Item       string
+}
+
+// ExpeditionMaxFind returns the maximum amount of resources (in metal units) an expedition can find.
+// The cap depends on the points of the top 1 player, and is multiplied by the economy speed
+// and by 1.5 for the discoverer class.
+func ExpeditionMaxFind(top1Points, economySpeed int64, isDiscoverer bool) int64 {
+	var maxFind int64
+	switch {
+	case top1Points < 10_000:
+		maxFind = 40_000
+	case top1Points < 100_000:
+		maxFind = 500_000
+	case top1Points < 1_000_000:
+		maxFind = 1_200_000
+	case top1Points < 5_000_000:
+		maxFind = 1_800_000
+	case top1Points < 25_000_000:
+		maxFind = 2_400_000
+	case top1Points < 50_000_000:
+		maxFind = 3_000_000
+	case top1Points < 75_000_000:
+		maxFind = 3_600_000
+	case top1Points < 100_000_000:
+		maxFind = 4_200_000
+	default:
+		maxFind = 5_000_000
+	}
+	maxFind *= economySpeed
+	if isDiscoverer {
+		maxFind = maxFind * 3 / 2
+	}
+	return maxFind
+}
@@ -0,0 +1,21 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpeditionMaxFind(t *testing.T) {
+	assert.Equal(t, int64(40_000), ExpeditionMaxFind(5_000, 1, false))
+	assert.Equal(t, int64(1_200_000), ExpeditionMaxFind(100_000, 1, false))
+	assert.Equal(t, int64(9_600_000), ExpeditionMaxFind(20_000_000, 4, false))
+	assert.Equal(t, int64(14_400_000), ExpeditionMaxFind(20_000_000, 4, true))
+	assert.Equal(t, int64(5_000_000), ExpeditionMaxFind(150_000_000, 1, false))
+}
+
+func TestExpeditionOutcomeType_String(t *testing.T) {
+	assert.Equal(t, "resources", ResourcesFound.String())
+	assert.Equal(t, "black hole", BlackHole.String())
+	assert.Equal(t, "unknown", ExpeditionOutcomeType(99).String())
+}
@@ -38,6 +38,8 @@ type DefenderObj interface {
 // Ship interface implemented by all ships units
 type Ship interface {
 	DefenderObj
+	GetBaseCargoCapacity() int64
+	GetBaseSpeed() int64
 	GetCargoCapacity(techs IResearches, probeRaids, isCollector, isPioneers bool) int64
 	GetFuelConsumption(techs IResearches, fleetDeutSaveFactor float64, isGeneral bool) int64
 	GetSpeed(techs IResearches, isCollector, isGeneral bool) int64
@@ -67,6 +69,18 @@ type Building interface {
 	DeconstructionPrice(lvl int64, techs IResearches) Resources
 }
 
+// LfBuilding interface that all lifeform buildings implement
+type LfBuilding interface {
+	Building
+	LfBuildingConstructionTime(nbr, universeSpeed int64, acc BuildingAccelerators, bonuses LfBonuses) time.Duration
+}
+
+// LfResearch interface that all lifeform techs implement
+type LfResearch interface {
+	Technology
+	LfResearchConstructionTime(nbr, universeSpeed int64, bonuses LfBonuses) time.Duration
+}
+
 // BuildAccelerators levels of things we need to calculate construction time of anything
 type BuildAccelerators interface {
 	TechAccelerators
@@ -1,30 +1,37 @@
 package ogame
 
+import (
+	"encoding/json"
+
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
 // Item Is an ogame item that can be activated
 type Item struct {
-	Ref            string
-	Name           string
-	Image          string
-	ImageLarge     string
-	Title          string
-	Rarity         string // common
-	Amount         int64
-	AmountFree     int64
-	AmountBought   int64
-	canBeActivated bool
-	//Category                []string
-	//Currency                string // dm
-	//Costs                   int64
+	Ref                  string
+	Name                 string
+	Image                string
+	ImageLarge           string
+	Title                string
+	Effect               string // Description of the effect (html)
+	Rarity               string // common
+	Amount               int64
+	AmountFree           int64
+	AmountBought         int64
+	Category             []string
+	Currency             string // dm
+	Costs                int64  // Shop price in Currency
+	Buyable              bool
+	Duration             int64 // Seconds the item stays active once activated, 0 for instant/permanent items
+	MoonOnlyItem         bool
+	IsCharacterClassItem bool
+	canBeActivated       bool
 	//IsReduced               bool
-	//buyable                 bool
 	//canBeBoughtAndActivated bool
 	//isAnUpgrade             bool
-	//isCharacterClassItem    bool
 	//hasEnoughCurrency       bool
 	//Cooldown                bool
 	//extendable              bool
-	//MoonOnlyItem            bool
-	//duration                any
 	//DurationExtension       any
 	//TotalTime               any
 	//timeLeft                any
@@ -35,6 +42,67 @@ type Item struct {
 	//activationTitle         string
 }
 
+// UnmarshalJSON decodes an item from the "items_inventory" javascript object.
+// "costs" is sent either as a string or as a number, and "duration" can be null.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Ref                  string      `json:"ref"`
+		Name                 string      `json:"name"`
+		Image                string      `json:"image"`
+		ImageLarge           string      `json:"imageLarge"`
+		Title                string      `json:"title"`
+		Effect               string      `json:"effect"`
+		Rarity               string      `json:"rarity"`
+		Amount               int64       `json:"amount"`
+		AmountFree           int64       `json:"amount_free"`
+		AmountBought         int64       `json:"amount_bought"`
+		Category             []string    `json:"category"`
+		Currency             string      `json:"currency"`
+		Costs                json.Number `json:"costs"`
+		Buyable              bool        `json:"buyable"`
+		CanBeActivated       bool        `json:"canBeActivated"`
+		Duration             *int64      `json:"duration"`
+		MoonOnlyItem         bool        `json:"moonOnlyItem"`
+		IsCharacterClassItem bool        `json:"isCharacterClassItem"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*i = Item{
+		Ref:                  tmp.Ref,
+		Name:                 tmp.Name,
+		Image:                tmp.Image,
+		ImageLarge:           tmp.ImageLarge,
+		Title:                tmp.Title,
+		Effect:               tmp.Effect,
+		Rarity:               tmp.Rarity,
+		Amount:               tmp.Amount,
+		AmountFree:           tmp.AmountFree,
+		AmountBought:         tmp.AmountBought,
+		Category:             tmp.Category,
+		Currency:             tmp.Currency,
+		Costs:                utils.DoParseI64(tmp.Costs.String()),
+		Buyable:              tmp.Buyable,
+		MoonOnlyItem:         tmp.MoonOnlyItem,
+		IsCharacterClassItem: tmp.IsCharacterClassItem,
+		canBeActivated:       tmp.CanBeActivated,
+	}
+	if tmp.Duration != nil {
+		i.Duration = *tmp.Duration
+	}
+	return nil
+}
+
+// GetEffect returns the effect of the item
+func (i Item) GetEffect() ItemEffect {
+	return GetItemEffect(i.Ref, i.Effect)
+}
+
+// CanBeActivated returns true if the item can be activated from the inventory
+func (i Item) CanBeActivated() bool {
+	return i.canBeActivated
+}
+
 // ActiveItem ...
 type ActiveItem struct {
 	ID            int64
@@ -44,3 +112,8 @@ type ActiveItem struct {
 	TotalDuration int64
 	ImgSmall      string
 }
+
+// GetEffect returns the effect of the active item
+func (i ActiveItem) GetEffect() ItemEffect {
+	return GetItemEffect(i.Ref, "")
+}
@@ -0,0 +1,203 @@
+package ogame
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
+// ItemKind kind of effect an item has once activated
+type ItemKind int64
+
+// Item kinds
+const (
+	UnknownItemKind     ItemKind = iota
+	ResourceBoosterItem          // Metal/Crystal/Deuterium booster
+	BuildingsTimeItem            // KRAKEN, reduces the building time of the building in progress
+	ShipyardTimeItem             // DETROID, reduces the construction time of the shipyard queue
+	ResearchTimeItem             // NEWTRON, reduces the time of the research in progress
+	PlanetFieldsItem             // Additional fields on a planet
+	MoonFieldsItem               // Additional fields on a moon
+	MoonsItem                    // M.O.O.N.S., increases the chance to create a moon
+	CharacterClassItem           // General/Collector/Discoverer
+)
+
+// String ...
+func (k ItemKind) String() string {
+	switch k {
+	case ResourceBoosterItem:
+		return "ResourceBooster"
+	case BuildingsTimeItem:
+		return "BuildingsTime"
+	case ShipyardTimeItem:
+		return "ShipyardTime"
+	case ResearchTimeItem:
+		return "ResearchTime"
+	case PlanetFieldsItem:
+		return "PlanetFields"
+	case MoonFieldsItem:
+		return "MoonFields"
+	case MoonsItem:
+		return "Moons"
+	case CharacterClassItem:
+		return "CharacterClass"
+	}
+	return "Unknown"
+}
+
+// ItemEffect effect of an item once activated
+type ItemEffect struct {
+	Kind          ItemKind
+	Production    Multiplier    // Bonus on the mines production of resource boosters (0.3 for +30%)
+	TimeReduction time.Duration // Time removed from the construction in progress (KRAKEN/DETROID/NEWTRON)
+	Fields        int64         // Fields added by planet/moon fields items
+}
+
+// ReduceTime returns the construction time left after using a KRAKEN/DETROID/NEWTRON
+func (e ItemEffect) ReduceTime(d time.Duration) time.Duration {
+	if e.TimeReduction >= d {
+		return 0
+	}
+	return d - e.TimeReduction
+}
+
+func boosterEffect(metal, crystal, deuterium float64) ItemEffect {
+	return ItemEffect{Kind: ResourceBoosterItem, Production: Multiplier{Metal: metal, Crystal: crystal, Deuterium: deuterium}}
+}
+
+// Effects of the items sold in the shop, by item ref.
+// The refs are the same in every universe and language.
+var itemEffects = map[string]ItemEffect{
+	"77eff880829027daf23b755e14820a60c4c6fd93": {Kind: CharacterClassItem}, // General
+	"2dd05cc4c0e185fce2e712112dc44932027aee98": {Kind: CharacterClassItem}, // Discoverer
+	"9374c79a24b84c4331f0d26526ef6c2d33319a6e": {Kind: CharacterClassItem}, // Collector
+
+	"05294270032e5dc968672425ab5611998c409166": boosterEffect(0.3, 0, 0), // Gold Metal Booster
+	"ba85cc2b8a5d986bbfba6954e2164ef71af95d4a": boosterEffect(0.2, 0, 0), // Silver Metal Booster
+	"de922af379061263a56d7204d1c395cefcfb7d75": boosterEffect(0.1, 0, 0), // Bronze Metal Booster
+	"b956c46faa8e4e5d8775701c69dbfbf53309b279": boosterEffect(0.1, 0, 0), // Bronze Metal Booster (buddy)
+	"118d34e685b5d1472267696d1010a393a59aed03": boosterEffect(0, 0.3, 0), // Gold Crystal Booster
+	"422db99aac4ec594d483d8ef7faadc5d40d6f7d3": boosterEffect(0, 0.2, 0), // Silver Crystal Booster
+	"3c9f85221807b8d593fa5276cdf7af9913c4a35d": boosterEffect(0, 0.1, 0), // Bronze Crystal Booster
+	"090a969b05d1b5dc458a6b1080da7ba08b84ec7f": boosterEffect(0, 0.1, 0), // Bronze Crystal Booster (buddy)
+	"5560a1580a0330e8aadf05cb5bfe6bc3200406e2": boosterEffect(0, 0, 0.3), // Gold Deuterium Booster
+	"e4b78acddfa6fd0234bcb814b676271898b0dbb3": boosterEffect(0, 0, 0.2), // Silver Deuterium Booster
+	"d9fa5f359e80ff4f4c97545d07c66dbadab1d1be": boosterEffect(0, 0, 0.1), // Bronze Deuterium Booster
+	"e254352ac599de4dd1f20f0719df0a070c623ca8": boosterEffect(0, 0, 0.1), // Bronze Deuterium Booster (buddy)
+
+	"04e58444d6d0beb57b3e998edc34c60f8318825a": {Kind: PlanetFieldsItem, Fields: 15}, // Gold Planet Fields
+	"0e41524dc46225dca21c9119f2fb735fd7ea5cb3": {Kind: PlanetFieldsItem, Fields: 9},  // Silver Planet Fields
+	"16768164989dffd819a373613b5e1a52e226a5b0": {Kind: PlanetFieldsItem, Fields: 4},  // Bronze Planet Fields
+	"05ee9654bd11a261f1ff0e5d0e49121b5e7e4401": {Kind: MoonFieldsItem, Fields: 6},    // Gold Moon Fields
+	"c21ff33ba8f0a7eadb6b7d1135763366f0c4b8bf": {Kind: MoonFieldsItem, Fields: 4},    // Silver Moon Fields
+	"be67e009a5894f19bbf3b0c9d9b072d49040a2cc": {Kind: MoonFieldsItem, Fields: 2},    // Bronze Moon Fields
+
+	"45d6660308689c65d97f3c27327b0b31f880ae75": {Kind: MoonsItem}, // Gold M.O.O.N.S.
+	"fd895a5c9fd978b9c5c7b65158099773ba0eccef": {Kind: MoonsItem}, // Silver M.O.O.N.S.
+	"485a6d5624d9de836d3eb52b181b13423f795770": {Kind: MoonsItem}, // Bronze M.O.O.N.S.
+
+	"929d5e15709cc51a4500de4499e19763c879f7f7": {Kind: BuildingsTimeItem, TimeReduction: 6 * time.Hour},    // KRAKEN Gold
+	"4a58d4978bbe24e3efb3b0248e21b3b4b1bfbd8a": {Kind: BuildingsTimeItem, TimeReduction: 2 * time.Hour},    // KRAKEN Silver
+	"40f6c78e11be01ad3389b7dccd6ab8efa9347f3c": {Kind: BuildingsTimeItem, TimeReduction: 30 * time.Minute}, // KRAKEN Bronze
+	"0968999df2fe956aa4a07aea74921f860af7d97f": {Kind: ShipyardTimeItem, TimeReduction: 6 * time.Hour},     // DETROID Gold
+	"27cbcd52f16693023cb966e5026d8a1efbbfc0f9": {Kind: ShipyardTimeItem, TimeReduction: 2 * time.Hour},     // DETROID Silver
+	"d3d541ecc23e4daa0c698e44c32f04afd2037d84": {Kind: ShipyardTimeItem, TimeReduction: 30 * time.Minute},  // DETROID Bronze
+	"da4a2a1bb9afd410be07bc9736d87f1c8059e66d": {Kind: ResearchTimeItem, TimeReduction: 30 * time.Minute},  // NEWTRON Bronze
+}
+
+var (
+	itemBoosterRgx   = regexp.MustCompile(`\+(\d+)% more (Metal|Crystal|Deuterium)`)
+	itemReductionRgx = regexp.MustCompile(`by <b>(\d+)([dhm])</b>`)
+	itemFieldsRgx    = regexp.MustCompile(`\+(\d+) additional fields on a (planet|moon)`)
+)
+
+// GetItemEffect returns the effect of an item given its ref.
+// Items that are not known are guessed from the effect description (english only).
+func GetItemEffect(ref, effect string) ItemEffect {
+	if e, ok := itemEffects[ref]; ok {
+		return e
+	}
+	return parseItemEffect(effect)
+}
+
+func parseItemEffect(effect string) ItemEffect {
+	if m := itemBoosterRgx.FindStringSubmatch(effect); len(m) == 3 {
+		pct := float64(utils.DoParseI64(m[1])) / 100
+		switch m[2] {
+		case "Metal":
+			return boosterEffect(pct, 0, 0)
+		case "Crystal":
+			return boosterEffect(0, pct, 0)
+		case "Deuterium":
+			return boosterEffect(0, 0, pct)
+		}
+	}
+	if m := itemReductionRgx.FindStringSubmatch(effect); len(m) == 3 {
+		nbr := time.Duration(utils.DoParseI64(m[1]))
+		var reduction time.Duration
+		switch m[2] {
+		case "d":
+			reduction = nbr * 24 * time.Hour
+		case "h":
+			reduction = nbr * time.Hour
+		case "m":
+			reduction = nbr * time.Minute
+		}
+		kind := BuildingsTimeItem
+		if strings.Contains(effect, "research") {
+			kind = ResearchTimeItem
+		} else if strings.Contains(effect, "shipyard") {
+			kind = ShipyardTimeItem
+		}
+		return ItemEffect{Kind: kind, TimeReduction: reduction}
+	}
+	if m := itemFieldsRgx.FindStringSubmatch(effect); len(m) == 3 {
+		kind := PlanetFieldsItem
+		if m[2] == "moon" {
+			kind = MoonFieldsItem
+		}
+		return ItemEffect{Kind: kind, Fields: utils.DoParseI64(m[1])}
+	}
+	return ItemEffect{Kind: UnknownItemKind}
+}
+
+// ItemsProductionBoost returns the production bonus of the active resource boosters.
+// Only one booster per resource can be active on a planet, the strongest one wins.
+func ItemsProductionBoost(items []ActiveItem) (boost Multiplier) {
+	for _, item := range items {
+		e := item.GetEffect()
+		if e.Kind != ResourceBoosterItem {
+			continue
+		}
+		boost.Metal = math.Max(boost.Metal, e.Production.Metal)
+		boost.Crystal = math.Max(boost.Crystal, e.Production.Crystal)
+		boost.Deuterium = math.Max(boost.Deuterium, e.Production.Deuterium)
+	}
+	return
+}
+
+// ItemCatalog items of the shop/inventory
+type ItemCatalog []Item
+
+// ByRef returns the item with the given ref
+func (c ItemCatalog) ByRef(ref string) (Item, bool) {
+	for _, item := range c {
+		if item.Ref == ref {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// ByKind returns the items having an effect of the given kind
+func (c ItemCatalog) ByKind(kind ItemKind) (out []Item) {
+	for _, item := range c {
+		if item.GetEffect().Kind == kind {
+			out = append(out, item)
+		}
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package ogame
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem_UnmarshalJSON(t *testing.T) {
+	var items map[string]Item
+	err := json.Unmarshal([]byte(`{
+		"a":{"ref":"05294270032e5dc968672425ab5611998c409166","name":"Gold Metal Booster","amount":2,"amount_free":1,"amount_bought":1,"currency":"dm","costs":"25000","buyable":true,"canBeActivated":true,"duration":604800,"moonOnlyItem":false},
+		"b":{"ref":"77eff880829027daf23b755e14820a60c4c6fd93","name":"General","costs":500000,"duration":null,"isCharacterClassItem":true}
+	}`), &items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25000), items["a"].Costs)
+	assert.Equal(t, int64(604800), items["a"].Duration)
+	assert.Equal(t, int64(1), items["a"].AmountFree)
+	assert.True(t, items["a"].CanBeActivated())
+	assert.Equal(t, int64(500000), items["b"].Costs)
+	assert.Equal(t, int64(0), items["b"].Duration)
+	assert.True(t, items["b"].IsCharacterClassItem)
+	assert.Equal(t, CharacterClassItem, items["b"].GetEffect().Kind)
+}
+
+func TestGetItemEffect(t *testing.T) {
+	e := GetItemEffect("05294270032e5dc968672425ab5611998c409166", "")
+	assert.Equal(t, ResourceBoosterItem, e.Kind)
+	assert.Equal(t, 0.3, e.Production.Metal)
+
+	e = GetItemEffect("unknown", "+40% more Crystal Mine production on one planet")
+	assert.Equal(t, boosterEffect(0, 0.4, 0), e)
+
+	e = GetItemEffect("unknown", "Reduces research time for all research that is currently in progress by <b>6h</b>.")
+	assert.Equal(t, ItemEffect{Kind: ResearchTimeItem, TimeReduction: 6 * time.Hour}, e)
+
+	e = GetItemEffect("unknown", "Reduces the construction time of current shipyard-contracts by <b>30m</b>.")
+	assert.Equal(t, ItemEffect{Kind: ShipyardTimeItem, TimeReduction: 30 * time.Minute}, e)
+
+	e = GetItemEffect("unknown", "+2 additional fields on a moon")
+	assert.Equal(t, ItemEffect{Kind: MoonFieldsItem, Fields: 2}, e)
+
+	assert.Equal(t, UnknownItemKind, GetItemEffect("unknown", "Start an attack on your planet to create a moon.").Kind)
+}
+
+func TestItemEffect_ReduceTime(t *testing.T) {
+	kraken := GetItemEffect("40f6c78e11be01ad3389b7dccd6ab8efa9347f3c", "")
+	assert.Equal(t, 15*time.Minute, kraken.ReduceTime(45*time.Minute))
+	assert.Equal(t, time.Duration(0), kraken.ReduceTime(10*time.Minute))
+}
+
+func TestItemsProductionBoost(t *testing.T) {
+	boost := ItemsProductionBoost([]ActiveItem{
+		{Ref: "ba85cc2b8a5d986bbfba6954e2164ef71af95d4a"}, // Silver Metal Booster
+		{Ref: "5560a1580a0330e8aadf05cb5bfe6bc3200406e2"}, // Gold Deuterium Booster
+		{Ref: "929d5e15709cc51a4500de4499e19763c879f7f7"}, // KRAKEN Gold
+	})
+	assert.Equal(t, Multiplier{Metal: 0.2, Deuterium: 0.3}, boost)
+}
+
+func TestItemCatalog(t *testing.T) {
+	catalog := ItemCatalog{
+		{Ref: "05294270032e5dc968672425ab5611998c409166", Name: "Gold Metal Booster"},
+		{Ref: "929d5e15709cc51a4500de4499e19763c879f7f7", Name: "KRAKEN Gold"},
+	}
+	item, ok := catalog.ByRef("929d5e15709cc51a4500de4499e19763c879f7f7")
+	assert.True(t, ok)
+	assert.Equal(t, "KRAKEN Gold", item.Name)
+	_, ok = catalog.ByRef("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(catalog.ByKind(ResourceBoosterItem)))
+}
@@ -0,0 +1,382 @@
+package ogame
+
+import (
+	"math"
+	"time"
+)
+
+// LfShipBonus bonuses of the lifeform technologies on a ship.
+// All values are ratios of the base value, 0.01 means +1% (or -1% for reductions).
+type LfShipBonus struct {
+	Cargo         float64
+	Speed         float64
+	FuelReduction float64
+	Armour        float64
+	Shield        float64
+	Weapon        float64
+}
+
+func (b LfShipBonus) add(o LfShipBonus, factor float64) LfShipBonus {
+	return LfShipBonus{
+		Cargo:         b.Cargo + o.Cargo*factor,
+		Speed:         b.Speed + o.Speed*factor,
+		FuelReduction: b.FuelReduction + o.FuelReduction*factor,
+		Armour:        b.Armour + o.Armour*factor,
+		Shield:        b.Shield + o.Shield*factor,
+		Weapon:        b.Weapon + o.Weapon*factor,
+	}
+}
+
+// LfExpeditionBonus bonuses of the lifeform technologies on expeditions
+type LfExpeditionBonus struct {
+	Resources  float64 // More resources found
+	Ships      float64 // More ships found
+	DarkMatter float64 // More dark matter found
+}
+
+// LfClassBonus amplifiers of the character class bonuses.
+// 0.1 means the bonuses of the class are 10% stronger (eg: collector mines bonus goes from 25% to 27.5%).
+type LfClassBonus struct {
+	Collector  float64
+	General    float64
+	Discoverer float64
+}
+
+// LfBonuses modifiers granted by the lifeform buildings and technologies of a planet.
+// All values are ratios, 0.015 means +1.5% (or -1.5% for reductions).
+type LfBonuses struct {
+	Production              Multiplier // Mines production
+	EnergyProduction        float64
+	AllShips                LfShipBonus // Bonus applying to every ship
+	Ships                   map[ID]LfShipBonus
+	BuildingTimeReduction   float64
+	ResearchTimeReduction   float64
+	LfBuildingTimeReduction float64 // Lifeform buildings construction time
+	LfResearchTimeReduction float64 // Lifeform techs research time
+	ResearchCostReduction   float64
+	Expedition              LfExpeditionBonus
+	Class                   LfClassBonus
+}
+
+// lfShips returns the same bonus for all the given ships
+func lfShips(bonus LfShipBonus, ids ...ID) map[ID]LfShipBonus {
+	out := make(map[ID]LfShipBonus, len(ids))
+	for _, id := range ids {
+		out[id] = bonus
+	}
+	return out
+}
+
+// Bonus of the ship specific technologies (Mk II, general overhauls, overclocking...)
+var lfShipTechBonus = LfShipBonus{Cargo: 0.002, Speed: 0.003, Armour: 0.003, Shield: 0.003, Weapon: 0.003}
+
+var lfCivilShips = []ID{SmallCargoID, LargeCargoID, ColonyShipID, RecyclerID, PathfinderID}
+
+// LfBonusesPerLevel bonuses granted by each level of the lifeform buildings and technologies.
+// None of the samples contain the lifeform tooltips and the values could not be checked against the game data,
+// so they are not checked by a test. The table is exported so a wrong or outdated value can be corrected by the caller.
+// The lifeform buildings and technologies missing from this table are listed in LfBonusesNotModelled.
+var LfBonusesPerLevel = map[ID]LfBonuses{
+	// Buildings
+	HighEnergySmeltingID:         {Production: Multiplier{Metal: 0.015}},
+	FusionPoweredProductionID:    {Production: Multiplier{Crystal: 0.015, Deuterium: 0.015}},
+	MagmaForgeID:                 {Production: Multiplier{Metal: 0.02}},
+	CrystalRefineryID:            {Production: Multiplier{Crystal: 0.02}},
+	DeuteriumSynthesiserID:       {Production: Multiplier{Deuterium: 0.02}},
+	HighPerformanceSynthesiserID: {Production: Multiplier{Deuterium: 0.02}},
+	MegalithID:                   {BuildingTimeReduction: 0.01, LfBuildingTimeReduction: 0.01},
+	ResearchCentreID:             {LfResearchTimeReduction: 0.0025},
+	RuneTechnologiumID:           {LfResearchTimeReduction: 0.0025},
+	RoboticsResearchCentreID:     {LfResearchTimeReduction: 0.0025},
+	VortexChamberID:              {LfResearchTimeReduction: 0.0025},
+
+	// Humans techs
+	HighPerformanceExtractorsID:      {Production: Multiplier{Metal: 0.0006, Crystal: 0.0006, Deuterium: 0.0006}},
+	FusionDrivesID:                   {AllShips: LfShipBonus{Speed: 0.001}},
+	ResearchAIID:                     {ResearchTimeReduction: 0.002},
+	EnhancedProductionTechnologiesID: {Production: Multiplier{Metal: 0.0006, Crystal: 0.0006, Deuterium: 0.0006}},
+	LightFighterMkIIID:               {Ships: lfShips(lfShipTechBonus, LightFighterID)},
+	CruiserMkIIID:                    {Ships: lfShips(lfShipTechBonus, CruiserID)},
+	ImprovedLabTechnologyID:          {ResearchCostReduction: 0.001},
+	LowTemperatureDrivesID:           {AllShips: LfShipBonus{Speed: 0.001}},
+	BomberMkIIID:                     {Ships: lfShips(lfShipTechBonus, BomberID)},
+	DestroyerMkIIID:                  {Ships: lfShips(lfShipTechBonus, DestroyerID)},
+	BattlecruiserMkIIID:              {Ships: lfShips(lfShipTechBonus, BattlecruiserID)},
+	SupercomputerID:                  {ResearchTimeReduction: 0.001},
+
+	// Rocktal techs
+	VolcanicBatteriesID:                 {EnergyProduction: 0.0025},
+	AcousticScanningID:                  {Production: Multiplier{Crystal: 0.0008}},
+	HighEnergyPumpSystemsID:             {Production: Multiplier{Deuterium: 0.0008}},
+	CargoHoldExpansionCivilianShipsID:   {Ships: lfShips(LfShipBonus{Cargo: 0.004}, lfCivilShips...)},
+	MagmaPoweredProductionID:            {Production: Multiplier{Metal: 0.0008, Crystal: 0.0008, Deuterium: 0.0008}},
+	GeothermalPowerPlantsID:             {EnergyProduction: 0.0025},
+	DepthSoundingID:                     {Production: Multiplier{Metal: 0.0008}},
+	IonCrystalEnhancementHeavyFighterID: {Ships: lfShips(lfShipTechBonus, HeavyFighterID)},
+	HardenedDiamondDrillHeadsID:         {Production: Multiplier{Metal: 0.0008}},
+	SeismicMiningTechnologyID:           {Production: Multiplier{Crystal: 0.0008}},
+	MagmaPoweredPumpSystemsID:           {Production: Multiplier{Deuterium: 0.0008}},
+	RocktalCollectorEnhancementID:       {Class: LfClassBonus{Collector: 0.002}},
+
+	// Mechas techs
+	CatalyserTechnologyID:          {Production: Multiplier{Deuterium: 0.0008}},
+	PlasmaDriveID:                  {AllShips: LfShipBonus{Speed: 0.001}},
+	EfficiencyModuleID:             {AllShips: LfShipBonus{FuelReduction: 0.003}},
+	GeneralOverhaulLightFighterID:  {Ships: lfShips(lfShipTechBonus, LightFighterID)},
+	AutomatedTransportLinesID:      {Production: Multiplier{Metal: 0.0006, Crystal: 0.0006, Deuterium: 0.0006}},
+	GeneralOverhaulCruiserID:       {Ships: lfShips(lfShipTechBonus, CruiserID)},
+	SlingshotAutopilotID:           {AllShips: LfShipBonus{Speed: 0.001}},
+	GeneralOverhaulBattleshipID:    {Ships: lfShips(lfShipTechBonus, BattleshipID)},
+	ArtificialSwarmIntelligenceID:  {Production: Multiplier{Metal: 0.0006, Crystal: 0.0006, Deuterium: 0.0006}},
+	GeneralOverhaulBattlecruiserID: {Ships: lfShips(lfShipTechBonus, BattlecruiserID)},
+	GeneralOverhaulBomberID:        {Ships: lfShips(lfShipTechBonus, BomberID)},
+	GeneralOverhaulDestroyerID:     {Ships: lfShips(lfShipTechBonus, DestroyerID)},
+	MechanGeneralEnhancementID:     {Class: LfClassBonus{General: 0.002}},
+
+	// Kaelesh techs
+	HeatRecoveryID:                 {AllShips: LfShipBonus{FuelReduction: 0.003}},
+	SulphideProcessID:              {Production: Multiplier{Deuterium: 0.0008}},
+	PsionicNetworkID:               {Expedition: LfExpeditionBonus{Resources: 0.002}},
+	TelekineticTractorBeamID:       {Expedition: LfExpeditionBonus{Ships: 0.002}},
+	EnhancedSensorTechnologyID:     {Expedition: LfExpeditionBonus{Resources: 0.002}},
+	NeuromodalCompressorID:         {Ships: lfShips(LfShipBonus{Cargo: 0.003}, lfCivilShips...)},
+	NeuroInterfaceID:               {ResearchTimeReduction: 0.001},
+	OverclockingHeavyFighterID:     {Ships: lfShips(lfShipTechBonus, HeavyFighterID)},
+	TelekineticDriveID:             {AllShips: LfShipBonus{Speed: 0.001}},
+	SixthSenseID:                   {Expedition: LfExpeditionBonus{DarkMatter: 0.002}},
+	PsychoharmoniserID:             {Production: Multiplier{Metal: 0.0006, Crystal: 0.0006, Deuterium: 0.0006}},
+	OverclockingLargeCargoID:       {Ships: lfShips(LfShipBonus{Cargo: 0.002, Speed: 0.003}, LargeCargoID)},
+	OverclockingBattleshipID:       {Ships: lfShips(lfShipTechBonus, BattleshipID)},
+	KaeleshDiscovererEnhancementID: {Class: LfClassBonus{Discoverer: 0.002}},
+}
+
+// LfBonusesNotModelled lifeform buildings and technologies that are not in LfBonusesPerLevel.
+// Their effects (population, food, defence, combat, debris, costs of other buildings...) have no field in LfBonuses,
+// or their values are not known. The population and food buildings are modelled in lfPopulation.go.
+var LfBonusesNotModelled = []ID{
+	// Humans
+	ResidentialSectorID, BiosphereFarmID, AcademyOfSciencesID, NeuroCalibrationCentreID, FoodSiloID, SkyscraperID,
+	BiotechLabID, MetropolisID, PlanetaryShieldID,
+	IntergalacticEnvoysID, StealthFieldGeneratorID, OrbitalDenID, HighPerformanceTerraformerID, PlasmaTerraformerID,
+	RobotAssistantsID,
+
+	// Rocktal
+	MeditationEnclaveID, CrystalFarmID, RuneForgeID, OriktoriumID, DisruptionChamberID, MineralResearchCentreID,
+	MetalRecyclingPlantID,
+	ImprovedStellaratorID, IonCrystalModulesID, OptimisedSiloConstructionMethodID, DiamondEnergyTransmitterID,
+	ObsidianShieldReinforcementID, RuneShieldsID,
+
+	// Mechas
+	AssemblyLineID, FusionCellFactoryID, UpdateNetworkID, QuantumComputerCentreID, AutomatisedAssemblyCentreID,
+	HighPerformanceTransformerID, MicrochipAssemblyLineID, ProductionAssemblyHallID, ChipMassProductionID, NanoRepairBotsID,
+	DepotAIID, ImprovedDroneAIID, ExperimentalRecyclingTechnologyID, HighTemperatureSuperconductorsID,
+	ExperimentalWeaponsTechnologyID,
+
+	// Kaelesh
+	SanctuaryID, AntimatterCondenserID, HallsOfRealisationID, ForumOfTranscendenceID, AntimatterConvectorID,
+	CloningLaboratoryID, ChrysalisAcceleratorID, BioModifierID, PsionicModulatorID, ShipManufacturingHallID, SupraRefractorID,
+	InterplanetaryAnalysisNetworkID, EfficientSwarmIntelligenceID, GravitationSensorsID, PsionicShieldMatrixID,
+}
+
+// LfSpeciesLevelTechBonus each level of the species increases the bonuses of its technologies by this ratio
+const LfSpeciesLevelTechBonus = 0.001
+
+// maxLfReduction time, cost and fuel reductions never go above 99%
+const maxLfReduction = 0.99
+
+func (b LfBonuses) add(o LfBonuses, factor float64) LfBonuses {
+	b.Production.Metal += o.Production.Metal * factor
+	b.Production.Crystal += o.Production.Crystal * factor
+	b.Production.Deuterium += o.Production.Deuterium * factor
+	b.EnergyProduction += o.EnergyProduction * factor
+	b.AllShips = b.AllShips.add(o.AllShips, factor)
+	for id, shipBonus := range o.Ships {
+		if b.Ships == nil {
+			b.Ships = make(map[ID]LfShipBonus)
+		}
+		b.Ships[id] = b.Ships[id].add(shipBonus, factor)
+	}
+	b.BuildingTimeReduction += o.BuildingTimeReduction * factor
+	b.ResearchTimeReduction += o.ResearchTimeReduction * factor
+	b.LfBuildingTimeReduction += o.LfBuildingTimeReduction * factor
+	b.LfResearchTimeReduction += o.LfResearchTimeReduction * factor
+	b.ResearchCostReduction += o.ResearchCostReduction * factor
+	b.Expedition.Resources += o.Expedition.Resources * factor
+	b.Expedition.Ships += o.Expedition.Ships * factor
+	b.Expedition.DarkMatter += o.Expedition.DarkMatter * factor
+	b.Class.Collector += o.Class.Collector * factor
+	b.Class.General += o.Class.General * factor
+	b.Class.Discoverer += o.Class.Discoverer * factor
+	return b
+}
+
+// GetLfBonuses computes the modifiers granted by the lifeform buildings and technologies of a planet.
+// speciesLevel is the level of the planet species, it amplifies the technologies bonuses.
+func GetLfBonuses(buildings LfBuildings, researches LfResearches, speciesLevel int64) LfBonuses {
+	var out LfBonuses
+	techFactor := 1 + float64(speciesLevel)*LfSpeciesLevelTechBonus
+	for id, perLevel := range LfBonusesPerLevel {
+		if id.IsLfBuilding() {
+			if lvl := buildings.ByID(id); lvl > 0 {
+				out = out.add(perLevel, float64(lvl))
+			}
+		} else if lvl := researches.ByID(id); lvl > 0 {
+			out = out.add(perLevel, float64(lvl)*techFactor)
+		}
+	}
+	out.BuildingTimeReduction = math.Min(out.BuildingTimeReduction, maxLfReduction)
+	out.ResearchTimeReduction = math.Min(out.ResearchTimeReduction, maxLfReduction)
+	out.ResearchCostReduction = math.Min(out.ResearchCostReduction, maxLfReduction)
+	out.LfBuildingTimeReduction = math.Min(out.LfBuildingTimeReduction, maxLfReduction)
+	out.LfResearchTimeReduction = math.Min(out.LfResearchTimeReduction, maxLfReduction)
+	return out
+}
+
+// Ship returns the bonus applying to the given ship
+func (b LfBonuses) Ship(id ID) LfShipBonus {
+	return b.AllShips.add(b.Ships[id], 1)
+}
+
+// ProductionBoost returns the mines production bonus, including the amplified collector bonus.
+// It can be combined with the items boost (see ItemsProductionBoost).
+func (b LfBonuses) ProductionBoost(class CharacterClass) Multiplier {
+	boost := b.Production
+	if class.IsCollector() {
+		extra := 0.25 * b.Class.Collector
+		boost.Metal += extra
+		boost.Crystal += extra
+		boost.Deuterium += extra
+	}
+	return boost
+}
+
+// CargoCapacity returns the ship cargo capacity with the lifeform bonuses
+func (b LfBonuses) CargoCapacity(ship Ship, techs IResearches, probeRaids, isCollector, isPioneers bool) int64 {
+	cargo := ship.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers)
+	if cargo == 0 {
+		return 0
+	}
+	baseCargo := float64(ship.GetBaseCargoCapacity())
+	bonus := b.Ship(ship.GetID()).Cargo
+	if isCollector && (ship.GetID() == SmallCargoID || ship.GetID() == LargeCargoID) {
+		bonus += 0.25 * b.Class.Collector
+	}
+	return cargo + int64(baseCargo*bonus)
+}
+
+// FleetCargo returns the total cargo of the ships with the lifeform bonuses
+func (b LfBonuses) FleetCargo(ships ShipsInfos, techs IResearches, probeRaids, isCollector, isPioneers bool) (out int64) {
+	for _, ship := range Ships {
+		if nbr := ships.ByID(ship.GetID()); nbr > 0 {
+			out += b.CargoCapacity(ship, techs, probeRaids, isCollector, isPioneers) * nbr
+		}
+	}
+	return
+}
+
+// Speed returns the ship speed with the lifeform bonuses
+func (b LfBonuses) Speed(ship Ship, techs IResearches, isCollector, isGeneral bool) int64 {
+	speed := ship.GetSpeed(techs, isCollector, isGeneral)
+	baseSpeed := float64(ship.GetBaseSpeed())
+	id := ship.GetID()
+	bonus := b.Ship(id).Speed
+	if isCollector && (id == SmallCargoID || id == LargeCargoID) {
+		bonus += b.Class.Collector
+	} else if isGeneral && (id == RecyclerID || id.IsCombatShip()) && id != DeathstarID {
+		bonus += b.Class.General
+	}
+	return speed + int64(baseSpeed*bonus)
+}
+
+// FuelConsumption returns the ship fuel consumption with the lifeform bonuses
+func (b LfBonuses) FuelConsumption(ship Ship, techs IResearches, fleetDeutSaveFactor float64, isGeneral bool) int64 {
+	fuel := float64(ship.GetFuelConsumption(techs, fleetDeutSaveFactor, isGeneral))
+	reduction := b.Ship(ship.GetID()).FuelReduction
+	if isGeneral {
+		// GetFuelConsumption already applies the general bonus, the amplifier adds its own reduction
+		reduction += b.Class.General
+	}
+	reduction = math.Min(reduction, maxLfReduction)
+	return int64(math.Ceil(fuel * (1 - reduction)))
+}
+
+// WeaponPower returns the ship weapon power with the lifeform bonuses
+func (b LfBonuses) WeaponPower(ship Ship, techs IResearches) int64 {
+	base := float64(ship.GetWeaponPower(Researches{}))
+	return ship.GetWeaponPower(techs) + int64(base*b.Ship(ship.GetID()).Weapon)
+}
+
+// ShieldPower returns the ship shield power with the lifeform bonuses
+func (b LfBonuses) ShieldPower(ship Ship, techs IResearches) int64 {
+	base := float64(ship.GetShieldPower(Researches{}))
+	return ship.GetShieldPower(techs) + int64(base*b.Ship(ship.GetID()).Shield)
+}
+
+// StructuralIntegrity returns the ship structural integrity with the lifeform bonuses
+func (b LfBonuses) StructuralIntegrity(ship Ship, techs IResearches) int64 {
+	base := float64(ship.GetStructuralIntegrity(Researches{}))
+	return ship.GetStructuralIntegrity(techs) + int64(base*b.Ship(ship.GetID()).Armour)
+}
+
+// BuildingTime returns the construction time of a building with the lifeform bonuses
+func (b LfBonuses) BuildingTime(d time.Duration) time.Duration {
+	return reduceDuration(d, b.BuildingTimeReduction)
+}
+
+// ResearchTime returns the research time with the lifeform bonuses.
+// d is the time given by TechnologyConstructionTime.
+func (b LfBonuses) ResearchTime(d time.Duration, isDiscoverer bool) time.Duration {
+	reduction := b.ResearchTimeReduction
+	if isDiscoverer {
+		// The discoverer bonus (-25%) is already applied to d, the amplifier only adds its part
+		reduction += 0.25 * b.Class.Discoverer / 0.75
+	}
+	return reduceDuration(d, math.Min(reduction, maxLfReduction))
+}
+
+// ResearchCost returns the research price with the lifeform bonuses
+func (b LfBonuses) ResearchCost(price Resources) Resources {
+	keep := 1 - b.ResearchCostReduction
+	return Resources{
+		Metal:     int64(float64(price.Metal) * keep),
+		Crystal:   int64(float64(price.Crystal) * keep),
+		Deuterium: int64(float64(price.Deuterium) * keep),
+		Energy:    price.Energy,
+	}
+}
+
+// ExpeditionMaxFind same as ExpeditionMaxFind with the lifeform bonuses
+func (b LfBonuses) ExpeditionMaxFind(top1Points, economySpeed int64, isDiscoverer bool) int64 {
+	maxFind := float64(ExpeditionMaxFind(top1Points, economySpeed, false))
+	bonus := b.Expedition.Resources
+	if isDiscoverer {
+		bonus += 0.5 * (1 + b.Class.Discoverer)
+	}
+	return int64(maxFind * (1 + bonus))
+}
+
+// ConstructionTime returns the duration to build nbr units, or the given level, of obj with the lifeform bonuses.
+// Buildings get the building time reduction, technologies the research time reduction, ships and defenses none.
+func (b LfBonuses) ConstructionTime(obj BaseOgameObj, nbr, universeSpeed int64, acc BuildAccelerators, hasTechnocrat, isDiscoverer bool) time.Duration {
+	switch o := obj.(type) {
+	case LfBuilding:
+		return o.LfBuildingConstructionTime(nbr, universeSpeed, acc, b)
+	case LfResearch:
+		return o.LfResearchConstructionTime(nbr, universeSpeed, b)
+	}
+	d := obj.ConstructionTime(nbr, universeSpeed, acc, hasTechnocrat, isDiscoverer)
+	if obj.GetID().IsTech() {
+		return b.ResearchTime(d, isDiscoverer)
+	} else if obj.GetID().IsBuilding() {
+		return b.BuildingTime(d)
+	}
+	return d
+}
+
+func reduceDuration(d time.Duration, reduction float64) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	secs := math.Max(1, math.Floor(d.Seconds()*(1-reduction)))
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,93 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLfBonuses(t *testing.T) {
+	bonuses := GetLfBonuses(LfBuildings{HighEnergySmelting: 10}, LfResearches{HighPerformanceExtractors: 10}, 0)
+	assert.InDelta(t, 0.156, bonuses.Production.Metal, 0.000001)
+	assert.InDelta(t, 0.006, bonuses.Production.Crystal, 0.000001)
+	assert.InDelta(t, 0.006, bonuses.Production.Deuterium, 0.000001)
+
+	// Species level amplifies the technologies, not the buildings
+	bonuses = GetLfBonuses(LfBuildings{HighEnergySmelting: 10}, LfResearches{HighPerformanceExtractors: 10}, 100)
+	assert.InDelta(t, 0.1566, bonuses.Production.Metal, 0.000001)
+	assert.InDelta(t, 0.0066, bonuses.Production.Crystal, 0.000001)
+
+	// Reductions are capped
+	bonuses = GetLfBonuses(LfBuildings{}, LfResearches{ResearchAI: 1000}, 0)
+	assert.Equal(t, 0.99, bonuses.ResearchTimeReduction)
+
+	bonuses = GetLfBonuses(LfBuildings{}, LfResearches{FusionDrives: 10, LightFighterMkII: 10}, 0)
+	assert.InDelta(t, 0.04, bonuses.Ship(LightFighterID).Speed, 0.000001)
+	assert.InDelta(t, 0.01, bonuses.Ship(CruiserID).Speed, 0.000001)
+}
+
+func TestLfBonuses_ProductionBoost(t *testing.T) {
+	bonuses := GetLfBonuses(LfBuildings{HighEnergySmelting: 10}, LfResearches{RocktalCollectorEnhancement: 50}, 0)
+	assert.InDelta(t, 0.15, bonuses.ProductionBoost(General).Metal, 0.000001)
+	assert.InDelta(t, 0.175, bonuses.ProductionBoost(Collector).Metal, 0.000001)
+	assert.InDelta(t, 0.025, bonuses.ProductionBoost(Collector).Crystal, 0.000001)
+}
+
+func TestLfBonuses_CargoCapacity(t *testing.T) {
+	lc := newLargeCargo()
+	bonuses := GetLfBonuses(LfBuildings{}, LfResearches{CargoHoldExpansionCivilianShips: 10}, 0)
+	assert.Equal(t, int64(26000), bonuses.CargoCapacity(lc, Researches{}, false, false, false))
+	bonuses = GetLfBonuses(LfBuildings{}, LfResearches{CargoHoldExpansionCivilianShips: 10, RocktalCollectorEnhancement: 50}, 0)
+	assert.Equal(t, int64(32875), bonuses.CargoCapacity(lc, Researches{}, false, true, false))
+	ships := ShipsInfos{LargeCargo: 2, SmallCargo: 1}
+	assert.Equal(t, ships.Cargo(Researches{}, false, false, false), LfBonuses{}.FleetCargo(ships, Researches{}, false, false, false))
+	bonuses = GetLfBonuses(LfBuildings{}, LfResearches{CargoHoldExpansionCivilianShips: 10}, 0)
+	assert.Equal(t, int64(2*26000+5200), bonuses.FleetCargo(ships, Researches{}, false, false, false))
+}
+
+func TestLfBonuses_Speed(t *testing.T) {
+	lc := newLargeCargo()
+	bonuses := GetLfBonuses(LfBuildings{}, LfResearches{FusionDrives: 10}, 0)
+	assert.Equal(t, int64(12075), bonuses.Speed(lc, Researches{CombustionDrive: 6}, false, false))
+}
+
+func TestLfBonuses_FuelConsumption(t *testing.T) {
+	lc := newLargeCargo()
+	bonuses := GetLfBonuses(LfBuildings{}, LfResearches{EfficiencyModule: 10}, 0)
+	assert.Equal(t, int64(49), bonuses.FuelConsumption(lc, Researches{}, 1, false))
+	assert.Equal(t, int64(50), LfBonuses{}.FuelConsumption(lc, Researches{}, 1, false))
+}
+
+func TestLfBonuses_Times(t *testing.T) {
+	bonuses := GetLfBonuses(LfBuildings{Megalith: 5}, LfResearches{ResearchAI: 50, ImprovedLabTechnology: 10}, 0)
+	assert.Equal(t, 95*time.Second, bonuses.BuildingTime(100*time.Second))
+	assert.Equal(t, time.Duration(0), bonuses.BuildingTime(0))
+	assert.Equal(t, 54*time.Minute, bonuses.ResearchTime(time.Hour, false))
+	assert.Equal(t, Resources{Metal: 990, Crystal: 495}, bonuses.ResearchCost(Resources{Metal: 1000, Crystal: 500}))
+}
+
+func TestLfBonuses_ConstructionTime(t *testing.T) {
+	bonuses := GetLfBonuses(LfBuildings{Megalith: 5}, LfResearches{ResearchAI: 50}, 0)
+	facilities := Facilities{RoboticsFactory: 10, ResearchLab: 10}
+	assert.Equal(t, 17445*time.Second, bonuses.ConstructionTime(MetalMine, 23, 4, facilities, false, false))
+	research := EnergyTechnology.ConstructionTime(10, 4, facilities, false, false)
+	assert.Equal(t, bonuses.ResearchTime(research, false), bonuses.ConstructionTime(EnergyTechnology, 10, 4, facilities, false, false))
+	assert.Equal(t, SmallCargo.ConstructionTime(10, 4, facilities, false, false), bonuses.ConstructionTime(SmallCargo, 10, 4, facilities, false, false))
+	assert.Equal(t, ResidentialSector.LfBuildingConstructionTime(10, 4, facilities, bonuses), bonuses.ConstructionTime(ResidentialSector, 10, 4, facilities, false, false))
+}
+
+func TestLfBonusesPerLevelCoverage(t *testing.T) {
+	notModelled := make(map[ID]bool)
+	for _, id := range LfBonusesNotModelled {
+		_, inTable := LfBonusesPerLevel[id]
+		assert.False(t, inTable, id)
+		notModelled[id] = true
+	}
+	for id := ID(11101); id <= 14218; id++ {
+		if id.IsLfBuilding() || id.IsLfTech() {
+			_, inTable := LfBonusesPerLevel[id]
+			assert.True(t, inTable || notModelled[id], id)
+		}
+	}
+}
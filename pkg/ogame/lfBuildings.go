@@ -2,6 +2,7 @@ package ogame
 
 import (
 	"math"
+	"time"
 )
 
 // LazyLfBuildings ...
@@ -198,6 +199,29 @@ type BaseLfBuilding struct {
 	BaseBuilding
 	energyIncreaseFactor     float64
 	populationIncreaseFactor float64
+	durationBase             int64 // Seconds
+	durationIncreaseFactor   float64
+}
+
+// LfBuildingConstructionTime returns the duration it takes to build given level, with the lifeform bonuses reductions
+func (b BaseLfBuilding) LfBuildingConstructionTime(level, universeSpeed int64, acc BuildingAccelerators, bonuses LfBonuses) time.Duration {
+	roboticLvl := float64(acc.GetRoboticsFactory())
+	naniteLvl := float64(acc.GetNaniteFactory())
+	secs := float64(level) * float64(b.durationBase) * math.Pow(b.durationIncreaseFactor, float64(level))
+	secs /= (1 + roboticLvl) * math.Pow(2, naniteLvl) * float64(universeSpeed)
+	secs *= 1 - bonuses.LfBuildingTimeReduction
+	secs = math.Max(1, secs)
+	return time.Duration(int64(math.Floor(secs))) * time.Second
+}
+
+// BuildingConstructionTime returns the duration it takes to build given level
+func (b BaseLfBuilding) BuildingConstructionTime(level, universeSpeed int64, acc BuildingAccelerators) time.Duration {
+	return b.LfBuildingConstructionTime(level, universeSpeed, acc, LfBonuses{})
+}
+
+// ConstructionTime returns the duration it takes to build given level
+func (b BaseLfBuilding) ConstructionTime(level, universeSpeed int64, facilities BuildAccelerators, _, _ bool) time.Duration {
+	return b.BuildingConstructionTime(level, universeSpeed, facilities)
 }
 
 // GetPrice returns the price to build the given level
@@ -227,6 +251,8 @@ func newResidentialSector() *residentialSector {
 	b.Name = "residential sector"
 	b.ID = ResidentialSectorID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.21
 	b.BaseCost = Resources{Metal: 7, Crystal: 2}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -241,6 +267,8 @@ func newBiosphereFarm() *biosphereFarm {
 	b.Name = "biosphere farm"
 	b.ID = BiosphereFarmID
 	b.IncreaseFactor = 1.23
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.25
 	b.energyIncreaseFactor = 1.021
 	b.BaseCost = Resources{Metal: 5, Crystal: 2, Energy: 8}
 	b.Requirements = map[ID]int64{}
@@ -256,6 +284,8 @@ func newResearchCentre() *researchCentre {
 	b.Name = "research centre"
 	b.ID = ResearchCentreID
 	b.IncreaseFactor = 1.3
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 20000, Crystal: 25000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 12, BiosphereFarmID: 13}
 	return b
@@ -270,6 +300,8 @@ func newAcademyOfSciences() *academyOfSciences {
 	b.Name = "academy of sciences"
 	b.ID = AcademyOfSciencesID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.60
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 5000, Crystal: 3200, Deuterium: 1500, Population: 20000000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40}
@@ -285,6 +317,8 @@ func newNeuroCalibrationCentre() *neuroCalibrationCentre {
 	b.Name = "neuro calibration centre"
 	b.ID = NeuroCalibrationCentreID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 64000
+	b.durationIncreaseFactor = 1.70
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 50000, Crystal: 40000, Deuterium: 50000, Population: 100000000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40, AcademyOfSciencesID: 1, FusionPoweredProductionID: 1, SkyscraperID: 5}
@@ -300,6 +334,8 @@ func newHighEnergySmelting() *highEnergySmelting {
 	b.Name = "high energy smelting"
 	b.ID = HighEnergySmeltingID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7500, Crystal: 5000, Deuterium: 3000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 12, BiosphereFarmID: 13, ResearchCentreID: 5}
 	return b
@@ -314,6 +350,8 @@ func newFoodSilo() *foodSilo {
 	b.Name = "food silo"
 	b.ID = FoodSiloID
 	b.IncreaseFactor = 1.09
+	b.durationBase = 12000
+	b.durationIncreaseFactor = 1.17
 	b.BaseCost = Resources{Metal: 25000, Crystal: 13000, Deuterium: 7000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 12, BiosphereFarmID: 13, ResearchCentreID: 5, HighEnergySmeltingID: 3}
 	return b
@@ -328,6 +366,8 @@ func newFusionPoweredProduction() *fusionPoweredProduction {
 	b.Name = "fusion powered production"
 	b.ID = FusionPoweredProductionID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 28000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 50000, Crystal: 25000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40, AcademyOfSciencesID: 1}
 	return b
@@ -342,6 +382,8 @@ func newSkyscraper() *skyscraper {
 	b.Name = "skyscraper"
 	b.ID = SkyscraperID
 	b.IncreaseFactor = 1.09
+	b.durationBase = 40000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 75000, Crystal: 20000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40, AcademyOfSciencesID: 1, FusionPoweredProductionID: 1}
 	return b
@@ -356,6 +398,8 @@ func newBiotechLab() *biotechLab {
 	b.Name = "biotech lab"
 	b.ID = BiotechLabID
 	b.IncreaseFactor = 1.12
+	b.durationBase = 52000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 150000, Crystal: 30000, Deuterium: 15000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40, AcademyOfSciencesID: 1, FusionPoweredProductionID: 2}
 	return b
@@ -370,6 +414,8 @@ func newMetropolis() *metropolis {
 	b.Name = "metropolis"
 	b.ID = MetropolisID
 	b.IncreaseFactor = 1.12
+	b.durationBase = 90000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 80000, Crystal: 35000, Deuterium: 60000}
 	b.Requirements = map[ID]int64{ResidentialSectorID: 40, AcademyOfSciencesID: 1, FusionPoweredProductionID: 1, SkyscraperID: 5, NeuroCalibrationCentreID: 1}
 	return b
@@ -384,6 +430,8 @@ func newPlanetaryShield() *planetaryShield {
 	b.Name = "planetary shield"
 	b.ID = PlanetaryShieldID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 95000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 250000, Crystal: 125000, Deuterium: 125000}
 	b.Requirements = map[ID]int64{
 		ResidentialSectorID:       40,
@@ -409,6 +457,8 @@ func newMeditationEnclave() *meditationEnclave {
 	b.Name = "meditation enclave"
 	b.ID = MeditationEnclaveID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.21
 	b.BaseCost = Resources{Metal: 9, Crystal: 3}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -423,6 +473,8 @@ func newCrystalFarm() *crystalFarm {
 	b.Name = "crystal farm"
 	b.ID = CrystalFarmID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.21
 	b.energyIncreaseFactor = 1.03
 	b.BaseCost = Resources{Metal: 7, Crystal: 2, Energy: 10}
 	b.Requirements = map[ID]int64{}
@@ -438,6 +490,8 @@ func newRuneTechnologium() *runeTechnologium {
 	b.Name = "rune technologium"
 	b.ID = RuneTechnologiumID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 40000, Crystal: 10000, Deuterium: 15000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 21, CrystalFarmID: 22}
 	return b
@@ -452,6 +506,8 @@ func newRuneForge() *runeForge {
 	b.Name = "rune forge"
 	b.ID = RuneForgeID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.60
 	b.populationIncreaseFactor = 1.14
 	b.BaseCost = Resources{Metal: 5000, Crystal: 3800, Deuterium: 1000, Population: 16000000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41}
@@ -467,6 +523,8 @@ func newOriktorium() *oriktorium {
 	b.Name = "oriktorium"
 	b.ID = OriktoriumID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 64000
+	b.durationIncreaseFactor = 1.70
 	b.populationIncreaseFactor = 1.65
 	b.BaseCost = Resources{Metal: 50000, Crystal: 40000, Deuterium: 50000, Population: 90000000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, RuneForgeID: 1, MegalithID: 1, CrystalRefineryID: 5}
@@ -482,6 +540,8 @@ func newMagmaForge() *magmaForge {
 	b.Name = "magma forge"
 	b.ID = MagmaForgeID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 10000, Crystal: 8000, Deuterium: 1000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 21, CrystalFarmID: 22, RuneTechnologiumID: 5}
 	return b
@@ -496,6 +556,8 @@ func newDisruptionChamber() *disruptionChamber {
 	b.Name = "disruption chamber"
 	b.ID = DisruptionChamberID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 20000, Crystal: 15000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 21, CrystalFarmID: 22, RuneTechnologiumID: 5, MagmaForgeID: 3}
 	return b
@@ -510,6 +572,8 @@ func newMegalith() *megalith {
 	b.Name = "megalith"
 	b.ID = MegalithID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 40000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 50000, Crystal: 35000, Deuterium: 15000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, RuneForgeID: 1}
 	return b
@@ -524,6 +588,8 @@ func newCrystalRefinery() *crystalRefinery {
 	b.Name = "crystal refinery"
 	b.ID = CrystalRefineryID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 40000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 85000, Crystal: 44000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, RuneForgeID: 1, MegalithID: 1}
 	return b
@@ -538,6 +604,8 @@ func newDeuteriumSynthesiser() *deuteriumSynthesiser {
 	b.Name = "deuterium synthesiser"
 	b.ID = DeuteriumSynthesiserID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 52000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 120000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, RuneForgeID: 1, MegalithID: 2}
 	return b
@@ -552,6 +620,8 @@ func newMineralResearchCentre() *mineralResearchCentre {
 	b.Name = "mineral research centre"
 	b.ID = MineralResearchCentreID
 	b.IncreaseFactor = 1.80
+	b.durationBase = 90000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 250000, Crystal: 150000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, RuneForgeID: 1, MegalithID: 1, CrystalRefineryID: 6, OriktoriumID: 1}
 	return b
@@ -566,6 +636,8 @@ func newMetalRecyclingPlant() *metalRecyclingPlant {
 	b.Name = "metal recycling plant"
 	b.ID = MetalRecyclingPlantID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 95000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 250000, Crystal: 125000, Deuterium: 125000}
 	b.Requirements = map[ID]int64{MeditationEnclaveID: 41, CrystalFarmID: 22, RuneForgeID: 1, MegalithID: 5, CrystalRefineryID: 6, OriktoriumID: 5, RuneTechnologiumID: 5, MagmaForgeID: 3, DisruptionChamberID: 4, MineralResearchCentreID: 5}
 	return b
@@ -581,6 +653,8 @@ func newAssemblyLine() *assemblyLine {
 	b.Name = "assembly line"
 	b.ID = AssemblyLineID
 	b.IncreaseFactor = 1.21
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.22
 	b.BaseCost = Resources{Metal: 6, Crystal: 2}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -595,6 +669,8 @@ func newFusionCellFactory() *fusionCellFactory {
 	b.Name = "fusion cell factory"
 	b.ID = FusionCellFactoryID
 	b.IncreaseFactor = 1.18
+	b.durationBase = 48
+	b.durationIncreaseFactor = 1.21
 	b.energyIncreaseFactor = 1.02
 	b.BaseCost = Resources{Metal: 5, Crystal: 2, Energy: 8}
 	b.Requirements = map[ID]int64{}
@@ -610,6 +686,8 @@ func newRoboticsResearchCentre() *roboticsResearchCentre {
 	b.Name = "robotics research centre"
 	b.ID = RoboticsResearchCentreID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 30000, Crystal: 20000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 20, FusionCellFactoryID: 17}
 	return b
@@ -624,6 +702,8 @@ func newUpdateNetwork() *updateNetwork {
 	b.Name = "update network"
 	b.ID = UpdateNetworkID
 	b.IncreaseFactor = 1.80
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.60
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 5000, Crystal: 3800, Deuterium: 1000, Population: 40000000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41}
@@ -639,6 +719,8 @@ func newQuantumComputerCentre() *quantumComputerCentre {
 	b.Name = "quantum computer centre"
 	b.ID = QuantumComputerCentreID
 	b.IncreaseFactor = 1.80
+	b.durationBase = 64000
+	b.durationIncreaseFactor = 1.70
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 50000, Crystal: 40000, Deuterium: 50000, Population: 130000000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, UpdateNetworkID: 1, MicrochipAssemblyLineID: 1, ProductionAssemblyHallID: 5}
@@ -654,6 +736,8 @@ func newAutomatisedAssemblyCentre() *automatisedAssemblyCentre {
 	b.Name = "automatised assembly centre"
 	b.ID = AutomatisedAssemblyCentreID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7500, Crystal: 7000, Deuterium: 1000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 17, FusionCellFactoryID: 20, RoboticsResearchCentreID: 5}
 	return b
@@ -668,6 +752,8 @@ func newHighPerformanceTransformer() *highPerformanceTransformer {
 	b.Name = "high performance transformer"
 	b.ID = HighPerformanceTransformerID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 35000, Crystal: 15000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 17, FusionCellFactoryID: 20, RoboticsResearchCentreID: 5, AutomatisedAssemblyCentreID: 3}
 	return b
@@ -682,6 +768,8 @@ func newMicrochipAssemblyLine() *microchipAssemblyLine {
 	b.Name = "microchip assembly line"
 	b.ID = MicrochipAssemblyLineID
 	b.IncreaseFactor = 1.07
+	b.durationBase = 12000
+	b.durationIncreaseFactor = 1.17
 	b.BaseCost = Resources{Metal: 50000, Crystal: 20000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, UpdateNetworkID: 1}
 	return b
@@ -696,6 +784,8 @@ func newProductionAssemblyHall() *productionAssemblyHall {
 	b.Name = "production assembly hall"
 	b.ID = ProductionAssemblyHallID
 	b.IncreaseFactor = 1.14
+	b.durationBase = 40000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 100000, Crystal: 10000, Deuterium: 3000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, UpdateNetworkID: 1, MicrochipAssemblyLineID: 1}
 	return b
@@ -710,6 +800,8 @@ func newHighPerformanceSynthesiser() *highPerformanceSynthesiser {
 	b.Name = "high performance synthesiser"
 	b.ID = HighPerformanceSynthesiserID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 52000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 100000, Crystal: 40000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, UpdateNetworkID: 1, MicrochipAssemblyLineID: 2}
 	return b
@@ -724,6 +816,8 @@ func newChipMassProduction() *chipMassProduction {
 	b.Name = "chip mass production"
 	b.ID = ChipMassProductionID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 50000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 55000, Crystal: 50000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, UpdateNetworkID: 1, MicrochipAssemblyLineID: 1, ProductionAssemblyHallID: 6, QuantumComputerCentreID: 1}
 	return b
@@ -738,6 +832,8 @@ func newNanoRepairBots() *nanoRepairBots {
 	b.Name = "nano repair bots"
 	b.ID = NanoRepairBotsID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 95000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 250000, Crystal: 125000, Deuterium: 125000}
 	b.Requirements = map[ID]int64{AssemblyLineID: 41, FusionCellFactoryID: 20, MicrochipAssemblyLineID: 5, RoboticsResearchCentreID: 5, HighPerformanceTransformerID: 4, ProductionAssemblyHallID: 6, QuantumComputerCentreID: 5, ChipMassProductionID: 11}
 	return b
@@ -753,6 +849,8 @@ func newSanctuary() *sanctuary {
 	b.Name = "sanctuary"
 	b.ID = SanctuaryID
 	b.IncreaseFactor = 1.21
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.22
 	b.BaseCost = Resources{Metal: 4, Crystal: 3}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -767,6 +865,8 @@ func newAntimatterCondenser() *antimatterCondenser {
 	b.Name = "antimatter condenser"
 	b.ID = AntimatterCondenserID
 	b.IncreaseFactor = 1.21
+	b.durationBase = 40
+	b.durationIncreaseFactor = 1.22
 	b.energyIncreaseFactor = 1.02
 	b.BaseCost = Resources{Metal: 6, Crystal: 3, Energy: 9}
 	b.Requirements = map[ID]int64{}
@@ -782,6 +882,8 @@ func newVortexChamber() *vortexChamber {
 	b.Name = "vortex chamber"
 	b.ID = VortexChamberID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 20000, Crystal: 20000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{SanctuaryID: 20, AntimatterCondenserID: 21}
 	return b
@@ -796,6 +898,8 @@ func newHallsOfRealisation() *hallsOfRealisation {
 	b.Name = "halls of realisation"
 	b.ID = HallsOfRealisationID
 	b.IncreaseFactor = 1.80
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.70
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 7500, Crystal: 5000, Deuterium: 800, Population: 30000000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42}
@@ -811,6 +915,8 @@ func newForumOfTranscendence() *forumOfTranscendence {
 	b.Name = "forum of transcendence"
 	b.ID = ForumOfTranscendenceID
 	b.IncreaseFactor = 1.80
+	b.durationBase = 64000
+	b.durationIncreaseFactor = 1.70
 	b.populationIncreaseFactor = 1.10
 	b.BaseCost = Resources{Metal: 60000, Crystal: 30000, Deuterium: 50000, Population: 100000000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, HallsOfRealisationID: 1, ChrysalisAcceleratorID: 1, BioModifierID: 5}
@@ -826,6 +932,8 @@ func newAntimatterConvector() *antimatterConvector {
 	b.Name = "antimatter convector"
 	b.ID = AntimatterConvectorID
 	b.IncreaseFactor = 1.25
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 8500, Crystal: 5000, Deuterium: 3000}
 	b.Requirements = map[ID]int64{SanctuaryID: 20, AntimatterCondenserID: 21, VortexChamberID: 5}
 	return b
@@ -840,6 +948,8 @@ func newCloningLaboratory() *cloningLaboratory {
 	b.Name = "cloning laboratory"
 	b.ID = CloningLaboratoryID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 12000
+	b.durationIncreaseFactor = 1.17
 	b.BaseCost = Resources{Metal: 15000, Crystal: 15000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{SanctuaryID: 20, AntimatterCondenserID: 21, VortexChamberID: 5, AntimatterConvectorID: 3}
 	return b
@@ -854,6 +964,8 @@ func newChrysalisAccelerator() *chrysalisAccelerator {
 	b.Name = "chrysalis accelerator"
 	b.ID = ChrysalisAcceleratorID
 	b.IncreaseFactor = 1.05
+	b.durationBase = 16000
+	b.durationIncreaseFactor = 1.25
 	b.BaseCost = Resources{Metal: 75000, Crystal: 25000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, HallsOfRealisationID: 1}
 	return b
@@ -868,6 +980,8 @@ func newBioModifier() *bioModifier {
 	b.Name = "bio modifier"
 	b.ID = BioModifierID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 40000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 87500, Crystal: 25000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, HallsOfRealisationID: 1, ChrysalisAcceleratorID: 1}
 	return b
@@ -882,6 +996,8 @@ func newPsionicModulator() *psionicModulator {
 	b.Name = "psionic modulator"
 	b.ID = PsionicModulatorID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 52000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 150000, Crystal: 30000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, HallsOfRealisationID: 1, ChrysalisAcceleratorID: 2}
 	return b
@@ -896,6 +1012,8 @@ func newShipManufacturingHall() *shipManufacturingHall {
 	b.Name = "ship manufacturing hall"
 	b.ID = ShipManufacturingHallID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 90000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 75000, Crystal: 50000, Deuterium: 55000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, HallsOfRealisationID: 1, ChrysalisAcceleratorID: 1, BioModifierID: 6, ForumOfTranscendenceID: 1}
 	return b
@@ -910,6 +1028,8 @@ func newSupraRefractor() *supraRefractor {
 	b.Name = "suprarefractor"
 	b.ID = SupraRefractorID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 95000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 500000, Crystal: 250000, Deuterium: 250000}
 	b.Requirements = map[ID]int64{SanctuaryID: 42, AntimatterCondenserID: 21, VortexChamberID: 5, AntimatterConvectorID: 3, CloningLaboratoryID: 4, HallsOfRealisationID: 1, ChrysalisAcceleratorID: 5, BioModifierID: 6, ForumOfTranscendenceID: 5, ShipManufacturingHallID: 5}
 	return b
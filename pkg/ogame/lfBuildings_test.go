@@ -1,8 +1,10 @@
 package ogame
 
 import (
-	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestResidentialSectorCost(t *testing.T) {
@@ -25,7 +27,24 @@ func TestResearchCenterCost(t *testing.T) {
 	assert.Equal(t, Resources{Metal: 52000, Crystal: 65000, Deuterium: 26000}, a.GetPrice(2))
 }
 
-//func TestResidentialSectorConstructionTime(t *testing.T) {
-//	rs := newResidentialSector()
-//	assert.Equal(t, (6*60*60+58*60+48)*time.Second, rs.ConstructionTime(35, 4, Facilities{}, false, false))
-//}
+func TestResidentialSectorConstructionTime(t *testing.T) {
+	rs := newResidentialSector()
+	// All the durations below were captured on the same planet of s186-en. The captures do not show the facilities nor the
+	// universe speed, the formulas only depend on (1 + robotics) * 2^nanite * speed = 44, so a robotics factory 10 on a x4 universe.
+	facilities := Facilities{RoboticsFactory: 10}
+	// samples/v9.0.4/en/lifeform/technologyDetails_supplies.html, metal mine level 23 (formula of the regular buildings)
+	assert.Equal(t, (5*60*60+6*60+4)*time.Second, newMetalMine().ConstructionTime(23, 4, facilities, false, false))
+	// samples/v9.0.4/en/lifeform/technologyDetails_lfbuilding_teardown_enabled.html, residential sector level 35
+	assert.Equal(t, (6*60*60+58*60+48)*time.Second, rs.ConstructionTime(35, 4, facilities, false, false))
+	// samples/v9.0.4/en/lifeform/technologyDetails_1.html, neuro-calibration centre level 1
+	assert.Equal(t, (41*60+12)*time.Second, newNeuroCalibrationCentre().ConstructionTime(1, 4, facilities, false, false))
+	// samples/v9.0.2/en/lifeform/overview_all_queues.html, residential sector level 28 in the queue,
+	// halving the time "reduces by 50% of the total construction time (44m 7s)"
+	assert.Equal(t, 5293*time.Second, rs.ConstructionTime(28, 4, facilities, false, false))
+	// samples/v9.0.2/en/lifeform/overview_all_queues2.html, biosphere farm level 30 in the queue, "(3h 3m)"
+	assert.Equal(t, 3*time.Hour+3*time.Minute, (newBiosphereFarm().ConstructionTime(30, 4, facilities, false, false) / 2).Truncate(time.Minute))
+
+	assert.Equal(t, 48*time.Second, rs.ConstructionTime(1, 1, Facilities{}, false, false))
+	bonuses := LfBonuses{LfBuildingTimeReduction: 0.5}
+	assert.Equal(t, 24*time.Second, rs.LfBuildingConstructionTime(1, 1, Facilities{}, bonuses))
+}
@@ -0,0 +1,175 @@
+package ogame
+
+import (
+	"math"
+	"time"
+)
+
+// lfPopulationParams parameters of the population and food buildings of a species.
+// The living space of a level is base * (level+1) * factor^level, the growth and food are base * level * factor^level.
+type lfPopulationParams struct {
+	residentialID     ID // Building providing the living space and the growth
+	livingSpace       float64
+	livingSpaceFactor float64
+	growth            float64 // Citizens per hour
+	growthFactor      float64
+	foodID            ID      // Building producing the food
+	food              float64 // Food per hour
+	foodFactor        float64
+	consumption       float64 // Food consumed per citizen per hour
+	livingSpaceSource string  // Sample the living space is checked against, empty when it is not checked
+}
+
+// The humans and rock'tal living space is checked against the population tooltips of samples/v9.0.4/en/lfbuildings.html
+// and samples/v9.0.4/en/lifeform/lfbuildings_rocktal.html. The growth, food and consumption values, the mechas
+// and kaelesh living space and the Skyscraper and Food Silo bonuses are not covered by a sample.
+var lfPopulationParamsPerSpecies = map[LifeformType]lfPopulationParams{
+	Humans: {
+		residentialID: ResidentialSectorID, livingSpace: 210, livingSpaceFactor: 1.21, growth: 16, growthFactor: 1.15,
+		foodID: BiosphereFarmID, food: 10, foodFactor: 1.14, consumption: 0.002,
+		livingSpaceSource: "samples/v9.0.4/en/lfbuildings.html",
+	},
+	Rocktal: {
+		residentialID: MeditationEnclaveID, livingSpace: 150, livingSpaceFactor: 1.216, growth: 12, growthFactor: 1.15,
+		foodID: CrystalFarmID, food: 8, foodFactor: 1.143, consumption: 0.002,
+		livingSpaceSource: "samples/v9.0.4/en/lifeform/lfbuildings_rocktal.html",
+	},
+	Mechas: {
+		residentialID: AssemblyLineID, livingSpace: 500, livingSpaceFactor: 1.205, growth: 24, growthFactor: 1.15,
+		foodID: FusionCellFactoryID, food: 18, foodFactor: 1.15, consumption: 0.002,
+	},
+	Kaelesh: {
+		residentialID: SanctuaryID, livingSpace: 250, livingSpaceFactor: 1.21, growth: 16, growthFactor: 1.15,
+		foodID: AntimatterCondenserID, food: 12, foodFactor: 1.14, consumption: 0.002,
+	},
+}
+
+const (
+	skyscraperLivingSpaceBonus  = 0.015 // Living space bonus per level of the Skyscraper
+	foodSiloConsumptionDecrease = 0.01  // Food consumption reduction per level of the Food Silo
+)
+
+// LfResearchTierPopulation population needed to research the technologies of each tier (index 1 to 3)
+var LfResearchTierPopulation = [...]int64{0, 0, 1_200_000, 13_000_000}
+
+// LfResearchTier returns the tier (1 to 3) of a lifeform technology, 0 if the id is not a lifeform technology.
+// Slots 1-6 are tier 1, slots 7-12 tier 2 and slots 13-18 tier 3.
+func LfResearchTier(id ID) int64 {
+	if !id.IsLfTech() {
+		return 0
+	}
+	slot := int64(id) % 100
+	return (slot-1)/6 + 1
+}
+
+// LfResearchPopulation returns the population needed to research a lifeform technology
+func LfResearchPopulation(id ID) int64 {
+	return LfResearchTierPopulation[LfResearchTier(id)]
+}
+
+// LfPopulation population and food economy of a planet, given its lifeform buildings
+type LfPopulation struct {
+	LifeformType       LifeformType
+	LivingSpace        int64   // Maximum population
+	GrowthPerHour      float64 // Citizens born per hour while there is food and living space
+	FoodProduction     float64 // Food produced per hour
+	ConsumptionPerHead float64 // Food consumed per citizen per hour
+	LivingSpaceChecked bool    // The living space of the species is checked against a sample (humans and rock'tal only)
+}
+
+// GetLfPopulation computes the population model of a planet from its lifeform buildings
+func GetLfPopulation(buildings LfBuildings) LfPopulation {
+	out := LfPopulation{LifeformType: buildings.LifeformType}
+	params, ok := lfPopulationParamsPerSpecies[buildings.LifeformType]
+	if !ok {
+		return out
+	}
+	out.LivingSpaceChecked = params.livingSpaceSource != ""
+	perLevel := func(base, factor float64, level int64) float64 {
+		return base * float64(level) * math.Pow(factor, float64(level))
+	}
+	residentialLvl := buildings.ByID(params.residentialID)
+	var livingSpace float64
+	if residentialLvl > 0 {
+		livingSpace = params.livingSpace * float64(residentialLvl+1) * math.Pow(params.livingSpaceFactor, float64(residentialLvl))
+	}
+	out.LivingSpace = int64(livingSpace * (1 + float64(buildings.Skyscraper)*skyscraperLivingSpaceBonus))
+	out.GrowthPerHour = perLevel(params.growth, params.growthFactor, residentialLvl)
+	out.FoodProduction = perLevel(params.food, params.foodFactor, buildings.ByID(params.foodID))
+	reduction := math.Min(float64(buildings.FoodSilo)*foodSiloConsumptionDecrease, maxLfReduction)
+	out.ConsumptionPerHead = params.consumption * (1 - reduction)
+	return out
+}
+
+// FoodConsumption returns the food consumed per hour by the given population
+func (p LfPopulation) FoodConsumption(population int64) float64 {
+	return float64(population) * p.ConsumptionPerHead
+}
+
+// FoodBalance returns the food produced minus the food consumed per hour by the given population
+func (p LfPopulation) FoodBalance(population int64) float64 {
+	return p.FoodProduction - p.FoodConsumption(population)
+}
+
+// MaxFedPopulation returns the biggest population the food production can feed without using the stock
+func (p LfPopulation) MaxFedPopulation() int64 {
+	if p.ConsumptionPerHead <= 0 {
+		return p.LivingSpace
+	}
+	return int64(p.FoodProduction / p.ConsumptionPerHead)
+}
+
+// maxPopulationForecast longest period simulated by TimeToPopulation
+const maxPopulationForecast = 365 * 24 * time.Hour
+
+// TimeToPopulation returns the time needed for the population to reach target, given the current population and food stock.
+// The population grows as long as there is living space and food (produced or in stock).
+// Returns false if the target can't be reached with the current buildings.
+// This is an approximation: the growth is constant until the target is reached and every citizen consumes
+// the same amount of food. The game growth rate changes with the satisfied population, and the T2 and T3
+// lifeforms are ignored, so the result is an estimate and not the time the game will display.
+func (p LfPopulation) TimeToPopulation(population, food, target int64) (time.Duration, bool) {
+	if population >= target {
+		return 0, true
+	}
+	if target > p.LivingSpace || p.GrowthPerHour <= 0 {
+		return 0, false
+	}
+	pop := float64(population)
+	stock := float64(food)
+	for hours := time.Duration(0); hours < maxPopulationForecast; hours += time.Hour {
+		stock += p.FoodBalance(int64(pop))
+		if stock < 0 {
+			// Starving citizens stop growing, the stock can't refill if production already can't feed them
+			return 0, false
+		}
+		remaining := float64(target) - pop
+		if remaining <= p.GrowthPerHour {
+			secs := math.Ceil(remaining / p.GrowthPerHour * 3600)
+			return hours + time.Duration(secs)*time.Second, true
+		}
+		pop += p.GrowthPerHour
+	}
+	return 0, false
+}
+
+// LfResearchTierForecast time needed for the population to unlock a research tier, see TimeToLfResearchTier
+type LfResearchTierForecast struct {
+	Duration           time.Duration
+	LifeformType       LifeformType
+	Estimate           bool // The duration is an approximation, the growth, food and consumption values are not sourced
+	LivingSpaceChecked bool // The living space of the species is checked against a sample, only humans and rock'tal are
+}
+
+// TimeToLfResearchTier returns the time needed for the population to unlock the given research tier.
+// The forecast is always an estimate (see TimeToPopulation), and for the mechas and kaelesh the living space
+// is not checked either. Returns false if the tier can't be reached with the current buildings.
+func (p LfPopulation) TimeToLfResearchTier(tier, population, food int64) (LfResearchTierForecast, bool) {
+	forecast := LfResearchTierForecast{LifeformType: p.LifeformType, Estimate: true, LivingSpaceChecked: p.LivingSpaceChecked}
+	if tier < 1 || tier >= int64(len(LfResearchTierPopulation)) {
+		return forecast, false
+	}
+	d, ok := p.TimeToPopulation(population, food, LfResearchTierPopulation[tier])
+	forecast.Duration = d
+	return forecast, ok
+}
@@ -0,0 +1,83 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLfResearchTier(t *testing.T) {
+	assert.Equal(t, int64(1), LfResearchTier(IntergalacticEnvoysID))
+	assert.Equal(t, int64(1), LfResearchTier(ResearchAIID))
+	assert.Equal(t, int64(2), LfResearchTier(EnhancedProductionTechnologiesID))
+	assert.Equal(t, int64(3), LfResearchTier(SupercomputerID))
+	assert.Equal(t, int64(0), LfResearchTier(ResidentialSectorID))
+	assert.Equal(t, int64(13_000_000), LfResearchPopulation(KaeleshDiscovererEnhancementID))
+	assert.Equal(t, int64(0), LfResearchPopulation(HeatRecoveryID))
+}
+
+func TestGetLfPopulation(t *testing.T) {
+	// Living space displayed in samples/v9.0.4/en/lfbuildings.html and samples/v9.0.4/en/lifeform/lfbuildings_rocktal.html
+	assert.Equal(t, int64(922), GetLfPopulation(LfBuildings{LifeformType: Humans, ResidentialSector: 2, BiosphereFarm: 1}).LivingSpace)
+	assert.Equal(t, int64(665), GetLfPopulation(LfBuildings{LifeformType: Rocktal, MeditationEnclave: 2, CrystalFarm: 1}).LivingSpace)
+	assert.Equal(t, int64(0), GetLfPopulation(LfBuildings{LifeformType: Humans}).LivingSpace)
+
+	pop := GetLfPopulation(LfBuildings{LifeformType: Humans, ResidentialSector: 10, BiosphereFarm: 10})
+	assert.Equal(t, int64(15540), pop.LivingSpace)
+	assert.InDelta(t, 647.29, pop.GrowthPerHour, 0.01)
+	assert.InDelta(t, 370.72, pop.FoodProduction, 0.01)
+	assert.Equal(t, int64(185361), pop.MaxFedPopulation())
+
+	pop = GetLfPopulation(LfBuildings{LifeformType: Humans, ResidentialSector: 10, Skyscraper: 10, FoodSilo: 10})
+	assert.Equal(t, int64(17871), pop.LivingSpace)
+	assert.InDelta(t, 0.0018, pop.ConsumptionPerHead, 0.000001)
+
+	assert.Equal(t, LfPopulation{}, GetLfPopulation(LfBuildings{ResidentialSector: 10}))
+}
+
+func TestLfPopulation_TimeToLfResearchTier(t *testing.T) {
+	pop := GetLfPopulation(LfBuildings{LifeformType: Humans, ResidentialSector: 40, BiosphereFarm: 40})
+	assert.True(t, pop.LivingSpaceChecked)
+	forecast, ok := pop.TimeToLfResearchTier(2, 1_000_000, 0)
+	assert.True(t, ok)
+	assert.True(t, forecast.Estimate)
+	assert.True(t, forecast.LivingSpaceChecked)
+	assert.Equal(t, Humans, forecast.LifeformType)
+	assert.Greater(t, forecast.Duration, time.Duration(0))
+
+	pop = GetLfPopulation(LfBuildings{LifeformType: Mechas, AssemblyLine: 40, FusionCellFactory: 40})
+	assert.False(t, pop.LivingSpaceChecked)
+	forecast, ok = pop.TimeToLfResearchTier(2, 1_000_000, 0)
+	assert.True(t, ok)
+	assert.True(t, forecast.Estimate)
+	assert.False(t, forecast.LivingSpaceChecked)
+	assert.Equal(t, Mechas, forecast.LifeformType)
+}
+
+func TestLfPopulation_TimeToPopulation(t *testing.T) {
+	pop := LfPopulation{LivingSpace: 10000, GrowthPerHour: 1000, FoodProduction: 100, ConsumptionPerHead: 0.01}
+	d, ok := pop.TimeToPopulation(1000, 0, 2500)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Minute, d)
+	d, ok = pop.TimeToPopulation(3000, 0, 2500)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	// More than the living space
+	_, ok = pop.TimeToPopulation(1000, 0, 20000)
+	assert.False(t, ok)
+
+	// Food production feeds 2000 citizens, stock is needed above that
+	pop.FoodProduction = 20
+	_, ok = pop.TimeToPopulation(1000, 0, 9000)
+	assert.False(t, ok)
+	d, ok = pop.TimeToPopulation(1000, 1000, 9000)
+	assert.True(t, ok)
+	assert.Equal(t, 8*time.Hour, d)
+
+	_, ok = pop.TimeToLfResearchTier(2, 1000, 0)
+	assert.False(t, ok)
+	_, ok = pop.TimeToLfResearchTier(4, 1000, 0)
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package ogame
 
 import (
 	"math"
+	"time"
 )
 
 // LazyLfResearches ...
@@ -240,6 +241,28 @@ func (b LfResearches) ByID(id ID) int64 {
 // BaseLfResearch base struct for Lifeform techs
 type BaseLfResearch struct {
 	BaseTechnology
+	durationBase           int64 // Seconds
+	durationIncreaseFactor float64
+}
+
+// LfResearchConstructionTime returns the duration it takes to research given level, with the lifeform bonuses reductions.
+// The research lab, the technocrat and the discoverer have no effect on lifeform techs.
+func (b BaseLfResearch) LfResearchConstructionTime(level, universeSpeed int64, bonuses LfBonuses) time.Duration {
+	secs := float64(level) * float64(b.durationBase) * math.Pow(b.durationIncreaseFactor, float64(level))
+	secs /= float64(universeSpeed)
+	secs *= 1 - bonuses.LfResearchTimeReduction
+	secs = math.Max(1, secs)
+	return time.Duration(int64(math.Floor(secs))) * time.Second
+}
+
+// TechnologyConstructionTime returns the duration it takes to research given level
+func (b BaseLfResearch) TechnologyConstructionTime(level, universeSpeed int64, _ TechAccelerators, _, _ bool) time.Duration {
+	return b.LfResearchConstructionTime(level, universeSpeed, LfBonuses{})
+}
+
+// ConstructionTime same as TechnologyConstructionTime, needed for BaseOgameObj implementation
+func (b BaseLfResearch) ConstructionTime(level, universeSpeed int64, facilities BuildAccelerators, hasTechnocrat, isDiscoverer bool) time.Duration {
+	return b.TechnologyConstructionTime(level, universeSpeed, facilities, hasTechnocrat, isDiscoverer)
 }
 
 // GetPrice returns the price to build the given level
@@ -264,6 +287,8 @@ func newIntergalacticEnvoys() *intergalacticEnvoys {
 	b.Name = "IntergalacticEnvoys"
 	b.ID = IntergalacticEnvoysID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 1000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 5000, Crystal: 2500, Deuterium: 500}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -278,6 +303,8 @@ func newHighPerformanceExtractors() *highPerformanceExtractors {
 	b.Name = "HighPerformanceExtractors"
 	b.ID = HighPerformanceExtractorsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7000, Crystal: 10000, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -292,6 +319,8 @@ func newFusionDrives() *fusionDrives {
 	b.Name = "FusionDrives"
 	b.ID = FusionDrivesID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 2500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 15000, Crystal: 10000, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -306,6 +335,8 @@ func newStealthFieldGenerator() *stealthFieldGenerator {
 	b.Name = "StealthFieldGenerator"
 	b.ID = StealthFieldGeneratorID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 3500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 20000, Crystal: 15000, Deuterium: 7500}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -320,6 +351,8 @@ func newOrbitalDen() *orbitalDen {
 	b.Name = "OrbitalDen"
 	b.ID = OrbitalDenID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 4500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 25000, Crystal: 20000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -334,6 +367,8 @@ func newResearchAI() *researchAI {
 	b.Name = "ResearchAI"
 	b.ID = ResearchAIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 5000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 35000, Crystal: 25000, Deuterium: 15000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -348,6 +383,8 @@ func newHighPerformanceTerraformer() *highPerformanceTerraformer {
 	b.Name = "HighPerformanceTerraformer"
 	b.ID = HighPerformanceTerraformerID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 8000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 70000, Crystal: 40000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -362,6 +399,8 @@ func newEnhancedProductionTechnologies() *enhancedProductionTechnologies {
 	b.Name = "EnhancedProductionTechnologies"
 	b.ID = EnhancedProductionTechnologiesID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 80000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -376,6 +415,8 @@ func newLightFighterMkII() *lightFighterMkII {
 	b.Name = "LightFighterMkII"
 	b.ID = LightFighterMkIIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -390,6 +431,8 @@ func newCruiserMkII() *cruiserMkII {
 	b.Name = "CruiserMkII"
 	b.ID = CruiserMkIIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 7000
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -404,6 +447,8 @@ func newImprovedLabTechnology() *improvedLabTechnology {
 	b.Name = "ImprovedLabTechnology"
 	b.ID = ImprovedLabTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 7500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 120000, Crystal: 30000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -418,6 +463,8 @@ func newPlasmaTerraformer() *plasmaTerraformer {
 	b.Name = "PlasmaTerraformer"
 	b.ID = PlasmaTerraformerID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 100000, Crystal: 40000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -432,6 +479,8 @@ func newLowTemperatureDrives() *lowTemperatureDrives {
 	b.Name = "LowTemperatureDrives"
 	b.ID = LowTemperatureDrivesID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 8500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 200000, Crystal: 100000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -446,6 +495,8 @@ func newBomberMkII() *bomberMkII {
 	b.Name = "BomberMkII"
 	b.ID = BomberMkIIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -460,6 +511,8 @@ func newDestroyerMkII() *destroyerMkII {
 	b.Name = "DestroyerMkII"
 	b.ID = DestroyerMkIIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -474,6 +527,8 @@ func newBattlecruiserMkII() *battlecruiserMkII {
 	b.Name = "BattlecruiserMkII"
 	b.ID = BattlecruiserMkIIID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -488,6 +543,8 @@ func newRobotAssistants() *robotAssistants {
 	b.Name = "robotAssistants"
 	b.ID = RobotAssistantsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 11000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 300000, Crystal: 180000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -502,6 +559,8 @@ func newSupercomputer() *supercomputer {
 	b.Name = "Supercomputer"
 	b.ID = SupercomputerID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 13000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 500000, Crystal: 300000, Deuterium: 200000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -517,6 +576,8 @@ func newVolcanicBatteries() *volcanicBatteries {
 	b.Name = "VolcanicBatteries"
 	b.ID = VolcanicBatteriesID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 1000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 10000, Crystal: 6000, Deuterium: 1000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -531,6 +592,8 @@ func newAcousticScanning() *acousticScanning {
 	b.Name = "AcousticScanning"
 	b.ID = AcousticScanningID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7500, Crystal: 12500, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -545,6 +608,8 @@ func newHighEnergyPumpSystems() *highEnergyPumpSystems {
 	b.Name = "HighEnergyPumpSystems"
 	b.ID = HighEnergyPumpSystemsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 15000, Crystal: 10000, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -559,6 +624,8 @@ func newCargoHoldExpansionCivilianShips() *cargoHoldExpansionCivilianShips {
 	b.Name = "CargoHoldExpansionCivilianShips"
 	b.ID = CargoHoldExpansionCivilianShipsID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 3500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 20000, Crystal: 15000, Deuterium: 7500}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -573,6 +640,8 @@ func newMagmaPoweredProduction() *magmaPoweredProduction {
 	b.Name = "MagmaPoweredProduction"
 	b.ID = MagmaPoweredProductionID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 4500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 25000, Crystal: 20000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -587,6 +656,8 @@ func newGeothermalPowerPlants() *geothermalPowerPlants {
 	b.Name = "GeothermalPowerPlants"
 	b.ID = GeothermalPowerPlantsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 5000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 50000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -601,6 +672,8 @@ func newDepthSounding() *depthSounding {
 	b.Name = "DepthSounding"
 	b.ID = DepthSoundingID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 8000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 70000, Crystal: 40000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -615,6 +688,8 @@ func newIonCrystalEnhancementHeavyFighter() *ionCrystalEnhancementHeavyFighter {
 	b.Name = "IonCrystalEnhancementHeavyFighter"
 	b.ID = IonCrystalEnhancementHeavyFighterID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -629,6 +704,8 @@ func newImprovedStellarator() *improvedStellarator {
 	b.Name = "ImprovedStellarator"
 	b.ID = ImprovedStellaratorID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 75000, Crystal: 55000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -643,6 +720,8 @@ func newHardenedDiamondDrillHeads() *hardenedDiamondDrillHeads {
 	b.Name = "HardenedDiamondDrillHeads"
 	b.ID = HardenedDiamondDrillHeadsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 7000
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 85000, Crystal: 40000, Deuterium: 35000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -657,6 +736,8 @@ func newSeismicMiningTechnology() *seismicMiningTechnology {
 	b.Name = "SeismicMiningTechnology"
 	b.ID = SeismicMiningTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 7500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 120000, Crystal: 30000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -671,6 +752,8 @@ func newMagmaPoweredPumpSystems() *magmaPoweredPumpSystems {
 	b.Name = "MagmaPoweredPumpSystems"
 	b.ID = MagmaPoweredPumpSystemsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 100000, Crystal: 40000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -685,6 +768,8 @@ func newIonCrystalModules() *ionCrystalModules {
 	b.Name = "IonCrystalModules"
 	b.ID = IonCrystalModulesID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 8500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 200000, Crystal: 100000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -699,6 +784,8 @@ func newOptimisedSiloConstructionMethod() *optimisedSiloConstructionMethod {
 	b.Name = "OptimisedSiloConstructionMethod"
 	b.ID = OptimisedSiloConstructionMethodID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 9000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 220000, Crystal: 110000, Deuterium: 110000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -713,6 +800,8 @@ func newDiamondEnergyTransmitter() *diamondEnergyTransmitter {
 	b.Name = "DiamondEnergyTransmitter"
 	b.ID = DiamondEnergyTransmitterID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 9500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 240000, Crystal: 120000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -727,6 +816,8 @@ func newObsidianShieldReinforcement() *obsidianShieldReinforcement {
 	b.Name = "ObsidianShieldReinforcement"
 	b.ID = ObsidianShieldReinforcementID
 	b.IncreaseFactor = 1.40
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 250000, Crystal: 250000, Deuterium: 250000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -741,6 +832,8 @@ func newRuneShields() *runeShields {
 	b.Name = "RuneShields"
 	b.ID = RuneShieldsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 11000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 500000, Crystal: 300000, Deuterium: 200000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -755,6 +848,8 @@ func newRocktalCollectorEnhancement() *rocktalCollectorEnhancement {
 	b.Name = "RocktalCollectorEnhancement"
 	b.ID = RocktalCollectorEnhancementID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 13000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 300000, Crystal: 180000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -771,6 +866,8 @@ func newCatalyserTechnology() *catalyserTechnology {
 	b.Name = "CatalyserTechnology"
 	b.ID = CatalyserTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 1000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 10000, Crystal: 6000, Deuterium: 1000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -785,6 +882,8 @@ func newPlasmaDrive() *plasmaDrive {
 	b.Name = "PlasmaDrive"
 	b.ID = PlasmaDriveID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7500, Crystal: 12500, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -799,6 +898,8 @@ func newEfficiencyModule() *efficiencyModule {
 	b.Name = "EfficiencyModule"
 	b.ID = EfficiencyModuleID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 15000, Crystal: 10000, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -813,6 +914,8 @@ func newDepotAI() *depotAI {
 	b.Name = "DepotAI"
 	b.ID = DepotAIID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 3500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 20000, Crystal: 15000, Deuterium: 7500}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -827,6 +930,8 @@ func newGeneralOverhaulLightFighter() *generalOverhaulLightFighter {
 	b.Name = "GeneralOverhaulLightFighter"
 	b.ID = GeneralOverhaulLightFighterID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 4500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -841,6 +946,8 @@ func newAutomatedTransportLines() *automatedTransportLines {
 	b.Name = "AutomatedTransportLines"
 	b.ID = AutomatedTransportLinesID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 5000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 50000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -855,6 +962,8 @@ func newImprovedDroneAI() *improvedDroneAI {
 	b.Name = "ImprovedDroneAI"
 	b.ID = ImprovedDroneAIID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 8000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 70000, Crystal: 40000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -869,6 +978,8 @@ func newExperimentalRecyclingTechnology() *experimentalRecyclingTechnology {
 	b.Name = "ExperimentalRecyclingTechnology"
 	b.ID = ExperimentalRecyclingTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -883,6 +994,8 @@ func newGeneralOverhaulCruiser() *generalOverhaulCruiser {
 	b.Name = "GeneralOverhaulCruiser"
 	b.ID = GeneralOverhaulCruiserID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -897,6 +1010,8 @@ func newSlingshotAutopilot() *slingshotAutopilot {
 	b.Name = "SlingshotAutopilot"
 	b.ID = SlingshotAutopilotID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 7000
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 85000, Crystal: 40000, Deuterium: 35000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -911,6 +1026,8 @@ func newHighTemperatureSuperconductors() *highTemperatureSuperconductors {
 	b.Name = "HighTemperatureSuperconductors"
 	b.ID = HighTemperatureSuperconductorsID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 7500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 120000, Crystal: 30000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -925,6 +1042,8 @@ func newGeneralOverhaulBattleship() *generalOverhaulBattleship {
 	b.Name = "GeneralOverhaulBattleship"
 	b.ID = GeneralOverhaulBattleshipID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -939,6 +1058,8 @@ func newArtificialSwarmIntelligence() *artificialSwarmIntelligence {
 	b.Name = "ArtificialSwarmIntelligence"
 	b.ID = ArtificialSwarmIntelligenceID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 8500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 200000, Crystal: 100000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -953,6 +1074,8 @@ func newGeneralOverhaulBattlecruiser() *generalOverhaulBattlecruiser {
 	b.Name = "GeneralOverhaulBattlecruiser"
 	b.ID = GeneralOverhaulBattlecruiserID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -967,6 +1090,8 @@ func newGeneralOverhaulBomber() *generalOverhaulBomber {
 	b.Name = "GeneralOverhaulBomber"
 	b.ID = GeneralOverhaulBomberID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -981,6 +1106,8 @@ func newGeneralOverhaulDestroyer() *generalOverhaulDestroyer {
 	b.Name = "GeneralOverhaulDestroyer"
 	b.ID = GeneralOverhaulDestroyerID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -995,6 +1122,8 @@ func newExperimentalWeaponsTechnology() *experimentalWeaponsTechnology {
 	b.Name = "ExperimentalWeaponsTechnology"
 	b.ID = ExperimentalWeaponsTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 11000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 500000, Crystal: 300000, Deuterium: 200000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1009,6 +1138,8 @@ func newMechanGeneralEnhancement() *mechanGeneralEnhancement {
 	b.Name = "MechanGeneralEnhancement"
 	b.ID = MechanGeneralEnhancementID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 13000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 300000, Crystal: 180000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1024,6 +1155,8 @@ func newHeatRecovery() *heatRecovery {
 	b.Name = "HeatRecovery"
 	b.ID = HeatRecoveryID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 1000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 10000, Crystal: 6000, Deuterium: 1000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1038,6 +1171,8 @@ func newSulphideProcess() *sulphideProcess {
 	b.Name = "SulphideProcess"
 	b.ID = SulphideProcessID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 7500, Crystal: 12500, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1052,6 +1187,8 @@ func newPsionicNetwork() *psionicNetwork {
 	b.Name = "PsionicNetwork"
 	b.ID = PsionicNetworkID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 2500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 15000, Crystal: 10000, Deuterium: 5000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1066,6 +1203,8 @@ func newTelekineticTractorBeam() *telekineticTractorBeam {
 	b.Name = "TelekineticTractorBeam"
 	b.ID = TelekineticTractorBeamID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 3500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 20000, Crystal: 15000, Deuterium: 7500}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1080,6 +1219,8 @@ func newEnhancedSensorTechnology() *enhancedSensorTechnology {
 	b.Name = "EnhancedSensorTechnology"
 	b.ID = EnhancedSensorTechnologyID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 4500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 25000, Crystal: 20000, Deuterium: 10000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1094,6 +1235,8 @@ func newNeuromodalCompressor() *neuromodalCompressor {
 	b.Name = "NeuromodalCompressor"
 	b.ID = NeuromodalCompressorID
 	b.IncreaseFactor = 1.30
+	b.durationBase = 5000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 50000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1108,6 +1251,8 @@ func newNeuroInterface() *neuroInterface {
 	b.Name = "NeuroInterface"
 	b.ID = NeuroInterfaceID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 8000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 70000, Crystal: 40000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1122,6 +1267,8 @@ func newInterplanetaryAnalysisNetwork() *interplanetaryAnalysisNetwork {
 	b.Name = "InterplanetaryAnalysisNetwork"
 	b.ID = InterplanetaryAnalysisNetworkID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 6000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 80000, Crystal: 50000, Deuterium: 20000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1136,6 +1283,8 @@ func newOverclockingHeavyFighter() *overclockingHeavyFighter {
 	b.Name = "OverclockingHeavyFighter"
 	b.ID = OverclockingHeavyFighterID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 6500
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1150,6 +1299,8 @@ func newTelekineticDrive() *telekineticDrive {
 	b.Name = "TelekineticDrive"
 	b.ID = TelekineticDriveID
 	b.IncreaseFactor = 1.20
+	b.durationBase = 7000
+	b.durationIncreaseFactor = 1.40
 	b.BaseCost = Resources{Metal: 85000, Crystal: 40000, Deuterium: 35000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1164,6 +1315,8 @@ func newSixthSense() *sixthSense {
 	b.Name = "SixthSense"
 	b.ID = SixthSenseID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 7500
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 120000, Crystal: 30000, Deuterium: 25000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1178,6 +1331,8 @@ func newPsychoharmoniser() *psychoharmoniser {
 	b.Name = "Psychoharmoniser"
 	b.ID = PsychoharmoniserID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.20
 	b.BaseCost = Resources{Metal: 100000, Crystal: 40000, Deuterium: 30000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1192,6 +1347,8 @@ func newEfficientSwarmIntelligence() *efficientSwarmIntelligence {
 	b.Name = "EfficientSwarmIntelligence"
 	b.ID = EfficientSwarmIntelligenceID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 8500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 200000, Crystal: 100000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1206,6 +1363,8 @@ func newOverclockingLargeCargo() *overclockingLargeCargo {
 	b.Name = "OverclockingLargeCargo"
 	b.ID = OverclockingLargeCargoID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 160000, Crystal: 120000, Deuterium: 50000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1220,6 +1379,8 @@ func newGravitationSensors() *gravitationSensors {
 	b.Name = "GravitationSensors"
 	b.ID = GravitationSensorsID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 9500
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 240000, Crystal: 120000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1234,6 +1395,8 @@ func newOverclockingBattleship() *overclockingBattleship {
 	b.Name = "OverclockingBattleship"
 	b.ID = OverclockingBattleshipID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 10000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 320000, Crystal: 240000, Deuterium: 100000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1248,6 +1411,8 @@ func newPsionicShieldMatrix() *psionicShieldMatrix {
 	b.Name = "PsionicShieldMatrix"
 	b.ID = PsionicShieldMatrixID
 	b.IncreaseFactor = 1.50
+	b.durationBase = 11000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 500000, Crystal: 300000, Deuterium: 200000}
 	b.Requirements = map[ID]int64{}
 	return b
@@ -1262,6 +1427,8 @@ func newKaeleshDiscovererEnhancement() *kaeleshDiscovererEnhancement {
 	b.Name = "KaeleshDiscovererEnhancement"
 	b.ID = KaeleshDiscovererEnhancementID
 	b.IncreaseFactor = 1.70
+	b.durationBase = 13000
+	b.durationIncreaseFactor = 1.30
 	b.BaseCost = Resources{Metal: 300000, Crystal: 180000, Deuterium: 120000}
 	b.Requirements = map[ID]int64{}
 	return b
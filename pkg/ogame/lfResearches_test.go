@@ -0,0 +1,25 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntergalacticEnvoysConstructionTime(t *testing.T) {
+	ie := newIntergalacticEnvoys()
+	assert.Equal(t, 20*time.Minute, ie.ConstructionTime(1, 1, Facilities{ResearchLab: 12}, true, true))
+	assert.Equal(t, 30958*time.Second, ie.ConstructionTime(10, 2, Facilities{}, false, false))
+	bonuses := LfBonuses{LfResearchTimeReduction: 0.25}
+	assert.Equal(t, 15*time.Minute, ie.LfResearchConstructionTime(1, 1, bonuses))
+}
+
+func TestLfObjsInterfaces(t *testing.T) {
+	_, ok := Objs.ByID(ResidentialSectorID).(LfBuilding)
+	assert.True(t, ok)
+	_, ok = Objs.ByID(SupercomputerID).(LfResearch)
+	assert.True(t, ok)
+	_, ok = Objs.ByID(MetalMineID).(LfBuilding)
+	assert.False(t, ok)
+}
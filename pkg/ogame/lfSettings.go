@@ -0,0 +1,71 @@
+package ogame
+
+// LfSpecies lifeform species as displayed by the lifeform settings page
+type LfSpecies struct {
+	Type                LifeformType
+	Name                string
+	Discovered          bool // Only discovered species can be selected
+	Selected            bool // Species of the planet
+	Level               int64
+	Experience          int64
+	NextLevelExperience int64
+}
+
+// LfResearchSlot lifeform research slot of a planet
+type LfResearchSlot struct {
+	Slot   int64 // 1 to 18
+	TechID ID    // Technology researched in the slot, 0 when not configured
+	Locked bool  // Slot not unlocked yet
+}
+
+// LfSettings lifeform settings page (page=ingame&component=lfsettings)
+type LfSettings struct {
+	Current       LifeformType // Species of the planet, NoneLfType when not yet selected
+	Species       []LfSpecies
+	ChangeCost    Resources // Price to switch the species of the planet, nothing to pay for the first selection
+	ResearchSlots []LfResearchSlot
+	Token         string
+}
+
+// SpeciesByType returns the species of the given lifeform type
+func (s LfSettings) SpeciesByType(lfType LifeformType) (LfSpecies, bool) {
+	for _, species := range s.Species {
+		if species.Type == lfType {
+			return species, true
+		}
+	}
+	return LfSpecies{}, false
+}
+
+// ResearchSlot returns the research slot with the given number
+func (s LfSettings) ResearchSlot(slot int64) (LfResearchSlot, bool) {
+	for _, researchSlot := range s.ResearchSlots {
+		if researchSlot.Slot == slot {
+			return researchSlot, true
+		}
+	}
+	return LfResearchSlot{}, false
+}
+
+// LfResearchSlotTechs returns the technologies that can be researched in a slot, one per species.
+// eg: slot 1 offers 11201 (humans), 12201 (rocktal), 13201 (mechas) and 14201 (kaelesh)
+func LfResearchSlotTechs(slot int64) []ID {
+	if slot < 1 || slot > 18 {
+		return nil
+	}
+	out := make([]ID, 0, 4)
+	for _, lfType := range []LifeformType{Humans, Rocktal, Mechas, Kaelesh} {
+		out = append(out, ID(10000+int64(lfType)*1000+200+slot))
+	}
+	return out
+}
+
+// IsLfResearchSlotTech returns true if the technology can be researched in the slot
+func IsLfResearchSlotTech(slot int64, techID ID) bool {
+	for _, id := range LfResearchSlotTechs(slot) {
+		if id == techID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLfResearchSlotTechs(t *testing.T) {
+	assert.Equal(t, []ID{IntergalacticEnvoysID, VolcanicBatteriesID, CatalyserTechnologyID, HeatRecoveryID}, LfResearchSlotTechs(1))
+	assert.Nil(t, LfResearchSlotTechs(0))
+	assert.Nil(t, LfResearchSlotTechs(19))
+	assert.True(t, IsLfResearchSlotTech(2, AcousticScanningID))
+	assert.False(t, IsLfResearchSlotTech(3, AcousticScanningID))
+}
+
+func TestLfSettings_SpeciesByType(t *testing.T) {
+	settings := LfSettings{
+		Species:       []LfSpecies{{Type: Humans, Level: 5}, {Type: Mechas}},
+		ResearchSlots: []LfResearchSlot{{Slot: 1, TechID: IntergalacticEnvoysID}},
+	}
+	species, ok := settings.SpeciesByType(Humans)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), species.Level)
+	_, ok = settings.SpeciesByType(Kaelesh)
+	assert.False(t, ok)
+	slot, ok := settings.ResearchSlot(1)
+	assert.True(t, ok)
+	assert.Equal(t, IntergalacticEnvoysID, slot.TechID)
+	_, ok = settings.ResearchSlot(2)
+	assert.False(t, ok)
+}
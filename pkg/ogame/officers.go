@@ -0,0 +1,140 @@
+package ogame
+
+import "time"
+
+// OfficerType type of officer, as used by the premium page
+type OfficerType int64
+
+// Officer types
+const (
+	CommanderOfficer  OfficerType = 2
+	AdmiralOfficer    OfficerType = 3
+	EngineerOfficer   OfficerType = 4
+	GeologistOfficer  OfficerType = 5
+	TechnocratOfficer OfficerType = 6
+
+	// CommandingStaffOfficer recruits all the officers at once, the officers bar links it as openDetail=12
+	CommandingStaffOfficer OfficerType = 12
+)
+
+// OfficerTypes all officers that can be recruited, they form the commanding staff
+var OfficerTypes = []OfficerType{CommanderOfficer, AdmiralOfficer, EngineerOfficer, GeologistOfficer, TechnocratOfficer}
+
+// String ...
+func (o OfficerType) String() string {
+	switch o {
+	case CommanderOfficer:
+		return "Commander"
+	case AdmiralOfficer:
+		return "Admiral"
+	case EngineerOfficer:
+		return "Engineer"
+	case GeologistOfficer:
+		return "Geologist"
+	case TechnocratOfficer:
+		return "Technocrat"
+	case CommandingStaffOfficer:
+		return "CommandingStaff"
+	}
+	return "Unknown"
+}
+
+// Price returns the dark matter price to recruit the officer for the given number of days (7 or 90).
+// The commanding staff price is not in any sample, the price of the five officers is returned as an upper bound.
+func (o OfficerType) Price(days int64) int64 {
+	if o == CommandingStaffOfficer {
+		var price int64
+		for _, officer := range OfficerTypes {
+			price += officer.Price(days)
+		}
+		return price
+	}
+	weekPrice := int64(12500)
+	if o == CommanderOfficer {
+		weekPrice = 10000
+	}
+	if days == 90 {
+		return weekPrice * 10
+	}
+	return weekPrice
+}
+
+// OfficerStatus status of an officer
+type OfficerStatus struct {
+	Type      OfficerType
+	Active    bool
+	Remaining time.Duration // Time left before the officer leaves, as displayed by the game (rounded to the unit shown)
+}
+
+// ExpiresAt returns the time at which the officer leaves, given the time the status was extracted
+func (o OfficerStatus) ExpiresAt(extractedAt time.Time) time.Time {
+	return extractedAt.Add(o.Remaining)
+}
+
+// Officers status of all the officers
+type Officers struct {
+	Commander  OfficerStatus
+	Admiral    OfficerStatus
+	Engineer   OfficerStatus
+	Geologist  OfficerStatus
+	Technocrat OfficerStatus
+
+	CommandingStaffBonus bool // The game grants the commanding staff bonus, the officers bar is marked "all"
+}
+
+// ByType returns the status of the officer of the given type
+func (o Officers) ByType(typ OfficerType) OfficerStatus {
+	switch typ {
+	case CommanderOfficer:
+		return o.Commander
+	case AdmiralOfficer:
+		return o.Admiral
+	case EngineerOfficer:
+		return o.Engineer
+	case GeologistOfficer:
+		return o.Geologist
+	case TechnocratOfficer:
+		return o.Technocrat
+	}
+	return OfficerStatus{Type: typ}
+}
+
+// CommandingStaff returns the status of the commanding staff bundle.
+// The staff is active when all the officers are, and lasts as long as the first officer to leave.
+func (o Officers) CommandingStaff() OfficerStatus {
+	staff := OfficerStatus{Active: true}
+	for i, typ := range OfficerTypes {
+		officer := o.ByType(typ)
+		if !officer.Active {
+			return OfficerStatus{}
+		}
+		if i == 0 || officer.Remaining < staff.Remaining {
+			staff.Remaining = officer.Remaining
+		}
+	}
+	return staff
+}
+
+// Elapse returns the officers status after d elapsed, officers that ran out are no longer active
+func (o Officers) Elapse(d time.Duration) Officers {
+	elapse := func(s OfficerStatus) OfficerStatus {
+		if !s.Active {
+			return s
+		}
+		s.Remaining -= d
+		if s.Remaining <= 0 {
+			s.Active = false
+			s.Remaining = 0
+		}
+		return s
+	}
+	officers := Officers{
+		Commander:  elapse(o.Commander),
+		Admiral:    elapse(o.Admiral),
+		Engineer:   elapse(o.Engineer),
+		Geologist:  elapse(o.Geologist),
+		Technocrat: elapse(o.Technocrat),
+	}
+	officers.CommandingStaffBonus = o.CommandingStaffBonus && officers.CommandingStaff().Active
+	return officers
+}
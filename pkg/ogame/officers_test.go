@@ -0,0 +1,60 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfficers_CommandingStaff(t *testing.T) {
+	officers := Officers{
+		Commander:  OfficerStatus{Type: CommanderOfficer, Active: true, Remaining: 5 * 24 * time.Hour},
+		Admiral:    OfficerStatus{Type: AdmiralOfficer, Active: true, Remaining: 3 * 24 * time.Hour},
+		Engineer:   OfficerStatus{Type: EngineerOfficer, Active: true, Remaining: 4 * 24 * time.Hour},
+		Geologist:  OfficerStatus{Type: GeologistOfficer, Active: true, Remaining: 6 * 24 * time.Hour},
+		Technocrat: OfficerStatus{Type: TechnocratOfficer, Active: true, Remaining: 7 * 24 * time.Hour},
+	}
+	staff := officers.CommandingStaff()
+	assert.True(t, staff.Active)
+	assert.Equal(t, 3*24*time.Hour, staff.Remaining)
+
+	officers.Engineer.Active = false
+	assert.False(t, officers.CommandingStaff().Active)
+}
+
+func TestOfficers_Elapse(t *testing.T) {
+	officers := Officers{
+		Commander: OfficerStatus{Type: CommanderOfficer, Active: true, Remaining: 5 * time.Hour},
+		Geologist: OfficerStatus{Type: GeologistOfficer, Active: true, Remaining: time.Hour},
+	}
+	officers = officers.Elapse(2 * time.Hour)
+	assert.Equal(t, OfficerStatus{Type: CommanderOfficer, Active: true, Remaining: 3 * time.Hour}, officers.Commander)
+	assert.Equal(t, OfficerStatus{Type: GeologistOfficer}, officers.Geologist)
+	assert.Equal(t, GeologistOfficer, officers.ByType(GeologistOfficer).Type)
+}
+
+func TestOfficers_Elapse_CommandingStaffBonus(t *testing.T) {
+	officers := Officers{
+		Commander:            OfficerStatus{Type: CommanderOfficer, Active: true, Remaining: 5 * time.Hour},
+		Admiral:              OfficerStatus{Type: AdmiralOfficer, Active: true, Remaining: time.Hour},
+		Engineer:             OfficerStatus{Type: EngineerOfficer, Active: true, Remaining: 5 * time.Hour},
+		Geologist:            OfficerStatus{Type: GeologistOfficer, Active: true, Remaining: 5 * time.Hour},
+		Technocrat:           OfficerStatus{Type: TechnocratOfficer, Active: true, Remaining: 5 * time.Hour},
+		CommandingStaffBonus: true,
+	}
+	assert.True(t, officers.Elapse(30*time.Minute).CommandingStaffBonus)
+	assert.False(t, officers.Elapse(2*time.Hour).CommandingStaffBonus)
+
+	officers.CommandingStaffBonus = false
+	assert.False(t, officers.Elapse(30*time.Minute).CommandingStaffBonus)
+}
+
+func TestOfficerType_Price(t *testing.T) {
+	assert.Equal(t, int64(10000), CommanderOfficer.Price(7))
+	assert.Equal(t, int64(100000), CommanderOfficer.Price(90))
+	assert.Equal(t, int64(12500), GeologistOfficer.Price(7))
+	assert.Equal(t, int64(125000), TechnocratOfficer.Price(90))
+	assert.Equal(t, int64(60000), CommandingStaffOfficer.Price(7))
+	assert.Equal(t, "CommandingStaff", CommandingStaffOfficer.String())
+}
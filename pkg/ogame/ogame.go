@@ -84,6 +84,19 @@ func (s Speed) String() string {
 	}
 }
 
+// Speeds returns the fleet speeds available for the character class, from the slowest to the fastest.
+// Only the general class can use the 5% steps.
+func Speeds(characterClass CharacterClass) []Speed {
+	if characterClass.IsGeneral() {
+		return []Speed{FivePercent, TenPercent, FifteenPercent, TwentyPercent, TwentyFivePercent, ThirtyPercent,
+			ThirtyFivePercent, FourtyPercent, FourtyFivePercent, FiftyPercent, FiftyFivePercent, SixtyPercent,
+			SixtyFivePercent, SeventyPercent, SeventyFivePercent, EightyPercent, EightyFivePercent, NinetyPercent,
+			NinetyFivePercent, HundredPercent}
+	}
+	return []Speed{TenPercent, TwentyPercent, ThirtyPercent, FourtyPercent, FiftyPercent, SixtyPercent,
+		SeventyPercent, EightyPercent, NinetyPercent, HundredPercent}
+}
+
 type ResourcesResp struct {
 	Metal struct {
 		Resources struct {
@@ -196,12 +209,48 @@ type EspionageReportSummary struct {
 	LootPercentage float64
 }
 
+// EspionageActionMessage message received when a foreign fleet spied on one of our celestials
+type EspionageActionMessage struct {
+	ID                     int64
+	Origin                 Coordinate
+	OriginName             string
+	PlayerName             string
+	PlayerID               int64
+	Target                 Coordinate
+	CounterEspionageChance int64 // percentage
+	CreatedAt              time.Time
+}
+
 // ExpeditionMessage ...
 type ExpeditionMessage struct {
 	ID         int64
 	Coordinate Coordinate
 	Content    string
 	CreatedAt  time.Time
+	Outcome    ExpeditionOutcome
+}
+
+// HarvestReport result of a debris field harvested by recyclers or pathfinders
+type HarvestReport struct {
+	ID         int64
+	Coordinate Coordinate // Harvested debris field
+	Ships      ShipsInfos
+	Capacity   int64     // Total cargo capacity of the harvesting fleet
+	Debris     Resources // Resources floating in the debris field when the fleet arrived
+	Harvested  Resources
+	CreatedAt  time.Time
+}
+
+// Message header of a message from any tab of the inbox
+type Message struct {
+	ID         int64
+	TabID      MessagesTabID
+	Title      string
+	Sender     string
+	Content    string
+	CreatedAt  time.Time
+	IsRead     bool
+	IsFavorite bool
 }
 
 // MarketplaceMessage ...
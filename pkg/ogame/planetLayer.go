@@ -0,0 +1,82 @@
+package ogame
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlanetLayer information of the planet layer overlay (page=planetlayer) used to rename and abandon a celestial,
+// with the relocation information of the overview of the celestial
+type PlanetLayer struct {
+	Name         string
+	AbandonToken string
+	Token        string
+	Relocation   PlanetRelocation
+}
+
+// PlanetRelocation relocation (planet move) information of a planet.
+// Possible and Cost are parsed from captured overviews, the other fields from markup that is not verified.
+type PlanetRelocation struct {
+	Possible    bool          // Relocation can be reserved from this celestial, never true for a moon
+	Cost        int64         // Dark matter cost of a relocation
+	Cooldown    time.Duration // Time before a new relocation can be reserved
+	InProgress  bool          // A relocation is reserved and waiting for its countdown
+	Destination Coordinate    // Destination of the reserved relocation
+	Countdown   time.Duration // Time before the reserved relocation happens
+}
+
+// RelocationStatus status of the relocation of a planet with everything that would cancel it.
+// Fleets still in flight and queues still running when the countdown ends cancel the relocation.
+type RelocationStatus struct {
+	PlanetRelocation
+	CelestialID         CelestialID
+	FleetsInFlight      []FleetID // Fleets sent from the planet or heading to it
+	BuildingID          ID
+	BuildingCountdown   int64
+	ResearchID          ID
+	ResearchCountdown   int64
+	ShipyardCountdown   int64
+	LfBuildingID        ID
+	LfBuildingCountdown int64
+	LfResearchID        ID
+	LfResearchCountdown int64
+}
+
+// QueuesRunning returns true if a building, research, shipyard or lifeform queue is running
+func (s RelocationStatus) QueuesRunning() bool {
+	return s.BuildingID != 0 || s.ResearchID != 0 || s.ShipyardCountdown > 0 || s.LfBuildingID != 0 || s.LfResearchID != 0
+}
+
+// Blockers returns a description of the conditions that prevent the relocation
+func (s RelocationStatus) Blockers() (out []string) {
+	if !s.Possible {
+		out = append(out, "relocation not possible from this celestial")
+	}
+	if s.Cooldown > 0 {
+		out = append(out, fmt.Sprintf("relocation cooldown (%s)", s.Cooldown))
+	}
+	if len(s.FleetsInFlight) > 0 {
+		out = append(out, fmt.Sprintf("%d fleets in flight", len(s.FleetsInFlight)))
+	}
+	if s.BuildingID != 0 {
+		out = append(out, fmt.Sprintf("building %s in progress", s.BuildingID))
+	}
+	if s.ResearchID != 0 {
+		out = append(out, fmt.Sprintf("research %s in progress", s.ResearchID))
+	}
+	if s.ShipyardCountdown > 0 {
+		out = append(out, "shipyard queue running")
+	}
+	if s.LfBuildingID != 0 {
+		out = append(out, fmt.Sprintf("lifeform building %s in progress", s.LfBuildingID))
+	}
+	if s.LfResearchID != 0 {
+		out = append(out, fmt.Sprintf("lifeform research %s in progress", s.LfResearchID))
+	}
+	return
+}
+
+// CanRelocate returns true if nothing prevents the relocation of the planet
+func (s RelocationStatus) CanRelocate() bool {
+	return len(s.Blockers()) == 0
+}
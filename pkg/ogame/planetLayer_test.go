@@ -0,0 +1,24 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelocationStatus_Blockers(t *testing.T) {
+	s := RelocationStatus{PlanetRelocation: PlanetRelocation{Possible: true}}
+	assert.True(t, s.CanRelocate())
+	assert.False(t, s.QueuesRunning())
+
+	s.FleetsInFlight = []FleetID{1, 2}
+	s.ShipyardCountdown = 30
+	s.Cooldown = time.Hour
+	assert.True(t, s.QueuesRunning())
+	assert.False(t, s.CanRelocate())
+	assert.Equal(t, []string{"relocation cooldown (1h0m0s)", "2 fleets in flight", "shipyard queue running"}, s.Blockers())
+
+	s = RelocationStatus{BuildingID: MetalMineID}
+	assert.Equal(t, []string{"relocation not possible from this celestial", "building MetalMine in progress"}, s.Blockers())
+}
@@ -0,0 +1,227 @@
+package ogame
+
+import (
+	"math"
+)
+
+// ProductionParams everything the hourly production of a planet depends on
+type ProductionParams struct {
+	ResourcesBuildings ResourcesBuildings
+	ResourceSettings   ResourceSettings
+	Researches         Researches
+	Temperature        Temperature
+	Position           int64 // Position of the planet in its system, no position bonus when 0
+	UniverseSpeed      int64
+	CharacterClass     CharacterClass
+	HasGeologist       bool
+	HasEngineer        bool
+	HasCommandingStaff bool // The game grants the commanding staff bonus (see Officers.CommandingStaffBonus)
+	Crawlers           int64
+	ItemsBoost         Multiplier // Active resource boosters (see ItemsProductionBoost)
+	LfBonuses          LfBonuses
+	GlobalRatio        float64 // Ratio applied to the mines, computed from the energy balance when 0
+}
+
+// ProductionBreakdown hourly production of a planet per source, as displayed by the resource settings page.
+// Energy of the mines and crawlers rows is their (negative) consumption.
+type ProductionBreakdown struct {
+	BasicIncome          Resources
+	MetalMine            Resources
+	CrystalMine          Resources
+	DeuteriumSynthesizer Resources
+	SolarPlant           Resources
+	FusionReactor        Resources
+	SolarSatellite       Resources
+	Crawler              Resources
+	PlasmaTechnology     Resources
+	Position             Resources
+	Items                Resources
+	Geologist            Resources
+	Engineer             Resources
+	CommandingStaff      Resources
+	CharacterClass       Resources
+	Lifeform             Resources
+	ProductionRatio      float64 // Ratio applied to the mines
+}
+
+// Total returns the hourly production of the planet
+func (p ProductionBreakdown) Total() Resources {
+	var total Resources
+	var energy int64
+	for _, source := range []Resources{p.BasicIncome, p.MetalMine, p.CrystalMine, p.DeuteriumSynthesizer, p.SolarPlant,
+		p.FusionReactor, p.SolarSatellite, p.Crawler, p.PlasmaTechnology, p.Position, p.Items, p.Geologist, p.Engineer,
+		p.CommandingStaff, p.CharacterClass, p.Lifeform} {
+		total = total.Add(source) // Add ignores the energy
+		energy += source.Energy
+	}
+	total.Energy = energy
+	return total
+}
+
+const (
+	geologistProductionBonus       = 0.10
+	engineerEnergyBonus            = 0.10
+	commandingStaffProductionBonus = 0.02
+	commandingStaffEnergyBonus     = 0.02
+	collectorProductionBonus       = 0.25
+	collectorEnergyBonus           = 0.10
+	crawlerProductionBonus         = 0.0002 // Per crawler
+	crawlerMaxProductionBonus      = 0.5
+	crawlerEnergyConsumption       = 50
+	crawlersPerMineLevel           = 8
+)
+
+// PositionProductionBonus returns the mines production bonus granted by the position of a planet
+func PositionProductionBonus(position int64) Multiplier {
+	switch position {
+	case 1:
+		return Multiplier{Crystal: 0.4}
+	case 2:
+		return Multiplier{Crystal: 0.3}
+	case 3:
+		return Multiplier{Crystal: 0.2}
+	case 6, 10:
+		return Multiplier{Metal: 0.17}
+	case 7, 9:
+		return Multiplier{Metal: 0.23}
+	case 8:
+		return Multiplier{Metal: 0.35}
+	}
+	return Multiplier{}
+}
+
+func (p ProductionParams) setting(pct int64) float64 {
+	return float64(pct) / 100
+}
+
+// usableCrawlers returns the number of crawlers the mines levels allow
+func (p ProductionParams) usableCrawlers() int64 {
+	b := p.ResourcesBuildings
+	maxCrawlers := crawlersPerMineLevel * (b.MetalMine + b.CrystalMine + b.DeuteriumSynthesizer)
+	return int64(math.Min(float64(p.Crawlers), float64(maxCrawlers)))
+}
+
+// crawlersBonus returns the mines production bonus of the crawlers
+func (p ProductionParams) crawlersBonus() float64 {
+	perCrawler := crawlerProductionBonus
+	if p.CharacterClass.IsCollector() {
+		perCrawler *= 1.5
+	}
+	bonus := math.Min(float64(p.usableCrawlers())*perCrawler, crawlerMaxProductionBonus)
+	return bonus * p.setting(p.ResourceSettings.Crawler)
+}
+
+// EnergyNeeded returns the energy consumed by the mines and the crawlers
+func (p ProductionParams) EnergyNeeded() int64 {
+	b, s := p.ResourcesBuildings, p.ResourceSettings
+	needed := int64(float64(MetalMine.EnergyConsumption(b.MetalMine)) * p.setting(s.MetalMine))
+	needed += int64(float64(CrystalMine.EnergyConsumption(b.CrystalMine)) * p.setting(s.CrystalMine))
+	needed += int64(float64(DeuteriumSynthesizer.EnergyConsumption(b.DeuteriumSynthesizer)) * p.setting(s.DeuteriumSynthesizer))
+	needed += int64(float64(p.usableCrawlers()*crawlerEnergyConsumption) * p.setting(s.Crawler))
+	return needed
+}
+
+// baseEnergyProduced energy produced by the solar plant, fusion reactor and solar satellites, without any bonus
+func (p ProductionParams) baseEnergyProduced() (solarPlant, fusionReactor, solarSatellite int64) {
+	b, s := p.ResourcesBuildings, p.ResourceSettings
+	solarPlant = int64(float64(SolarPlant.Production(b.SolarPlant)) * p.setting(s.SolarPlant))
+	fusionReactor = int64(float64(FusionReactor.Production(p.Researches.EnergyTechnology, b.FusionReactor)) * p.setting(s.FusionReactor))
+	solarSatellite = int64(float64(SolarSatellite.Production(p.Temperature, b.SolarSatellite, false)) * p.setting(s.SolarSatellite))
+	return
+}
+
+// energyBonus returns the energy production bonus of the officers, class and lifeform
+func (p ProductionParams) energyBonus() float64 {
+	var bonus float64
+	if p.HasEngineer {
+		bonus += engineerEnergyBonus
+	}
+	if p.HasCommandingStaff {
+		bonus += commandingStaffEnergyBonus
+	}
+	if p.CharacterClass.IsCollector() {
+		bonus += collectorEnergyBonus * (1 + p.LfBonuses.Class.Collector)
+	}
+	return bonus + p.LfBonuses.EnergyProduction
+}
+
+// EnergyProduced returns the energy produced, with the bonuses
+func (p ProductionParams) EnergyProduced() int64 {
+	solarPlant, fusionReactor, solarSatellite := p.baseEnergyProduced()
+	base := solarPlant + fusionReactor + solarSatellite
+	return base + int64(float64(base)*p.energyBonus())
+}
+
+// ProductionRatio returns the ratio applied to the mines when there is not enough energy
+func (p ProductionParams) ProductionRatio() float64 {
+	if p.GlobalRatio > 0 {
+		return p.GlobalRatio
+	}
+	produced := p.EnergyProduced()
+	needed := p.EnergyNeeded()
+	if needed > produced {
+		return float64(produced) / float64(needed)
+	}
+	return 1
+}
+
+// GetProductionBreakdown computes the hourly production of a planet per source
+func GetProductionBreakdown(p ProductionParams) ProductionBreakdown {
+	b, s := p.ResourcesBuildings, p.ResourceSettings
+	speed := float64(p.UniverseSpeed)
+	ratio := p.ProductionRatio()
+	out := ProductionBreakdown{ProductionRatio: ratio}
+
+	// Mines production without any bonus, every bonus is a ratio of it
+	mines := struct{ metal, crystal, deuterium float64 }{
+		metal:     30 * speed * float64(b.MetalMine) * math.Pow(1.1, float64(b.MetalMine)) * p.setting(s.MetalMine) * ratio,
+		crystal:   20 * speed * float64(b.CrystalMine) * math.Pow(1.1, float64(b.CrystalMine)) * p.setting(s.CrystalMine) * ratio,
+		deuterium: 10 * speed * float64(b.DeuteriumSynthesizer) * math.Pow(1.1, float64(b.DeuteriumSynthesizer)) * (-0.004*float64(p.Temperature.Mean()) + 1.36) * p.setting(s.DeuteriumSynthesizer) * ratio,
+	}
+	bonus := func(m Multiplier) Resources {
+		return Resources{
+			Metal:     int64(mines.metal * m.Metal),
+			Crystal:   int64(mines.crystal * m.Crystal),
+			Deuterium: int64(mines.deuterium * m.Deuterium),
+		}
+	}
+	same := func(v float64) Multiplier {
+		return Multiplier{Metal: v, Crystal: v, Deuterium: v}
+	}
+
+	out.BasicIncome = Resources{Metal: int64(30 * speed), Crystal: int64(15 * speed)}
+	out.MetalMine = Resources{Metal: int64(mines.metal), Energy: -int64(float64(MetalMine.EnergyConsumption(b.MetalMine)) * p.setting(s.MetalMine))}
+	out.CrystalMine = Resources{Crystal: int64(mines.crystal), Energy: -int64(float64(CrystalMine.EnergyConsumption(b.CrystalMine)) * p.setting(s.CrystalMine))}
+	out.DeuteriumSynthesizer = Resources{Deuterium: int64(mines.deuterium), Energy: -int64(float64(DeuteriumSynthesizer.EnergyConsumption(b.DeuteriumSynthesizer)) * p.setting(s.DeuteriumSynthesizer))}
+
+	solarPlant, fusionReactor, solarSatellite := p.baseEnergyProduced()
+	out.SolarPlant = Resources{Energy: solarPlant}
+	out.FusionReactor = Resources{Energy: fusionReactor, Deuterium: -FusionReactor.GetFuelConsumption(p.UniverseSpeed, p.setting(s.FusionReactor), b.FusionReactor)}
+	out.SolarSatellite = Resources{Energy: solarSatellite}
+	baseEnergy := float64(solarPlant + fusionReactor + solarSatellite)
+
+	out.Crawler = bonus(same(p.crawlersBonus()))
+	out.Crawler.Energy = -int64(float64(p.usableCrawlers()*crawlerEnergyConsumption) * p.setting(s.Crawler))
+	plasma := float64(p.Researches.PlasmaTechnology)
+	out.PlasmaTechnology = bonus(Multiplier{Metal: plasma * 0.01, Crystal: plasma * 0.0066, Deuterium: plasma * 0.0033})
+	out.Position = bonus(PositionProductionBonus(p.Position))
+	out.Items = bonus(p.ItemsBoost)
+	if p.HasGeologist {
+		out.Geologist = bonus(same(geologistProductionBonus))
+	}
+	if p.HasEngineer {
+		out.Engineer = Resources{Energy: int64(baseEnergy * engineerEnergyBonus)}
+	}
+	if p.HasCommandingStaff {
+		out.CommandingStaff = bonus(same(commandingStaffProductionBonus))
+		out.CommandingStaff.Energy = int64(baseEnergy * commandingStaffEnergyBonus)
+	}
+	if p.CharacterClass.IsCollector() {
+		amplifier := 1 + p.LfBonuses.Class.Collector
+		out.CharacterClass = bonus(same(collectorProductionBonus * amplifier))
+		out.CharacterClass.Energy = int64(baseEnergy * collectorEnergyBonus * amplifier)
+	}
+	out.Lifeform = bonus(p.LfBonuses.Production)
+	out.Lifeform.Energy = int64(baseEnergy * p.LfBonuses.EnergyProduction)
+	return out
+}
@@ -0,0 +1,93 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductionParams_ProductionRatio(t *testing.T) {
+	params := ProductionParams{
+		Temperature:        Temperature{-23, 17},
+		ResourcesBuildings: ResourcesBuildings{MetalMine: 29, CrystalMine: 16, DeuteriumSynthesizer: 26, SolarPlant: 29, FusionReactor: 13, SolarSatellite: 51},
+		ResourceSettings:   ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100},
+		Researches:         Researches{EnergyTechnology: 12},
+	}
+	assert.Equal(t, 1.0, params.ProductionRatio())
+	params.ResourceSettings.FusionReactor = 0
+	params.ResourceSettings.SolarSatellite = 0
+	assert.InDelta(t, 9200.0/(4601+736+6198), params.ProductionRatio(), 0.000001)
+}
+
+func TestProductionParams_EnergyNeeded(t *testing.T) {
+	params := ProductionParams{
+		ResourcesBuildings: ResourcesBuildings{MetalMine: 29, CrystalMine: 16, DeuteriumSynthesizer: 26},
+		ResourceSettings:   ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100},
+	}
+	assert.Equal(t, int64(4601+736+6198), params.EnergyNeeded())
+	params.Crawlers = 10
+	params.ResourceSettings.Crawler = 100
+	assert.Equal(t, int64(4601+736+6198+500), params.EnergyNeeded())
+}
+
+func TestProductionParams_EnergyProduced(t *testing.T) {
+	params := ProductionParams{
+		Temperature:        Temperature{-23, 17},
+		ResourcesBuildings: ResourcesBuildings{SolarPlant: 29, FusionReactor: 13, SolarSatellite: 51},
+		ResourceSettings:   ResourceSettings{SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100},
+		Researches:         Researches{EnergyTechnology: 12},
+	}
+	assert.Equal(t, int64(9200+3002+1326), params.EnergyProduced())
+	params.HasEngineer = true
+	assert.Equal(t, int64(13528+1352), params.EnergyProduced())
+}
+
+func TestGetProductionBreakdown_Boost(t *testing.T) {
+	params := ProductionParams{
+		ResourcesBuildings: ResourcesBuildings{MetalMine: 10},
+		ResourceSettings:   ResourceSettings{MetalMine: 100},
+		UniverseSpeed:      1,
+		GlobalRatio:        1,
+	}
+	assert.Equal(t, int64(808), GetProductionBreakdown(params).Total().Metal)
+	params.ItemsBoost = Multiplier{Metal: 0.3}
+	assert.Equal(t, int64(808+233), GetProductionBreakdown(params).Total().Metal)
+}
+
+func TestGetProductionBreakdown(t *testing.T) {
+	params := ProductionParams{
+		ResourcesBuildings: ResourcesBuildings{MetalMine: 10, CrystalMine: 10, SolarPlant: 30},
+		ResourceSettings:   ResourceSettings{MetalMine: 100, CrystalMine: 100, SolarPlant: 100, Crawler: 100},
+		Researches:         Researches{PlasmaTechnology: 10},
+		Position:           8,
+		UniverseSpeed:      1,
+		CharacterClass:     Collector,
+		HasGeologist:       true,
+		Crawlers:           100,
+		LfBonuses:          LfBonuses{Production: Multiplier{Metal: 0.1}},
+	}
+	breakdown := GetProductionBreakdown(params)
+	assert.Equal(t, 1.0, breakdown.ProductionRatio)
+	assert.Equal(t, Resources{Metal: 30, Crystal: 15}, breakdown.BasicIncome)
+	assert.Equal(t, int64(778), breakdown.MetalMine.Metal)
+	assert.Equal(t, int64(518), breakdown.CrystalMine.Crystal)
+	assert.Equal(t, int64(77), breakdown.PlasmaTechnology.Metal)
+	assert.Equal(t, int64(272), breakdown.Position.Metal)
+	assert.Equal(t, int64(0), breakdown.Position.Crystal)
+	assert.Equal(t, int64(77), breakdown.Geologist.Metal)
+	assert.Equal(t, int64(194), breakdown.CharacterClass.Metal)
+	assert.Equal(t, int64(77), breakdown.Lifeform.Metal)
+	// 100 crawlers allowed by 20 mines levels (8 per level), 0.03% each for a collector
+	assert.Equal(t, int64(23), breakdown.Crawler.Metal)
+	assert.Equal(t, int64(-5000), breakdown.Crawler.Energy)
+	assert.Equal(t, int64(30+778+77+272+77+194+77+23), breakdown.Total().Metal)
+	energy := breakdown.SolarPlant.Energy + breakdown.CharacterClass.Energy + breakdown.MetalMine.Energy + breakdown.CrystalMine.Energy + breakdown.Crawler.Energy
+	assert.Equal(t, energy, breakdown.Total().Energy)
+	assert.True(t, energy > 0)
+}
+
+func TestPositionProductionBonus(t *testing.T) {
+	assert.Equal(t, Multiplier{Crystal: 0.4}, PositionProductionBonus(1))
+	assert.Equal(t, Multiplier{Metal: 0.35}, PositionProductionBonus(8))
+	assert.Equal(t, Multiplier{}, PositionProductionBonus(15))
+}
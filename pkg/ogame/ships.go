@@ -107,7 +107,7 @@ func (s ShipsInfos) FromQuantifiables(in []Quantifiable) (out ShipsInfos) {
 	return
 }
 
-// Cargo returns the total cargo of the ships
+// Cargo returns the total cargo of the ships, without the lifeform bonuses (see LfBonuses.FleetCargo)
 func (s ShipsInfos) Cargo(techs Researches, probeRaids, isCollector, isPioneers bool) (out int64) {
 	for _, ship := range Ships {
 		out += ship.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers) * s.ByID(ship.GetID())
@@ -0,0 +1,96 @@
+package ogame
+
+import "math"
+
+// TradeResource resource exchanged with the resource trader
+type TradeResource int64
+
+// Resources the resource trader can exchange
+const (
+	TradeMetal     TradeResource = 1
+	TradeCrystal   TradeResource = 2
+	TradeDeuterium TradeResource = 3
+)
+
+// String ...
+func (r TradeResource) String() string {
+	switch r {
+	case TradeMetal:
+		return "metal"
+	case TradeCrystal:
+		return "crystal"
+	case TradeDeuterium:
+		return "deuterium"
+	}
+	return "unknown"
+}
+
+// ResourceTrader resource trader overlay (Merchant -> Resources), with the exchange rates of the day
+type ResourceTrader struct {
+	Rates           Multiplier // Value of one unit of each resource
+	DarkMatterCost  int64      // Dark matter paid for every exchange
+	Token           string
+	PlanetResources PlanetResources
+}
+
+// rate returns the value of one unit of resource r
+func (t ResourceTrader) rate(r TradeResource) float64 {
+	switch r {
+	case TradeMetal:
+		return t.Rates.Metal
+	case TradeCrystal:
+		return t.Rates.Crystal
+	case TradeDeuterium:
+		return t.Rates.Deuterium
+	}
+	return 0
+}
+
+// Exchange returns the amount of resource "to" received for the resources given, at the rates of the trader.
+// The resource "to" is never traded against itself, so it is ignored in give.
+func (t ResourceTrader) Exchange(give Resources, to TradeResource) int64 {
+	toRate := t.rate(to)
+	if toRate <= 0 {
+		return 0
+	}
+	var value float64
+	if to != TradeMetal {
+		value += float64(give.Metal) * t.Rates.Metal
+	}
+	if to != TradeCrystal {
+		value += float64(give.Crystal) * t.Rates.Crystal
+	}
+	if to != TradeDeuterium {
+		value += float64(give.Deuterium) * t.Rates.Deuterium
+	}
+	return int64(math.Floor(value / toRate))
+}
+
+// ScrapMerchant scrap merchant overlay (Merchant -> Scrap Merchant)
+type ScrapMerchant struct {
+	Percentage    int64 // Part of the units price paid back by the merchant
+	MaxPercentage int64 // Percentage the bargaining cannot go over
+	BargainCost   int64 // Dark matter cost of the next bargain
+	Token         string
+	Units         map[ID]int64 // Ships and defenses available on the celestial
+}
+
+// CanBargain returns true if the percentage can still be raised
+func (s ScrapMerchant) CanBargain() bool {
+	return s.Percentage < s.MaxPercentage
+}
+
+// Offer returns the resources paid back by the merchant for the units, at the current percentage
+func (s ScrapMerchant) Offer(units map[ID]int64) Resources {
+	var price Resources
+	for id, nbr := range units {
+		if obj := Objs.ByID(id); obj != nil && nbr > 0 {
+			price = price.Add(obj.GetPrice(nbr))
+		}
+	}
+	return Resources{
+		Metal:     price.Metal * s.Percentage / 100,
+		Crystal:   price.Crystal * s.Percentage / 100,
+		Deuterium: price.Deuterium * s.Percentage / 100,
+	}
+}
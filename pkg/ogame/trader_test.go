@@ -0,0 +1,24 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTrader_Exchange(t *testing.T) {
+	trader := ResourceTrader{Rates: Multiplier{Metal: 1, Crystal: 1.5, Deuterium: 3}}
+	assert.Equal(t, int64(1000), trader.Exchange(Resources{Metal: 1500}, TradeCrystal))
+	assert.Equal(t, int64(1500), trader.Exchange(Resources{Metal: 1500, Crystal: 2000}, TradeDeuterium))
+	assert.Equal(t, int64(3000), trader.Exchange(Resources{Metal: 3000, Deuterium: 1000}, TradeMetal))
+	assert.Equal(t, int64(0), trader.Exchange(Resources{Metal: 3000}, TradeResource(0)))
+}
+
+func TestScrapMerchant_Offer(t *testing.T) {
+	merchant := ScrapMerchant{Percentage: 35, MaxPercentage: 75}
+	assert.True(t, merchant.CanBargain())
+	offer := merchant.Offer(map[ID]int64{SmallCargoID: 10, RocketLauncherID: 2})
+	assert.Equal(t, Resources{Metal: 8400, Crystal: 7000}, offer)
+	merchant.Percentage = 75
+	assert.False(t, merchant.CanBargain())
+}
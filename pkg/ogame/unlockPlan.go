@@ -0,0 +1,131 @@
+package ogame
+
+import (
+	"sort"
+	"time"
+)
+
+// UnlockLevels current levels of a celestial used to resolve an unlock plan
+type UnlockLevels struct {
+	ResourcesBuildings IResourcesBuildings
+	Facilities         IFacilities
+	Researches         IResearches
+	LfBuildings        LfBuildings
+	LfResearches       LfResearches
+}
+
+// UnlockStep one level to build or research
+type UnlockStep struct {
+	ID               ID
+	Level            int64
+	Price            Resources
+	ConstructionTime time.Duration
+}
+
+// UnlockPlan ordered list of upgrades needed to unlock an ogame object.
+// Every step only depends on the steps before it.
+type UnlockPlan struct {
+	ID         ID
+	Steps      []UnlockStep
+	TotalPrice Resources
+	TotalTime  time.Duration // Sum of the construction times, as if everything was built one after the other
+}
+
+// IsUnlocked returns true if nothing has to be built to unlock the object
+func (p UnlockPlan) IsUnlocked() bool {
+	return len(p.Steps) == 0
+}
+
+type unlockResolver struct {
+	levels        UnlockLevels
+	planned       map[ID]int64
+	universeSpeed int64
+	hasTechnocrat bool
+	isDiscoverer  bool
+	plan          UnlockPlan
+}
+
+func (r *unlockResolver) level(id ID) int64 {
+	if lvl, ok := r.planned[id]; ok {
+		return lvl
+	}
+	switch {
+	case id.IsResourceBuilding():
+		return r.levels.ResourcesBuildings.ByID(id)
+	case id.IsFacility():
+		return r.levels.Facilities.ByID(id)
+	case id.IsTech():
+		return r.levels.Researches.ByID(id)
+	case id.IsLfBuilding():
+		return r.levels.LfBuildings.ByID(id)
+	case id.IsLfTech():
+		return r.levels.LfResearches.ByID(id)
+	}
+	return 0
+}
+
+// accelerators returns the facilities levels, including the planned ones, to estimate the construction times
+func (r *unlockResolver) accelerators() Facilities {
+	return Facilities{
+		RoboticsFactory: r.level(RoboticsFactoryID),
+		NaniteFactory:   r.level(NaniteFactoryID),
+		Shipyard:        r.level(ShipyardID),
+		ResearchLab:     r.level(ResearchLabID),
+	}
+}
+
+func (r *unlockResolver) require(requirements map[ID]int64) {
+	ids := make([]ID, 0, len(requirements))
+	for id := range requirements {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		needed := requirements[id]
+		if r.level(id) >= needed {
+			continue
+		}
+		obj := Objs.ByID(id)
+		r.require(obj.GetRequirements())
+		for lvl := r.level(id) + 1; lvl <= needed; lvl++ {
+			step := UnlockStep{
+				ID:               id,
+				Level:            lvl,
+				Price:            obj.GetPrice(lvl),
+				ConstructionTime: obj.ConstructionTime(lvl, r.universeSpeed, r.accelerators(), r.hasTechnocrat, r.isDiscoverer),
+			}
+			r.planned[id] = lvl
+			r.plan.Steps = append(r.plan.Steps, step)
+			r.plan.TotalPrice = r.plan.TotalPrice.Add(step.Price)
+			r.plan.TotalTime += step.ConstructionTime
+		}
+	}
+}
+
+// ResolveUnlockPlan returns the full ordered list of upgrades needed to unlock an ogame object,
+// given the current levels of a celestial.
+func ResolveUnlockPlan(id ID, levels UnlockLevels, universeSpeed int64, hasTechnocrat, isDiscoverer bool) (UnlockPlan, error) {
+	obj := Objs.ByID(id)
+	if obj == nil {
+		return UnlockPlan{}, ErrInvalidOgameID
+	}
+	if levels.ResourcesBuildings == nil {
+		levels.ResourcesBuildings = ResourcesBuildings{}
+	}
+	if levels.Facilities == nil {
+		levels.Facilities = Facilities{}
+	}
+	if levels.Researches == nil {
+		levels.Researches = Researches{}
+	}
+	r := &unlockResolver{
+		levels:        levels,
+		planned:       make(map[ID]int64),
+		universeSpeed: universeSpeed,
+		hasTechnocrat: hasTechnocrat,
+		isDiscoverer:  isDiscoverer,
+		plan:          UnlockPlan{ID: id},
+	}
+	r.require(obj.GetRequirements())
+	return r.plan, nil
+}
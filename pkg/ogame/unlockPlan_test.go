@@ -0,0 +1,56 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveUnlockPlan(t *testing.T) {
+	// Espionage probe: shipyard 3, combustion drive 3, espionage technology 2 (which needs research lab 3)
+	plan, err := ResolveUnlockPlan(EspionageProbeID, UnlockLevels{Facilities: Facilities{RoboticsFactory: 2, ResearchLab: 1}}, 1, false, false)
+	assert.NoError(t, err)
+	steps := make([]UnlockStep, 0)
+	for _, step := range plan.Steps {
+		steps = append(steps, UnlockStep{ID: step.ID, Level: step.Level})
+	}
+	assert.Equal(t, []UnlockStep{
+		{ID: ShipyardID, Level: 1},
+		{ID: ShipyardID, Level: 2},
+		{ID: ShipyardID, Level: 3},
+		{ID: ResearchLabID, Level: 2},
+		{ID: ResearchLabID, Level: 3},
+		{ID: EspionageTechnologyID, Level: 1},
+		{ID: EspionageTechnologyID, Level: 2},
+		{ID: EnergyTechnologyID, Level: 1},
+		{ID: CombustionDriveID, Level: 1},
+		{ID: CombustionDriveID, Level: 2},
+		{ID: CombustionDriveID, Level: 3},
+	}, steps)
+	var total Resources
+	for _, step := range plan.Steps {
+		total = total.Add(step.Price)
+		assert.True(t, step.ConstructionTime > 0)
+	}
+	assert.Equal(t, total, plan.TotalPrice)
+	assert.Equal(t, ResearchLab.GetPrice(2), plan.Steps[3].Price)
+	assert.False(t, plan.IsUnlocked())
+}
+
+func TestResolveUnlockPlan_Unlocked(t *testing.T) {
+	plan, err := ResolveUnlockPlan(MetalMineID, UnlockLevels{}, 1, false, false)
+	assert.NoError(t, err)
+	assert.True(t, plan.IsUnlocked())
+	assert.Equal(t, Resources{}, plan.TotalPrice)
+
+	_, err = ResolveUnlockPlan(ID(999), UnlockLevels{}, 1, false, false)
+	assert.Equal(t, ErrInvalidOgameID, err)
+}
+
+func TestResolveUnlockPlan_Lifeform(t *testing.T) {
+	plan, err := ResolveUnlockPlan(ResearchCentreID, UnlockLevels{LfBuildings: LfBuildings{ResidentialSector: 12, BiosphereFarm: 11}}, 1, false, false)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Steps, 2)
+	assert.Equal(t, UnlockStep{ID: BiosphereFarmID, Level: 12, Price: BiosphereFarm.GetPrice(12), ConstructionTime: BiosphereFarm.ConstructionTime(12, 1, Facilities{}, false, false)}, plan.Steps[0])
+	assert.Equal(t, plan.Steps[0].ConstructionTime+plan.Steps[1].ConstructionTime, plan.TotalTime)
+}
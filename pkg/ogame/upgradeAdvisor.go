@@ -0,0 +1,224 @@
+package ogame
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultResourceRatio trade ratio used when a ratio has a zero component
+var DefaultResourceRatio = Multiplier{Metal: 3, Crystal: 2, Deuterium: 1}
+
+// UpgradeStep one level (or number of units) to build
+type UpgradeStep struct {
+	ID    ID
+	Level int64 // Level to build, number of units for ships
+	Price Resources
+}
+
+// UpgradeAdvice production upgrade with its amortization time
+type UpgradeAdvice struct {
+	UpgradeStep
+	EnergySteps      []UpgradeStep // Energy builds needed to keep the mines running at full speed
+	TotalPrice       Resources     // Price of the upgrade and of its energy steps
+	ProductionGain   Resources     // Hourly production gained
+	AmortizationTime time.Duration // Time for the production gain to pay back the total price
+}
+
+// maxEnergySteps maximum number of solar plant/fusion reactor levels added to cover the energy of an upgrade
+const maxEnergySteps = 10
+
+type upgradeAdvisor struct {
+	baseTotal Resources
+	ratio     Multiplier
+}
+
+// value returns the value of the resources in metal, using the trade ratio
+func (a upgradeAdvisor) value(r Resources) float64 {
+	return float64(r.Metal) +
+		float64(r.Crystal)*a.ratio.Metal/a.ratio.Crystal +
+		float64(r.Deuterium)*a.ratio.Metal/a.ratio.Deuterium
+}
+
+func (a upgradeAdvisor) stepsValue(steps []UpgradeStep) float64 {
+	var price Resources
+	for _, step := range steps {
+		price = price.Add(step.Price)
+	}
+	return a.value(price)
+}
+
+func enoughEnergy(p ProductionParams) bool {
+	return p.EnergyProduced() >= p.EnergyNeeded()
+}
+
+// coverEnergy returns the cheapest energy builds (solar plant, solar satellites or fusion reactor)
+// that bring the energy production back above the consumption
+func (a upgradeAdvisor) coverEnergy(p ProductionParams) (ProductionParams, []UpgradeStep) {
+	if enoughEnergy(p) {
+		return p, nil
+	}
+	type option struct {
+		params ProductionParams
+		steps  []UpgradeStep
+	}
+	options := make([]option, 0, 3)
+
+	byLevels := func(id ID, settingPtr func(*ProductionParams) *int64, levelPtr func(*ProductionParams) *int64) {
+		o := option{params: p}
+		if *settingPtr(&o.params) == 0 {
+			*settingPtr(&o.params) = 100
+		}
+		obj := Objs.ByID(id)
+		for i := 0; i < maxEnergySteps && !enoughEnergy(o.params); i++ {
+			lvl := levelPtr(&o.params)
+			*lvl++
+			o.steps = append(o.steps, UpgradeStep{ID: id, Level: *lvl, Price: obj.GetPrice(*lvl)})
+		}
+		if enoughEnergy(o.params) {
+			options = append(options, o)
+		}
+	}
+	byLevels(SolarPlantID,
+		func(p *ProductionParams) *int64 { return &p.ResourceSettings.SolarPlant },
+		func(p *ProductionParams) *int64 { return &p.ResourcesBuildings.SolarPlant })
+	if p.ResourcesBuildings.FusionReactor > 0 {
+		byLevels(FusionReactorID,
+			func(p *ProductionParams) *int64 { return &p.ResourceSettings.FusionReactor },
+			func(p *ProductionParams) *int64 { return &p.ResourcesBuildings.FusionReactor })
+	}
+
+	// Solar satellites, estimate the number needed then adjust
+	if perSatellite := SolarSatellite.Production(p.Temperature, 1, false); perSatellite > 0 {
+		o := option{params: p}
+		if o.params.ResourceSettings.SolarSatellite == 0 {
+			o.params.ResourceSettings.SolarSatellite = 100
+		}
+		deficit := o.params.EnergyNeeded() - o.params.EnergyProduced()
+		nbr := int64(math.Ceil(float64(deficit) / float64(perSatellite)))
+		o.params.ResourcesBuildings.SolarSatellite += nbr
+		for !enoughEnergy(o.params) {
+			o.params.ResourcesBuildings.SolarSatellite++
+			nbr++
+		}
+		o.steps = []UpgradeStep{{ID: SolarSatelliteID, Level: nbr, Price: SolarSatellite.GetPrice(nbr)}}
+		options = append(options, o)
+	}
+
+	if len(options) == 0 {
+		// Energy can't be covered, the mines will run below 100%
+		return p, nil
+	}
+	sort.SliceStable(options, func(i, j int) bool { return a.stepsValue(options[i].steps) < a.stepsValue(options[j].steps) })
+	return options[0].params, options[0].steps
+}
+
+// evaluate returns the advice for an upgrade, false if the upgrade does not increase the production
+func (a upgradeAdvisor) evaluate(step UpgradeStep, p ProductionParams) (UpgradeAdvice, bool) {
+	p, energySteps := a.coverEnergy(p)
+	advice := UpgradeAdvice{UpgradeStep: step, EnergySteps: energySteps, TotalPrice: step.Price}
+	for _, energyStep := range energySteps {
+		advice.TotalPrice = advice.TotalPrice.Add(energyStep.Price)
+	}
+	total := GetProductionBreakdown(p).Total()
+	advice.ProductionGain = Resources{
+		Metal:     total.Metal - a.baseTotal.Metal,
+		Crystal:   total.Crystal - a.baseTotal.Crystal,
+		Deuterium: total.Deuterium - a.baseTotal.Deuterium,
+	}
+	gain := a.value(advice.ProductionGain)
+	if gain <= 0 {
+		return advice, false
+	}
+	hours := a.value(advice.TotalPrice) / gain
+	advice.AmortizationTime = time.Duration(hours * float64(time.Hour))
+	return advice, true
+}
+
+// lfProductionBuildings lifeform buildings of a species that increase the mines production
+func lfProductionBuildings(lfType LifeformType) (out []ID) {
+	for id, bonus := range LfBonusesPerLevel {
+		if !id.IsLfBuilding() || LifeformType(int64(id)/1000-10) != lfType {
+			continue
+		}
+		if bonus.Production.Metal > 0 || bonus.Production.Crystal > 0 || bonus.Production.Deuterium > 0 {
+			out = append(out, id)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return
+}
+
+// AdviseUpgrades ranks the next production upgrades of a planet by amortization time.
+// Candidates are the mines, plasma and energy technologies, energy buildings, the lifeform production buildings
+// of the planet species (lfBuildings) and the crawlers.
+// The energy needed by an upgrade is covered with the cheapest energy builds, their price is part of the upgrade price.
+// ratio is the trade ratio used to compare resources (eg: 3:2:1), technologies gains only count for this planet.
+func AdviseUpgrades(p ProductionParams, lfBuildings LfBuildings, ratio Multiplier) []UpgradeAdvice {
+	if ratio.Metal <= 0 || ratio.Crystal <= 0 || ratio.Deuterium <= 0 {
+		ratio = DefaultResourceRatio
+	}
+	p.GlobalRatio = 0
+	a := upgradeAdvisor{baseTotal: GetProductionBreakdown(p).Total(), ratio: ratio}
+	out := make([]UpgradeAdvice, 0)
+	add := func(step UpgradeStep, upgraded ProductionParams) {
+		if advice, ok := a.evaluate(step, upgraded); ok {
+			out = append(out, advice)
+		}
+	}
+	next := func(id ID, lvl int64) UpgradeStep {
+		return UpgradeStep{ID: id, Level: lvl + 1, Price: Objs.ByID(id).GetPrice(lvl + 1)}
+	}
+
+	upgraded := p
+	upgraded.ResourcesBuildings.MetalMine++
+	add(next(MetalMineID, p.ResourcesBuildings.MetalMine), upgraded)
+
+	upgraded = p
+	upgraded.ResourcesBuildings.CrystalMine++
+	add(next(CrystalMineID, p.ResourcesBuildings.CrystalMine), upgraded)
+
+	upgraded = p
+	upgraded.ResourcesBuildings.DeuteriumSynthesizer++
+	add(next(DeuteriumSynthesizerID, p.ResourcesBuildings.DeuteriumSynthesizer), upgraded)
+
+	upgraded = p
+	upgraded.ResourcesBuildings.SolarPlant++
+	add(next(SolarPlantID, p.ResourcesBuildings.SolarPlant), upgraded)
+
+	if p.ResourcesBuildings.FusionReactor > 0 {
+		upgraded = p
+		upgraded.ResourcesBuildings.FusionReactor++
+		add(next(FusionReactorID, p.ResourcesBuildings.FusionReactor), upgraded)
+	}
+
+	upgraded = p
+	upgraded.Researches.PlasmaTechnology++
+	add(next(PlasmaTechnologyID, p.Researches.PlasmaTechnology), upgraded)
+
+	upgraded = p
+	upgraded.Researches.EnergyTechnology++
+	add(next(EnergyTechnologyID, p.Researches.EnergyTechnology), upgraded)
+
+	for _, id := range lfProductionBuildings(lfBuildings.LifeformType) {
+		upgraded = p
+		upgraded.LfBonuses.Production.Metal += LfBonusesPerLevel[id].Production.Metal
+		upgraded.LfBonuses.Production.Crystal += LfBonusesPerLevel[id].Production.Crystal
+		upgraded.LfBonuses.Production.Deuterium += LfBonusesPerLevel[id].Production.Deuterium
+		add(next(id, lfBuildings.ByID(id)), upgraded)
+	}
+
+	// Crawlers, fill the mines up to the number of crawlers they can use
+	maxCrawlers := crawlersPerMineLevel * (p.ResourcesBuildings.MetalMine + p.ResourcesBuildings.CrystalMine + p.ResourcesBuildings.DeuteriumSynthesizer)
+	if nbr := maxCrawlers - p.Crawlers; nbr > 0 {
+		upgraded = p
+		upgraded.Crawlers = maxCrawlers
+		if upgraded.ResourceSettings.Crawler == 0 {
+			upgraded.ResourceSettings.Crawler = 100
+		}
+		add(UpgradeStep{ID: CrawlerID, Level: nbr, Price: Crawler.GetPrice(nbr)}, upgraded)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AmortizationTime < out[j].AmortizationTime })
+	return out
+}
@@ -0,0 +1,60 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAdvisorTestParams() ProductionParams {
+	return ProductionParams{
+		ResourcesBuildings: ResourcesBuildings{MetalMine: 20, CrystalMine: 15, DeuteriumSynthesizer: 10, SolarPlant: 20},
+		ResourceSettings:   ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100, Crawler: 100},
+		Temperature:        Temperature{Min: 10, Max: 50},
+		UniverseSpeed:      1,
+	}
+}
+
+func TestAdviseUpgrades(t *testing.T) {
+	p := newAdvisorTestParams()
+	advices := AdviseUpgrades(p, LfBuildings{LifeformType: Humans}, Multiplier{Metal: 3, Crystal: 2, Deuterium: 1})
+	ids := make(map[ID]UpgradeAdvice)
+	for i, advice := range advices {
+		ids[advice.ID] = advice
+		assert.True(t, advice.AmortizationTime > 0)
+		assert.True(t, advice.ProductionGain.Total() > 0)
+		if i > 0 {
+			assert.True(t, advices[i-1].AmortizationTime <= advice.AmortizationTime)
+		}
+	}
+	for _, id := range []ID{MetalMineID, CrystalMineID, DeuteriumSynthesizerID, PlasmaTechnologyID, CrawlerID, HighEnergySmeltingID, FusionPoweredProductionID} {
+		assert.Contains(t, ids, id)
+	}
+	// Lifeform buildings of other species are not candidates
+	assert.NotContains(t, ids, MagmaForgeID)
+
+	metalMine := ids[MetalMineID]
+	assert.Equal(t, int64(21), metalMine.Level)
+	assert.Equal(t, MetalMine.GetPrice(21), metalMine.Price)
+	// Mines already use all the energy, the upgrade needs more
+	assert.NotEmpty(t, metalMine.EnergySteps)
+	upgraded := p
+	upgraded.ResourcesBuildings.MetalMine++
+	for _, step := range metalMine.EnergySteps {
+		switch step.ID {
+		case SolarPlantID:
+			upgraded.ResourcesBuildings.SolarPlant = step.Level
+		case SolarSatelliteID:
+			upgraded.ResourcesBuildings.SolarSatellite += step.Level
+		}
+	}
+	assert.True(t, upgraded.EnergyProduced() >= upgraded.EnergyNeeded())
+
+	crawlers := ids[CrawlerID]
+	assert.Equal(t, int64(8*45), crawlers.Level)
+}
+
+func TestAdviseUpgrades_DefaultRatio(t *testing.T) {
+	p := newAdvisorTestParams()
+	assert.Equal(t, AdviseUpgrades(p, LfBuildings{}, DefaultResourceRatio), AdviseUpgrades(p, LfBuildings{}, Multiplier{}))
+}
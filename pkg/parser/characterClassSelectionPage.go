@@ -0,0 +1,7 @@
+package parser
+
+import "github.com/alaingilbert/ogame/pkg/ogame"
+
+func (p CharacterClassSelectionPage) ExtractCharacterClassSelection() (ogame.CharacterClassSelection, error) {
+	return p.e.ExtractCharacterClassSelectionFromDoc(p.GetDoc())
+}
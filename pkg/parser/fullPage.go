@@ -42,6 +42,10 @@ func (p FullPage) ExtractTechnocrat() bool {
 	return p.e.ExtractTechnocratFromDoc(p.GetDoc())
 }
 
+func (p FullPage) ExtractOfficers() ogame.Officers {
+	return p.e.ExtractOfficersFromDoc(p.GetDoc())
+}
+
 func (p FullPage) ExtractLifeformEnabled() bool {
 	return p.e.ExtractLifeformEnabled(p.GetContent())
 }
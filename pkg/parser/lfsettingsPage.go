@@ -0,0 +1,7 @@
+package parser
+
+import "github.com/alaingilbert/ogame/pkg/ogame"
+
+func (p LfSettingsPage) ExtractLfSettings() (ogame.LfSettings, error) {
+	return p.e.ExtractLfSettingsFromDoc(p.GetDoc())
+}
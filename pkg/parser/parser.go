@@ -47,6 +47,8 @@ type DefensesPage struct{ FullPage }
 type MovementPage struct{ FullPage }
 type LfBuildingsPage struct{ FullPage }
 type LfResearchPage struct{ FullPage }
+type LfSettingsPage struct{ FullPage }
+type CharacterClassSelectionPage struct{ FullPage }
 
 type FullPagePages interface {
 	OverviewPage |
@@ -56,20 +58,21 @@ type FullPagePages interface {
 		FacilitiesPage |
 		LfBuildingsPage |
 		LfResearchPage |
+		LfSettingsPage |
 		//TraderOverviewPageContent |
 		//TraderResourcesPageContent |
 		ResearchPage |
 		ShipyardPage |
 		DefensesPage |
 		//FleetDispatchPageContent |
-		MovementPage
+		MovementPage |
+		CharacterClassSelectionPage
 	//GalaxyPageContent |
 	//AlliancePageContent |
 	//PremiumPageContent |
 	//ShopPageContent |
 	//MessagesPageContent |
 	//ChatPageContent |
-	//CharacterClassSelectionPageContent |
 	//BuddiesPageContent |
 	//HighScorePageContent
 }
@@ -93,6 +96,7 @@ type IFullPage interface {
 	ExtractEngineer() bool
 	ExtractGeologist() bool
 	ExtractTechnocrat() bool
+	ExtractOfficers() ogame.Officers
 	ExtractServerTime() (time.Time, error)
 }
 
@@ -136,6 +140,8 @@ func ParsePage[T FullPagePages](e extractor.Extractor, pageHTML []byte) (T, erro
 		return T(LfBuildingsPage{fullPage}), nil
 	case LfResearchPage:
 		return T(LfResearchPage{fullPage}), nil
+	case LfSettingsPage:
+		return T(LfSettingsPage{fullPage}), nil
 	case SuppliesPage:
 		return T(SuppliesPage{fullPage}), nil
 	case ResourcesSettingsPage:
@@ -144,6 +150,8 @@ func ParsePage[T FullPagePages](e extractor.Extractor, pageHTML []byte) (T, erro
 		return T(PreferencesPage{fullPage}), nil
 	case MovementPage:
 		return T(MovementPage{fullPage}), nil
+	case CharacterClassSelectionPage:
+		return T(CharacterClassSelectionPage{fullPage}), nil
 	default:
 		return zero, errors.New("page type not implemented")
 	}
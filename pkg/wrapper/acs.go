@@ -0,0 +1,244 @@
+package wrapper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// ErrACSTooSlow returned when a fleet would delay the union by more than the ACS 30% rule allows
+var ErrACSTooSlow = errors.New("fleet would delay the union too much")
+
+// acsMaxDelayRatio a fleet cannot delay the union by more than 30% of its remaining flight time
+const acsMaxDelayRatio = 0.3
+
+// ACSFleet a fleet taking part in an ACS attack
+type ACSFleet struct {
+	Origin Celestial
+	Ships  ogame.ShipsInfos
+}
+
+// ACSMember outcome of a fleet of the ACS
+type ACSMember struct {
+	Origin   ogame.Coordinate
+	Dispatch TimedDispatch
+	Delay    time.Duration // How much the fleet delayed the union
+	Err      error
+}
+
+// ACSResult outcome of an ACS attack
+type ACSResult struct {
+	UnionID     int64
+	ArrivalTime time.Time
+	Members     []ACSMember
+}
+
+// ACSError returned by Launch when some fleets failed to join the union, the other fleets are sent anyway
+type ACSError struct {
+	Members []ACSMember // The members that failed
+}
+
+// Error ...
+func (e *ACSError) Error() string {
+	msgs := make([]string, 0, len(e.Members))
+	for _, m := range e.Members {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", m.Origin, m.Err))
+	}
+	return fmt.Sprintf("%d acs fleet(s) failed: %s", len(e.Members), strings.Join(msgs, ", "))
+}
+
+// Is returns true if one of the members failed with target
+func (e *ACSError) Is(target error) bool {
+	for _, m := range e.Members {
+		if errors.Is(m.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// acsMembersError returns an ACSError holding the members that failed, nil if none did
+func acsMembersError(members []ACSMember) error {
+	failed := make([]ACSMember, 0)
+	for _, m := range members {
+		if m.Err != nil {
+			failed = append(failed, m)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &ACSError{Members: failed}
+}
+
+// ACS sends fleets from several origins so they all arrive together on the same target
+type ACS struct {
+	b        Wrapper
+	priority taskRunner.Priority
+	target   ogame.Coordinate
+	fleets   []ACSFleet
+	users    []string
+	arriveAt time.Time
+}
+
+// NewACS ...
+func NewACS(b Wrapper) *ACS {
+	a := new(ACS)
+	a.b = b
+	a.priority = taskRunner.Critical
+	return a
+}
+
+// SetPriority ...
+func (a *ACS) SetPriority(priority taskRunner.Priority) *ACS {
+	a.priority = priority
+	return a
+}
+
+// SetTarget ...
+func (a *ACS) SetTarget(target ogame.Coordinate) *ACS {
+	a.target = target
+	return a
+}
+
+// AddFleet add a fleet to the union
+func (a *ACS) AddFleet(origin any, ships ogame.ShipsInfos) *ACS {
+	a.fleets = append(a.fleets, ACSFleet{Origin: a.b.GetCachedCelestial(origin), Ships: ships})
+	return a
+}
+
+// SetUsers set the players invited in the union
+func (a *ACS) SetUsers(users []string) *ACS {
+	a.users = users
+	return a
+}
+
+// SetArriveAt set the union arrival time, by default the slowest fleet is sent right away
+func (a *ACS) SetArriveAt(t time.Time) *ACS {
+	a.arriveAt = t
+	return a
+}
+
+// Launch sends the lead fleet, creates the union and dispatches the other fleets so they join it on time.
+// Each dispatch runs in its own transaction, the bot is not locked while waiting for the timed departures.
+// When some of the other fleets fail, the result is returned with an *ACSError listing them.
+func (a *ACS) Launch() (ACSResult, error) {
+	var res ACSResult
+	if len(a.fleets) == 0 {
+		return res, errors.New("no fleet to send")
+	}
+	// The slowest fleet leads the union, so every other fleet can be timed to arrive with it
+	flightTimes := make([]time.Duration, len(a.fleets))
+	lead := 0
+	for i, fleet := range a.fleets {
+		if fleet.Origin == nil {
+			return res, errors.New("invalid origin")
+		}
+		secs, _ := a.b.WithPriority(a.priority).FlightTime(fleet.Origin.GetCoordinate(), a.target, ogame.HundredPercent, fleet.Ships, ogame.Attack)
+		flightTimes[i] = time.Duration(secs) * time.Second
+		if flightTimes[i] > flightTimes[lead] {
+			lead = i
+		}
+	}
+
+	res.Members = make([]ACSMember, len(a.fleets))
+	leadFleet := a.fleets[lead]
+	leadMember := &res.Members[lead]
+	leadMember.Origin = leadFleet.Origin.GetCoordinate()
+	fb := NewFleetBuilder(a.b).
+		SetOrigin(leadFleet.Origin).
+		SetDestination(a.target).
+		SetMission(ogame.Attack).
+		SetShips(leadFleet.Ships)
+	if a.arriveAt.IsZero() {
+		leadMember.Dispatch.DepartureTime = time.Now()
+		leadMember.Dispatch.Speed = ogame.HundredPercent
+		leadMember.Dispatch.Fleet, leadMember.Err = a.sendNow(fb)
+	} else {
+		leadMember.Dispatch, leadMember.Err = fb.SetArriveAt(a.arriveAt).SendTimed()
+	}
+	if leadMember.Err != nil {
+		return res, leadMember.Err
+	}
+	res.ArrivalTime = leadMember.Dispatch.Fleet.ArrivalTime
+	if res.ArrivalTime.IsZero() {
+		res.ArrivalTime = leadMember.Dispatch.DepartureTime.Add(flightTimes[lead])
+	}
+
+	unionID, err := a.b.WithPriority(a.priority).CreateUnion(leadMember.Dispatch.Fleet, a.users)
+	if err != nil {
+		return res, err
+	}
+	res.UnionID = unionID
+
+	// Fleets that could delay the union are handled first, so the timed ones use the final arrival time
+	order := make([]int, 0, len(a.fleets))
+	for i := range a.fleets {
+		if i != lead {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return flightTimes[order[i]] > flightTimes[order[j]] })
+
+	var wg sync.WaitGroup
+	for _, i := range order {
+		fleet := a.fleets[i]
+		member := &res.Members[i]
+		member.Origin = fleet.Origin.GetCoordinate()
+		fb := NewFleetBuilder(a.b).
+			SetOrigin(fleet.Origin).
+			SetDestination(a.target).
+			SetMission(ogame.GroupedAttack).
+			SetUnionID(unionID).
+			SetShips(fleet.Ships)
+
+		delay, ok := acsDelay(time.Now(), res.ArrivalTime, flightTimes[i])
+		member.Delay = delay
+		if !ok {
+			member.Err = ErrACSTooSlow
+			continue
+		}
+		if delay > 0 {
+			// The fleet cannot make it on time but is allowed to delay the union
+			res.ArrivalTime = res.ArrivalTime.Add(delay)
+			member.Dispatch.DepartureTime = time.Now()
+			member.Dispatch.Speed = ogame.HundredPercent
+			member.Dispatch.Fleet, member.Err = a.sendNow(fb)
+			continue
+		}
+		wg.Add(1)
+		go func(arrival time.Time) {
+			defer wg.Done()
+			member.Dispatch, member.Err = fb.SetArriveAt(arrival).SendTimed()
+		}(res.ArrivalTime)
+	}
+	wg.Wait()
+	return res, acsMembersError(res.Members)
+}
+
+// sendNow dispatches the fleet in its own transaction
+func (a *ACS) sendNow(fb *FleetBuilder) (fleet ogame.Fleet, err error) {
+	err = a.b.WithPriority(a.priority).Tx(func(tx Prioritizable) error {
+		fleet, err = fb.SetTx(tx).SendNow()
+		return err
+	})
+	return
+}
+
+// acsDelay returns how much a fleet leaving now would delay the union,
+// and whether it is allowed to join according to the ACS 30% rule.
+func acsDelay(now, unionArrival time.Time, flightTime time.Duration) (time.Duration, bool) {
+	arrival := now.Add(flightTime)
+	if !arrival.After(unionArrival) {
+		return 0, true
+	}
+	delay := arrival.Sub(unionArrival)
+	maxDelay := time.Duration(float64(unionArrival.Sub(now)) * acsMaxDelayRatio)
+	return delay, delay <= maxDelay
+}
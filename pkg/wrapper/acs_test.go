@@ -0,0 +1,45 @@
+package wrapper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcsDelay(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	arrival := now.Add(100 * time.Minute)
+
+	delay, ok := acsDelay(now, arrival, 60*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	delay, ok = acsDelay(now, arrival, 130*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Minute, delay)
+
+	delay, ok = acsDelay(now, arrival, 131*time.Minute)
+	assert.False(t, ok)
+	assert.Equal(t, 31*time.Minute, delay)
+}
+
+func TestAcsMembersError(t *testing.T) {
+	assert.NoError(t, acsMembersError([]ACSMember{{}, {}}))
+
+	errSend := errors.New("send failed")
+	err := acsMembersError([]ACSMember{
+		{Origin: ogame.Coordinate{Galaxy: 1, System: 2, Position: 3, Type: ogame.PlanetType}},
+		{Origin: ogame.Coordinate{Galaxy: 1, System: 4, Position: 5, Type: ogame.PlanetType}, Err: ErrACSTooSlow},
+		{Origin: ogame.Coordinate{Galaxy: 1, System: 6, Position: 7, Type: ogame.MoonType}, Err: errSend},
+	})
+	var acsErr *ACSError
+	assert.True(t, errors.As(err, &acsErr))
+	assert.Equal(t, 2, len(acsErr.Members))
+	assert.ErrorIs(t, err, ErrACSTooSlow)
+	assert.ErrorIs(t, err, errSend)
+	assert.NotErrorIs(t, err, ogame.ErrNotEnoughShips)
+	assert.Contains(t, err.Error(), "2 acs fleet(s) failed")
+}
@@ -0,0 +1,363 @@
+package wrapper
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
+// ErrAuctionFinished returned when trying to bid on a finished auction
+var ErrAuctionFinished = errors.New("auction completed")
+
+// ErrAlreadyHighestBidder returned when we already hold the highest bid
+var ErrAlreadyHighestBidder = errors.New("already highest bidder")
+
+// ErrAuctionNoMaxPrice returned when no max price is set for the auctioned item
+var ErrAuctionNoMaxPrice = errors.New("no max price for auctioned item")
+
+// ErrAuctionOverMaxPrice returned when outbidding would cost more than the max price of the item
+var ErrAuctionOverMaxPrice = errors.New("auction over max price")
+
+// ErrAuctionNotEnoughResources returned when the celestials of the policy cannot pay the bid
+var ErrAuctionNotEnoughResources = errors.New("not enough resources to bid")
+
+// AuctionResourcePolicy which celestials the resources of a bid are taken from.
+// On each celestial, metal is used first, then crystal, then deuterium.
+type AuctionResourcePolicy struct {
+	Celestials []ogame.CelestialID // In order of preference, empty means every celestial
+	Keep       ogame.Resources     // Resources to leave on every celestial
+}
+
+// AuctionBid a bid placed by the agent
+type AuctionBid struct {
+	Auction ogame.Auction
+	Value   int64 // Value of the bid, in metal
+	Bid     map[ogame.CelestialID]ogame.Resources
+}
+
+// AuctionAgent follows the auctioneer through the chat websocket and outbids the other players
+// near the end of the auction, as long as the total bid stays under the max price of the item.
+type AuctionAgent struct {
+	sync.Mutex
+	b               Wrapper
+	priority        taskRunner.Priority
+	maxPrices       map[string]int64
+	defaultMaxPrice int64
+	policy          AuctionResourcePolicy
+	bidWindow       time.Duration
+	endsAt          time.Time
+	registered      bool
+	running         bool
+	bidding         bool
+	bidCallbacks    []func(AuctionBid)
+	skipCallbacks   []func(ogame.Auction, error)
+	errorCallbacks  []func(error)
+}
+
+// NewAuctionAgent ...
+func NewAuctionAgent(b Wrapper) *AuctionAgent {
+	a := new(AuctionAgent)
+	a.b = b
+	a.priority = taskRunner.Normal
+	a.maxPrices = make(map[string]int64)
+	a.bidWindow = 5 * time.Minute
+	return a
+}
+
+// SetPriority ...
+func (a *AuctionAgent) SetPriority(priority taskRunner.Priority) *AuctionAgent {
+	a.priority = priority
+	return a
+}
+
+// SetMaxPrice set the maximum total bid (in metal) for an item, the item is either its short or long name (case-insensitive)
+func (a *AuctionAgent) SetMaxPrice(item string, price int64) *AuctionAgent {
+	a.Lock()
+	defer a.Unlock()
+	a.maxPrices[strings.ToLower(item)] = price
+	return a
+}
+
+// SetDefaultMaxPrice set the maximum total bid (in metal) for the items that have no max price, 0 to not bid on them
+func (a *AuctionAgent) SetDefaultMaxPrice(price int64) *AuctionAgent {
+	a.defaultMaxPrice = price
+	return a
+}
+
+// SetResourcePolicy set which celestials pay for the bids
+func (a *AuctionAgent) SetResourcePolicy(policy AuctionResourcePolicy) *AuctionAgent {
+	a.policy = policy
+	return a
+}
+
+// SetBidWindow set how long before the end of the auction the agent starts to bid
+func (a *AuctionAgent) SetBidWindow(window time.Duration) *AuctionAgent {
+	a.bidWindow = window
+	return a
+}
+
+// OnBid register a callback called when a bid was placed
+func (a *AuctionAgent) OnBid(clb func(AuctionBid)) *AuctionAgent {
+	a.bidCallbacks = append(a.bidCallbacks, clb)
+	return a
+}
+
+// OnSkip register a callback called when the agent decided not to bid (already highest bidder, over max price...)
+func (a *AuctionAgent) OnSkip(clb func(ogame.Auction, error)) *AuctionAgent {
+	a.skipCallbacks = append(a.skipCallbacks, clb)
+	return a
+}
+
+// OnError register a callback called when the auction could not be fetched or the bid failed
+func (a *AuctionAgent) OnError(clb func(error)) *AuctionAgent {
+	a.errorCallbacks = append(a.errorCallbacks, clb)
+	return a
+}
+
+// Start follows the auctioneer events and bids when needed
+func (a *AuctionAgent) Start() {
+	a.Lock()
+	if !a.registered {
+		a.b.RegisterAuctioneerCallback(a.onAuctioneer)
+		a.registered = true
+	}
+	a.running = true
+	a.Unlock()
+	go a.sync()
+}
+
+// Stop ...
+func (a *AuctionAgent) Stop() {
+	a.Lock()
+	defer a.Unlock()
+	a.running = false
+}
+
+// Bid fetches the current auction and outbids the highest bidder if the item is worth it
+func (a *AuctionAgent) Bid() (AuctionBid, error) {
+	var auction ogame.Auction
+	var value int64
+	var bid map[ogame.CelestialID]ogame.Resources
+	// The auction is read and bid on in the same transaction
+	err := a.b.WithPriority(a.priority).Tx(func(tx Prioritizable) (err error) {
+		if auction, err = tx.GetAuction(); err != nil {
+			return err
+		}
+		a.setEndsAt(auctionEndsAt(auction, time.Now()))
+		if value, err = auctionBidValue(auction, a.b.GetCachedPlayer().PlayerID, a.maxPrice(auction)); err != nil {
+			return err
+		}
+		var ok bool
+		if bid, ok = planAuctionBid(value, auction, a.policy); !ok {
+			return ErrAuctionNotEnoughResources
+		}
+		return tx.DoAuction(bid)
+	})
+	if err != nil {
+		return AuctionBid{Auction: auction}, err
+	}
+	res := AuctionBid{Auction: auction, Value: value, Bid: bid}
+	for _, clb := range a.bidCallbacks {
+		clb(res)
+	}
+	return res, nil
+}
+
+func (a *AuctionAgent) onAuctioneer(packet any) {
+	if !a.isRunning() {
+		return
+	}
+	now := time.Now()
+	switch pck := packet.(type) {
+	case ogame.AuctioneerNewAuction:
+		a.setEndsAt(now.Add(time.Duration(pck.Approx) * time.Second))
+	case ogame.AuctioneerTimeRemaining:
+		a.setEndsAt(now.Add(time.Duration(pck.Approx) * time.Second))
+		if a.inBidWindow(now) {
+			go a.tryBid()
+		}
+	case ogame.AuctioneerNewBid:
+		if pck.Player.ID != a.b.GetCachedPlayer().PlayerID && a.inBidWindow(now) {
+			go a.tryBid()
+		}
+	case ogame.AuctioneerAuctionFinished, ogame.AuctioneerNextAuction:
+		a.setEndsAt(time.Time{})
+	}
+}
+
+// sync gets the end of the current auction, in case the agent is started in the middle of it
+func (a *AuctionAgent) sync() {
+	auction, err := a.b.WithPriority(a.priority).GetAuction()
+	if err != nil {
+		a.emitError(err)
+		return
+	}
+	now := time.Now()
+	a.setEndsAt(auctionEndsAt(auction, now))
+	if a.inBidWindow(now) {
+		a.tryBid()
+	}
+}
+
+// tryBid bids unless a bid is already in progress
+func (a *AuctionAgent) tryBid() {
+	a.Lock()
+	if a.bidding || !a.running {
+		a.Unlock()
+		return
+	}
+	a.bidding = true
+	a.Unlock()
+	defer func() {
+		a.Lock()
+		a.bidding = false
+		a.Unlock()
+	}()
+	res, err := a.Bid()
+	if isAuctionSkip(err) {
+		for _, clb := range a.skipCallbacks {
+			clb(res.Auction, err)
+		}
+	} else if err != nil {
+		a.emitError(err)
+	}
+}
+
+func (a *AuctionAgent) maxPrice(auction ogame.Auction) int64 {
+	a.Lock()
+	defer a.Unlock()
+	if price, ok := a.maxPrices[strings.ToLower(auction.CurrentItem)]; ok {
+		return price
+	}
+	if price, ok := a.maxPrices[strings.ToLower(auction.CurrentItemLong)]; ok {
+		return price
+	}
+	return a.defaultMaxPrice
+}
+
+func (a *AuctionAgent) setEndsAt(endsAt time.Time) {
+	a.Lock()
+	defer a.Unlock()
+	a.endsAt = endsAt
+}
+
+func (a *AuctionAgent) inBidWindow(now time.Time) bool {
+	a.Lock()
+	defer a.Unlock()
+	return !a.endsAt.IsZero() && a.endsAt.Sub(now) <= a.bidWindow
+}
+
+func (a *AuctionAgent) isRunning() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.running
+}
+
+func (a *AuctionAgent) emitError(err error) {
+	for _, clb := range a.errorCallbacks {
+		clb(err)
+	}
+}
+
+func isAuctionSkip(err error) bool {
+	return errors.Is(err, ErrAuctionFinished) ||
+		errors.Is(err, ErrAlreadyHighestBidder) ||
+		errors.Is(err, ErrAuctionNoMaxPrice) ||
+		errors.Is(err, ErrAuctionOverMaxPrice)
+}
+
+// auctionEndsAt returns the approximate end of an auction, zero time if it is finished
+func auctionEndsAt(auction ogame.Auction, now time.Time) time.Time {
+	if auction.HasFinished {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(auction.Endtime) * time.Second)
+}
+
+// auctionBidValue returns the value (in metal) to add to our current bid to become the highest bidder
+func auctionBidValue(auction ogame.Auction, ownPlayerID, maxPrice int64) (int64, error) {
+	if auction.HasFinished {
+		return 0, ErrAuctionFinished
+	}
+	if ownPlayerID != 0 && auction.HighestBidderUserID == ownPlayerID {
+		return 0, ErrAlreadyHighestBidder
+	}
+	if maxPrice <= 0 {
+		return 0, ErrAuctionNoMaxPrice
+	}
+	// Bidding the min-bid would double our total bid, see extractAuctionFromDoc
+	value := utils.MaxInt(auction.DeficitBid, auction.MinimumBid-auction.AlreadyBid)
+	if auction.AlreadyBid+value > maxPrice {
+		return 0, ErrAuctionOverMaxPrice
+	}
+	return value, nil
+}
+
+// auctionCelestialResources returns the resources available on every celestial for the auction
+func auctionCelestialResources(auction ogame.Auction) map[ogame.CelestialID]ogame.Resources {
+	out := make(map[ogame.CelestialID]ogame.Resources)
+	for celestialIDStr, v := range auction.Resources {
+		celestial, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		input, ok := celestial["input"].(map[string]any)
+		if !ok {
+			continue
+		}
+		out[ogame.CelestialID(utils.DoParseI64(celestialIDStr))] = ogame.Resources{
+			Metal:     int64(utils.DoCastF64(input["metal"])),
+			Crystal:   int64(utils.DoCastF64(input["crystal"])),
+			Deuterium: int64(utils.DoCastF64(input["deuterium"])),
+		}
+	}
+	return out
+}
+
+// planAuctionBid splits a bid value across the celestials of the policy.
+// Returns false if the celestials do not have enough resources.
+func planAuctionBid(value int64, auction ogame.Auction, policy AuctionResourcePolicy) (map[ogame.CelestialID]ogame.Resources, bool) {
+	available := auctionCelestialResources(auction)
+	celestials := policy.Celestials
+	if len(celestials) == 0 {
+		for celestialID := range available {
+			celestials = append(celestials, celestialID)
+		}
+		sort.Slice(celestials, func(i, j int) bool { return celestials[i] < celestials[j] })
+	}
+	mult := auction.ResourceMultiplier
+	remaining := value
+	bid := make(map[ogame.CelestialID]ogame.Resources)
+	take := func(avail, keep int64, multiplier float64) int64 {
+		if remaining <= 0 || multiplier <= 0 || avail-keep <= 0 {
+			return 0
+		}
+		units := utils.MinInt(int64(math.Ceil(float64(remaining)/multiplier)), avail-keep)
+		remaining -= int64(math.Floor(float64(units) * multiplier))
+		return units
+	}
+	for _, celestialID := range celestials {
+		res, ok := available[celestialID]
+		if !ok {
+			continue
+		}
+		part := ogame.Resources{}
+		part.Metal = take(res.Metal, policy.Keep.Metal, mult.Metal)
+		part.Crystal = take(res.Crystal, policy.Keep.Crystal, mult.Crystal)
+		part.Deuterium = take(res.Deuterium, policy.Keep.Deuterium, mult.Deuterium)
+		if part.Total() > 0 {
+			bid[celestialID] = part
+		}
+		if remaining <= 0 {
+			return bid, true
+		}
+	}
+	return bid, remaining <= 0
+}
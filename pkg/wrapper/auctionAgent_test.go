@@ -0,0 +1,64 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAuction() ogame.Auction {
+	auction := ogame.Auction{MinimumBid: 4000, DeficitBid: 1000, AlreadyBid: 2000, HighestBidderUserID: 2}
+	auction.ResourceMultiplier.Metal = 1
+	auction.ResourceMultiplier.Crystal = 1.5
+	auction.ResourceMultiplier.Deuterium = 3
+	_ = json.Unmarshal([]byte(`{
+		"1":{"input":{"metal":500,"crystal":1000,"deuterium":100},"output":{"metal":0,"crystal":0,"deuterium":0},"isMoon":false},
+		"2":{"input":{"metal":10000,"crystal":0,"deuterium":0},"output":{"metal":0,"crystal":0,"deuterium":0},"isMoon":true}
+	}`), &auction.Resources)
+	return auction
+}
+
+func TestAuctionBidValue(t *testing.T) {
+	auction := newTestAuction()
+	value, err := auctionBidValue(auction, 1, 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), value)
+
+	_, err = auctionBidValue(auction, 1, 3000)
+	assert.ErrorIs(t, err, ErrAuctionOverMaxPrice)
+	_, err = auctionBidValue(auction, 1, 0)
+	assert.ErrorIs(t, err, ErrAuctionNoMaxPrice)
+	_, err = auctionBidValue(auction, 2, 5000)
+	assert.ErrorIs(t, err, ErrAlreadyHighestBidder)
+
+	auction.HasFinished = true
+	_, err = auctionBidValue(auction, 1, 5000)
+	assert.ErrorIs(t, err, ErrAuctionFinished)
+}
+
+func TestAuctionCelestialResources(t *testing.T) {
+	res := auctionCelestialResources(newTestAuction())
+	assert.Equal(t, ogame.Resources{Metal: 500, Crystal: 1000, Deuterium: 100}, res[1])
+	assert.Equal(t, ogame.Resources{Metal: 10000}, res[2])
+}
+
+func TestPlanAuctionBid(t *testing.T) {
+	auction := newTestAuction()
+
+	bid, ok := planAuctionBid(2000, auction, AuctionResourcePolicy{})
+	assert.True(t, ok)
+	assert.Equal(t, map[ogame.CelestialID]ogame.Resources{1: {Metal: 500, Crystal: 1000}}, bid)
+
+	bid, ok = planAuctionBid(2000, auction, AuctionResourcePolicy{Celestials: []ogame.CelestialID{2, 1}})
+	assert.True(t, ok)
+	assert.Equal(t, map[ogame.CelestialID]ogame.Resources{2: {Metal: 2000}}, bid)
+
+	bid, ok = planAuctionBid(1700, auction, AuctionResourcePolicy{Celestials: []ogame.CelestialID{1}, Keep: ogame.Resources{Metal: 500}})
+	assert.True(t, ok)
+	assert.Equal(t, map[ogame.CelestialID]ogame.Resources{1: {Crystal: 1000, Deuterium: 67}}, bid)
+
+	_, ok = planAuctionBid(20000, auction, AuctionResourcePolicy{})
+	assert.False(t, ok)
+}
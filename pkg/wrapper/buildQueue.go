@@ -0,0 +1,622 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// Errors returned by the build queue
+var (
+	ErrBuildQueueInvalidNbr   = errors.New("invalid level or number of units")
+	ErrBuildQueueInvalidIndex = errors.New("invalid build queue index")
+	ErrBuildQueueRequirements = errors.New("requirements not met")
+)
+
+// BuildQueueItem one order of a build queue.
+// Buildings and technologies stay in the queue until the target level is reached,
+// ships and defenses are removed as soon as the order is started.
+type BuildQueueItem struct {
+	ID  ogame.ID
+	Nbr int64 // Target level for buildings and technologies, number of units for ships and defenses
+}
+
+// isLevelable returns true if Nbr is a target level
+func (i BuildQueueItem) isLevelable() bool {
+	return !i.ID.IsShip() && !i.ID.IsDefense()
+}
+
+// buildSlot queue of the game an item is built in
+type buildSlot int
+
+const (
+	buildingSlot buildSlot = iota
+	researchSlot
+	shipyardSlot
+	lfBuildingSlot
+	lfResearchSlot
+)
+
+func slotOf(id ogame.ID) buildSlot {
+	switch {
+	case id.IsShip(), id.IsDefense():
+		return shipyardSlot
+	case id.IsLfBuilding():
+		return lfBuildingSlot
+	case id.IsLfTech():
+		return lfResearchSlot
+	case id.IsTech():
+		return researchSlot
+	}
+	return buildingSlot
+}
+
+// buildQueueStatus state of a celestial used to plan its queue
+type buildQueueStatus struct {
+	building   ogame.ID                    // Building being upgraded
+	countdowns map[buildSlot]time.Duration // Remaining time of the slots in use
+	levels     map[ogame.ID]int64          // Current levels of the queued buildings and technologies and of their requirements
+	available  ogame.Resources
+	production ogame.Resources                  // Hourly production
+	backoffs   map[BuildQueueItem]time.Duration // Remaining time before retrying the items that failed to start
+}
+
+// buildQueuePlan what to do with the items of a queue
+type buildQueuePlan struct {
+	start  []BuildQueueItem
+	done   []BuildQueueItem // Items whose target level is reached
+	parked []BuildQueueItem // Items whose requirements are not met and not queued before them
+	wait   time.Duration    // Time before something can be started, 0 if nothing is waiting
+}
+
+// planBuildQueue returns the items to start now. Only the first item of each slot can be started.
+// Items are served in order: once an item waits for resources, the items after it are not started
+// so they don't delay it.
+// Items backing off after a failure, and items whose requirements are not met, don't hold their slot.
+// An item whose missing requirements are queued before it waits for them, otherwise it is parked.
+func planBuildQueue(items []BuildQueueItem, status buildQueueStatus) (plan buildQueuePlan) {
+	available := status.available
+	waitFor := func(d time.Duration) {
+		if plan.wait == 0 || d < plan.wait {
+			plan.wait = d
+		}
+	}
+	seen := make(map[buildSlot]bool)
+	queued := make(map[ogame.ID]int64) // Target levels of the items before the current one
+	starving := false
+	for _, item := range items {
+		slot := slotOf(item.ID)
+		level := status.levels[item.ID]
+		if item.isLevelable() && level >= item.Nbr {
+			plan.done = append(plan.done, item)
+			continue
+		}
+		provided := status.requirementsMet(item.ID, queued)
+		if item.isLevelable() && item.Nbr > queued[item.ID] {
+			queued[item.ID] = item.Nbr
+		}
+		if !status.requirementsMet(item.ID, nil) {
+			if !provided {
+				plan.parked = append(plan.parked, item)
+			}
+			continue
+		}
+		if backoff := status.backoffs[item]; backoff > 0 {
+			waitFor(backoff)
+			continue
+		}
+		if seen[slot] || starving {
+			continue
+		}
+		seen[slot] = true
+		if countdown := status.countdowns[slot]; countdown > 0 && slot != shipyardSlot {
+			waitFor(countdown)
+			continue
+		}
+		if blocked := status.blockedFor(item.ID); blocked > 0 {
+			waitFor(blocked)
+			continue
+		}
+		price := itemPrice(item, level)
+		if !available.CanAfford(price) {
+			starving = true
+			waitFor(timeToAfford(available, price, status.production))
+			continue
+		}
+		available = available.Sub(price)
+		plan.start = append(plan.start, item)
+	}
+	return
+}
+
+// requirementsMet returns true if the current levels, or the levels queued, reach the requirements of the object
+func (s buildQueueStatus) requirementsMet(id ogame.ID, queued map[ogame.ID]int64) bool {
+	for reqID, reqLevel := range ogame.Objs.ByID(id).GetRequirements() {
+		if s.levels[reqID] < reqLevel && queued[reqID] < reqLevel {
+			return false
+		}
+	}
+	return true
+}
+
+// blockedFor returns the time before the item can be started because of another construction.
+// Shipyard and nanite factory can't be upgraded while the shipyard is producing,
+// the research lab while researching, and the shipyard can't produce while one of them is upgraded.
+func (s buildQueueStatus) blockedFor(id ogame.ID) time.Duration {
+	switch {
+	case id == ogame.ShipyardID, id == ogame.NaniteFactoryID:
+		return s.countdowns[shipyardSlot]
+	case id == ogame.ResearchLabID:
+		return s.countdowns[researchSlot]
+	case id.IsShip(), id.IsDefense():
+		if s.building == ogame.ShipyardID || s.building == ogame.NaniteFactoryID {
+			return s.countdowns[buildingSlot]
+		}
+	}
+	return 0
+}
+
+// itemPrice returns the price of the next step of an item
+func itemPrice(item BuildQueueItem, level int64) ogame.Resources {
+	obj := ogame.Objs.ByID(item.ID)
+	if item.isLevelable() {
+		return obj.GetPrice(level + 1)
+	}
+	return obj.GetPrice(item.Nbr)
+}
+
+// timeToAfford returns the time for the production to cover the missing resources, capped to a week
+func timeToAfford(available, price, production ogame.Resources) time.Duration {
+	const maxWait = 7 * 24 * time.Hour
+	var hours float64
+	for _, r := range []struct{ available, price, production int64 }{
+		{available.Metal, price.Metal, production.Metal},
+		{available.Crystal, price.Crystal, production.Crystal},
+		{available.Deuterium, price.Deuterium, production.Deuterium},
+	} {
+		missing := r.price - r.available
+		if missing <= 0 {
+			continue
+		}
+		if r.production <= 0 {
+			return maxWait
+		}
+		hours = math.Max(hours, float64(missing)/float64(r.production))
+	}
+	return time.Duration(math.Min(hours*float64(time.Hour), float64(maxWait)))
+}
+
+// buildQueueBackoff failures of an item that could not be started
+type buildQueueBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// BuildQueue persistent per-celestial queue of buildings, researches and shipyard orders.
+// The executor starts the next items when their slot is free and the resources are available,
+// then waits for the constructions countdown. The queue is saved to a file after every change.
+// An item that fails to start, or whose requirements are not met, is retried after a delay doubling
+// at each failure, from the minimum to the maximum check interval.
+type BuildQueue struct {
+	sync.Mutex
+	b              Wrapper
+	priority       taskRunner.Priority
+	filename       string
+	queues         map[ogame.CelestialID][]BuildQueueItem
+	skipFailed     bool
+	backoffs       map[ogame.CelestialID]map[BuildQueueItem]*buildQueueBackoff
+	minInterval    time.Duration
+	maxInterval    time.Duration
+	stopCh         chan struct{}
+	startCallbacks []func(ogame.CelestialID, BuildQueueItem)
+	errorCallbacks []func(ogame.CelestialID, BuildQueueItem, error)
+}
+
+// NewBuildQueue ...
+func NewBuildQueue(b Wrapper) *BuildQueue {
+	q := new(BuildQueue)
+	q.b = b
+	q.priority = taskRunner.Normal
+	q.queues = make(map[ogame.CelestialID][]BuildQueueItem)
+	q.backoffs = make(map[ogame.CelestialID]map[BuildQueueItem]*buildQueueBackoff)
+	q.minInterval = 10 * time.Second
+	q.maxInterval = 15 * time.Minute
+	return q
+}
+
+// SetPriority set the task runner priority used by the executor
+func (q *BuildQueue) SetPriority(priority taskRunner.Priority) *BuildQueue {
+	q.priority = priority
+	return q
+}
+
+// SetFilename set the file the queue is saved to, the queue is only kept in memory when not set
+func (q *BuildQueue) SetFilename(filename string) *BuildQueue {
+	q.filename = filename
+	return q
+}
+
+// SetSkipFailed remove the items that fail to start, or whose requirements are not met, instead of retrying them later
+func (q *BuildQueue) SetSkipFailed(skipFailed bool) *BuildQueue {
+	q.skipFailed = skipFailed
+	return q
+}
+
+// SetInterval set the minimum and maximum time between two checks
+func (q *BuildQueue) SetInterval(minInterval, maxInterval time.Duration) *BuildQueue {
+	q.minInterval = minInterval
+	q.maxInterval = maxInterval
+	return q
+}
+
+// OnStart register a callback called when an item is started
+func (q *BuildQueue) OnStart(clb func(ogame.CelestialID, BuildQueueItem)) *BuildQueue {
+	q.startCallbacks = append(q.startCallbacks, clb)
+	return q
+}
+
+// OnError register a callback called when an item could not be started or the queue could not be saved
+func (q *BuildQueue) OnError(clb func(ogame.CelestialID, BuildQueueItem, error)) *BuildQueue {
+	q.errorCallbacks = append(q.errorCallbacks, clb)
+	return q
+}
+
+// Load the queue from the file, a missing file is an empty queue
+func (q *BuildQueue) Load() error {
+	q.Lock()
+	defer q.Unlock()
+	if q.filename == "" {
+		return nil
+	}
+	by, err := os.ReadFile(q.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	queues := make(map[ogame.CelestialID][]BuildQueueItem)
+	if err := json.Unmarshal(by, &queues); err != nil {
+		return err
+	}
+	q.queues = queues
+	return nil
+}
+
+// save must be called with the lock held
+func (q *BuildQueue) save() error {
+	if q.filename == "" {
+		return nil
+	}
+	by, err := json.Marshal(q.queues)
+	if err != nil {
+		return err
+	}
+	// Write then rename so a crash never leaves a truncated file
+	tmp := q.filename + ".tmp"
+	if err := os.WriteFile(tmp, by, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.filename)
+}
+
+// Add an item at the end of the queue of a celestial.
+// nbr is the target level for buildings and technologies, the number of units for ships and defenses.
+func (q *BuildQueue) Add(celestialID ogame.CelestialID, id ogame.ID, nbr int64) error {
+	if ogame.Objs.ByID(id) == nil {
+		return ogame.ErrInvalidOgameID
+	}
+	if nbr <= 0 {
+		return ErrBuildQueueInvalidNbr
+	}
+	q.Lock()
+	defer q.Unlock()
+	q.queues[celestialID] = append(q.queues[celestialID], BuildQueueItem{ID: id, Nbr: nbr})
+	return q.save()
+}
+
+// Remove the item at the given index of the queue of a celestial
+func (q *BuildQueue) Remove(celestialID ogame.CelestialID, index int) error {
+	q.Lock()
+	defer q.Unlock()
+	items := q.queues[celestialID]
+	if index < 0 || index >= len(items) {
+		return ErrBuildQueueInvalidIndex
+	}
+	q.setItems(celestialID, append(items[:index:index], items[index+1:]...))
+	return q.save()
+}
+
+// Clear the queue of a celestial
+func (q *BuildQueue) Clear(celestialID ogame.CelestialID) error {
+	q.Lock()
+	defer q.Unlock()
+	delete(q.queues, celestialID)
+	return q.save()
+}
+
+// Items returns the queue of a celestial
+func (q *BuildQueue) Items(celestialID ogame.CelestialID) []BuildQueueItem {
+	q.Lock()
+	defer q.Unlock()
+	return append([]BuildQueueItem{}, q.queues[celestialID]...)
+}
+
+// Celestials returns the celestials having a queue
+func (q *BuildQueue) Celestials() []ogame.CelestialID {
+	q.Lock()
+	defer q.Unlock()
+	out := make([]ogame.CelestialID, 0, len(q.queues))
+	for celestialID := range q.queues {
+		out = append(out, celestialID)
+	}
+	return out
+}
+
+func (q *BuildQueue) setItems(celestialID ogame.CelestialID, items []BuildQueueItem) {
+	if len(items) == 0 {
+		delete(q.queues, celestialID)
+		return
+	}
+	q.queues[celestialID] = items
+}
+
+// removeItems removes the first occurrence of each item, the queue may have changed since it was planned
+func (q *BuildQueue) removeItems(celestialID ogame.CelestialID, toRemove []BuildQueueItem) error {
+	if len(toRemove) == 0 {
+		return nil
+	}
+	q.Lock()
+	defer q.Unlock()
+	items := append([]BuildQueueItem{}, q.queues[celestialID]...)
+	for _, item := range toRemove {
+		for i, el := range items {
+			if el == item {
+				items = append(items[:i], items[i+1:]...)
+				break
+			}
+		}
+	}
+	q.setItems(celestialID, items)
+	return q.save()
+}
+
+// Start the executor in a goroutine
+func (q *BuildQueue) Start() {
+	q.Lock()
+	defer q.Unlock()
+	if q.stopCh != nil {
+		return
+	}
+	q.stopCh = make(chan struct{})
+	go q.run(q.stopCh)
+}
+
+// Stop the executor, the queue is kept
+func (q *BuildQueue) Stop() {
+	q.Lock()
+	defer q.Unlock()
+	if q.stopCh != nil {
+		close(q.stopCh)
+		q.stopCh = nil
+	}
+}
+
+func (q *BuildQueue) run(stopCh chan struct{}) {
+	for {
+		interval := q.Check()
+		select {
+		case <-time.After(interval):
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// Check starts the items that can be started on every celestial, returns the time to wait before next check
+func (q *BuildQueue) Check() time.Duration {
+	interval := q.maxInterval
+	for _, celestialID := range q.Celestials() {
+		if wait := q.checkCelestial(celestialID); wait > 0 && wait < interval {
+			interval = wait
+		}
+	}
+	if interval < q.minInterval {
+		interval = q.minInterval
+	}
+	return interval
+}
+
+// checkCelestial returns the time before something can be started on the celestial, 0 if nothing is waiting
+func (q *BuildQueue) checkCelestial(celestialID ogame.CelestialID) time.Duration {
+	items := q.Items(celestialID)
+	if len(items) == 0 {
+		return 0
+	}
+	type failure struct {
+		item BuildQueueItem
+		err  error
+	}
+	var plan buildQueuePlan
+	var started []BuildQueueItem
+	var failures []failure
+	backoffs := q.remainingBackoffs(celestialID, items)
+	// One transaction so nothing else runs between the status and the builds it is based on
+	err := q.b.WithPriority(q.priority).Tx(func(tx Prioritizable) error {
+		status, err := q.status(tx, celestialID, items)
+		if err != nil {
+			return err
+		}
+		status.backoffs = backoffs
+		plan = planBuildQueue(items, status)
+		for _, item := range plan.start {
+			if err := tx.Build(celestialID, item.ID, item.Nbr); err != nil {
+				failures = append(failures, failure{item, err})
+				continue
+			}
+			started = append(started, item)
+		}
+		return nil
+	})
+	if err != nil {
+		q.error(celestialID, BuildQueueItem{}, err)
+		return q.minInterval
+	}
+	for _, item := range plan.parked {
+		// Reported again only once its back off is over
+		if backoffs[item] <= 0 {
+			failures = append(failures, failure{item, ErrBuildQueueRequirements})
+		}
+	}
+	toRemove := plan.done
+	for _, f := range failures {
+		q.error(celestialID, f.item, f.err)
+		if q.skipFailed {
+			toRemove = append(toRemove, f.item)
+		} else {
+			q.backOff(celestialID, f.item)
+		}
+	}
+	for _, item := range started {
+		q.resetBackoff(celestialID, item)
+		if !item.isLevelable() {
+			toRemove = append(toRemove, item)
+		}
+		for _, clb := range q.startCallbacks {
+			clb(celestialID, item)
+		}
+	}
+	if err := q.removeItems(celestialID, toRemove); err != nil {
+		q.error(celestialID, BuildQueueItem{}, err)
+	}
+	if len(plan.start) > 0 {
+		// Check again soon to get the countdown of what was started
+		return q.minInterval
+	}
+	return plan.wait
+}
+
+// remainingBackoffs returns the time before the items that failed can be retried, and forgets the items no longer queued
+func (q *BuildQueue) remainingBackoffs(celestialID ogame.CelestialID, items []BuildQueueItem) map[BuildQueueItem]time.Duration {
+	q.Lock()
+	defer q.Unlock()
+	queued := make(map[BuildQueueItem]bool, len(items))
+	for _, item := range items {
+		queued[item] = true
+	}
+	out := make(map[BuildQueueItem]time.Duration)
+	for item, backoff := range q.backoffs[celestialID] {
+		if !queued[item] {
+			delete(q.backoffs[celestialID], item)
+			continue
+		}
+		if remaining := time.Until(backoff.until); remaining > 0 {
+			out[item] = remaining
+		}
+	}
+	return out
+}
+
+// backOff delays the next try of an item, the delay doubles at each failure up to the maximum interval
+func (q *BuildQueue) backOff(celestialID ogame.CelestialID, item BuildQueueItem) {
+	q.Lock()
+	defer q.Unlock()
+	if q.backoffs[celestialID] == nil {
+		q.backoffs[celestialID] = make(map[BuildQueueItem]*buildQueueBackoff)
+	}
+	backoff, ok := q.backoffs[celestialID][item]
+	if !ok {
+		backoff = new(buildQueueBackoff)
+		q.backoffs[celestialID][item] = backoff
+	}
+	delay := q.maxInterval
+	if backoff.failures < 16 {
+		delay = time.Duration(math.Min(float64(q.minInterval<<backoff.failures), float64(q.maxInterval)))
+	}
+	backoff.failures++
+	backoff.until = time.Now().Add(delay)
+}
+
+func (q *BuildQueue) resetBackoff(celestialID ogame.CelestialID, item BuildQueueItem) {
+	q.Lock()
+	defer q.Unlock()
+	delete(q.backoffs[celestialID], item)
+}
+
+// status fetches what is needed to plan the queue of a celestial
+func (q *BuildQueue) status(tx Prioritizable, celestialID ogame.CelestialID, items []BuildQueueItem) (buildQueueStatus, error) {
+	status := buildQueueStatus{
+		countdowns: make(map[buildSlot]time.Duration),
+		levels:     make(map[ogame.ID]int64),
+	}
+	building, buildingCountdown, _, researchCountdown, _, lfBuildingCountdown, _, lfResearchCountdown := tx.ConstructionsBeingBuilt(celestialID)
+	status.building = building
+	status.countdowns[buildingSlot] = time.Duration(buildingCountdown) * time.Second
+	status.countdowns[researchSlot] = time.Duration(researchCountdown) * time.Second
+	status.countdowns[lfBuildingSlot] = time.Duration(lfBuildingCountdown) * time.Second
+	status.countdowns[lfResearchSlot] = time.Duration(lfResearchCountdown) * time.Second
+	_, shipyardCountdown, err := tx.GetProduction(celestialID)
+	if err != nil {
+		return status, err
+	}
+	status.countdowns[shipyardSlot] = time.Duration(shipyardCountdown) * time.Second
+
+	resBuildings, facilities, _, _, researches, lfBuildings, err := tx.GetTechs(celestialID)
+	if err != nil {
+		return status, err
+	}
+	// Levels of the queued items and of their requirements
+	ids := make([]ogame.ID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+		for reqID := range ogame.Objs.ByID(item.ID).GetRequirements() {
+			ids = append(ids, reqID)
+		}
+	}
+	var lfResearches ogame.LfResearches
+	for _, id := range ids {
+		if id.IsLfTech() {
+			if lfResearches, err = tx.GetLfResearch(celestialID); err != nil {
+				return status, err
+			}
+			break
+		}
+	}
+	for _, id := range ids {
+		switch {
+		case id.IsResourceBuilding():
+			status.levels[id] = resBuildings.ByID(id)
+		case id.IsFacility():
+			status.levels[id] = facilities.ByID(id)
+		case id.IsTech():
+			status.levels[id] = researches.ByID(id)
+		case id.IsLfBuilding():
+			status.levels[id] = lfBuildings.ByID(id)
+		case id.IsLfTech():
+			status.levels[id] = lfResearches.ByID(id)
+		}
+	}
+
+	details, err := tx.GetResourcesDetails(celestialID)
+	if err != nil {
+		return status, err
+	}
+	status.available = details.Available()
+	status.production = ogame.Resources{
+		Metal:     details.Metal.CurrentProduction,
+		Crystal:   details.Crystal.CurrentProduction,
+		Deuterium: details.Deuterium.CurrentProduction,
+	}
+	return status, nil
+}
+
+func (q *BuildQueue) error(celestialID ogame.CelestialID, item BuildQueueItem, err error) {
+	for _, clb := range q.errorCallbacks {
+		clb(celestialID, item, err)
+	}
+}
@@ -0,0 +1,136 @@
+package wrapper
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBuildQueueStatus() buildQueueStatus {
+	return buildQueueStatus{
+		countdowns: make(map[buildSlot]time.Duration),
+		levels: map[ogame.ID]int64{ogame.MetalMineID: 10, ogame.ShipyardID: 4, ogame.EnergyTechnologyID: 3,
+			ogame.RoboticsFactoryID: 2, ogame.ResearchLabID: 1, ogame.CombustionDriveID: 2},
+		available:  ogame.Resources{Metal: 1_000_000, Crystal: 1_000_000, Deuterium: 1_000_000},
+		production: ogame.Resources{Metal: 3600, Crystal: 1800, Deuterium: 900},
+	}
+}
+
+func TestPlanBuildQueue(t *testing.T) {
+	items := []BuildQueueItem{
+		{ID: ogame.MetalMineID, Nbr: 10},
+		{ID: ogame.ShipyardID, Nbr: 6},
+		{ID: ogame.MetalMineID, Nbr: 12},
+		{ID: ogame.EnergyTechnologyID, Nbr: 4},
+		{ID: ogame.SmallCargoID, Nbr: 5},
+	}
+	status := newBuildQueueStatus()
+	plan := planBuildQueue(items, status)
+	assert.Equal(t, []BuildQueueItem{{ID: ogame.MetalMineID, Nbr: 10}}, plan.done)
+	// Only the first item of the building slot is started
+	assert.Equal(t, []BuildQueueItem{items[1], items[3], items[4]}, plan.start)
+	assert.Equal(t, time.Duration(0), plan.wait)
+
+	// Busy slots
+	status.building = ogame.ShipyardID
+	status.countdowns[buildingSlot] = 5 * time.Minute
+	status.countdowns[researchSlot] = 2 * time.Minute
+	plan = planBuildQueue(items, status)
+	assert.Empty(t, plan.start)
+	assert.Equal(t, 2*time.Minute, plan.wait)
+}
+
+func TestPlanBuildQueue_Resources(t *testing.T) {
+	items := []BuildQueueItem{
+		{ID: ogame.MetalMineID, Nbr: 11},
+		{ID: ogame.EnergyTechnologyID, Nbr: 4},
+	}
+	status := newBuildQueueStatus()
+	status.available = ogame.MetalMine.GetPrice(11)
+	status.available.Metal -= 3600
+	plan := planBuildQueue(items, status)
+	// Energy technology waits so it doesn't delay the metal mine
+	assert.Empty(t, plan.start)
+	assert.Equal(t, time.Hour, plan.wait)
+
+	status.available.Metal += 3600
+	plan = planBuildQueue(items, status)
+	assert.Equal(t, []BuildQueueItem{items[0]}, plan.start)
+}
+
+func TestPlanBuildQueue_Requirements(t *testing.T) {
+	items := []BuildQueueItem{
+		{ID: ogame.RoboticsFactoryID, Nbr: 10},
+		{ID: ogame.NaniteFactoryID, Nbr: 1},        // Waits for the robotics factory queued before it
+		{ID: ogame.GravitonTechnologyID, Nbr: 1},   // Parked, nothing queued provides its requirements
+		{ID: ogame.EnergyTechnologyID, Nbr: 4},     // The parked research doesn't hold the research slot
+		{ID: ogame.HyperspaceTechnologyID, Nbr: 1}, // Parked
+	}
+	status := newBuildQueueStatus()
+	status.levels[ogame.ComputerTechnologyID] = 10
+	plan := planBuildQueue(items, status)
+	assert.Equal(t, []BuildQueueItem{items[0], items[3]}, plan.start)
+	assert.Equal(t, []BuildQueueItem{items[2], items[4]}, plan.parked)
+}
+
+func TestPlanBuildQueue_Backoff(t *testing.T) {
+	items := []BuildQueueItem{
+		{ID: ogame.MetalMineID, Nbr: 11},
+		{ID: ogame.ShipyardID, Nbr: 6},
+	}
+	status := newBuildQueueStatus()
+	status.backoffs = map[BuildQueueItem]time.Duration{items[0]: time.Minute}
+	plan := planBuildQueue(items, status)
+	// The failing item doesn't block the next item of its slot
+	assert.Equal(t, []BuildQueueItem{items[1]}, plan.start)
+	assert.Equal(t, time.Minute, plan.wait)
+}
+
+func TestBuildQueue_backOff(t *testing.T) {
+	q := NewBuildQueue(nil).SetInterval(10*time.Second, time.Minute)
+	item := BuildQueueItem{ID: ogame.MetalMineID, Nbr: 11}
+	for i := 0; i < 5; i++ {
+		q.backOff(123, item)
+	}
+	assert.Equal(t, 5, q.backoffs[123][item].failures)
+	remaining := q.remainingBackoffs(123, []BuildQueueItem{item})[item]
+	assert.True(t, remaining > 50*time.Second && remaining <= time.Minute)
+	q.resetBackoff(123, item)
+	assert.Empty(t, q.remainingBackoffs(123, []BuildQueueItem{item}))
+
+	q.backOff(123, item)
+	remaining = q.remainingBackoffs(123, []BuildQueueItem{item})[item]
+	assert.True(t, remaining > 0 && remaining <= 10*time.Second)
+	// Forgotten once the item is no longer queued
+	assert.Empty(t, q.remainingBackoffs(123, nil))
+	assert.Empty(t, q.backoffs[123])
+}
+
+func TestTimeToAfford(t *testing.T) {
+	production := ogame.Resources{Metal: 1000, Crystal: 500}
+	assert.Equal(t, time.Duration(0), timeToAfford(ogame.Resources{Metal: 100}, ogame.Resources{Metal: 100}, production))
+	assert.Equal(t, 2*time.Hour, timeToAfford(ogame.Resources{}, ogame.Resources{Metal: 1000, Crystal: 1000}, production))
+	assert.Equal(t, 7*24*time.Hour, timeToAfford(ogame.Resources{}, ogame.Resources{Deuterium: 1}, production))
+}
+
+func TestBuildQueuePersistence(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "queue.json")
+	q := NewBuildQueue(nil).SetFilename(filename)
+	assert.NoError(t, q.Load())
+	assert.NoError(t, q.Add(123, ogame.MetalMineID, 12))
+	assert.NoError(t, q.Add(123, ogame.SmallCargoID, 10))
+	assert.NoError(t, q.Add(456, ogame.EnergyTechnologyID, 5))
+	assert.Equal(t, ogame.ErrInvalidOgameID, q.Add(123, ogame.ID(999), 1))
+	assert.Equal(t, ErrBuildQueueInvalidNbr, q.Add(123, ogame.MetalMineID, 0))
+	assert.Equal(t, ErrBuildQueueInvalidIndex, q.Remove(123, 2))
+	assert.NoError(t, q.Remove(123, 0))
+	assert.NoError(t, q.Clear(456))
+
+	restored := NewBuildQueue(nil).SetFilename(filename)
+	assert.NoError(t, restored.Load())
+	assert.Equal(t, []BuildQueueItem{{ID: ogame.SmallCargoID, Nbr: 10}}, restored.Items(123))
+	assert.Equal(t, []ogame.CelestialID{123}, restored.Celestials())
+}
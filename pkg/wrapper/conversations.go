@@ -0,0 +1,79 @@
+package wrapper
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+)
+
+// ErrConversationsTab returned when the conversations tab is read as a messages tab, conversations are read with GetConversations
+var ErrConversationsTab = errors.New("conversations are read with GetConversations")
+
+type conversationKey struct {
+	playerID      int64
+	associationID int64
+}
+
+func newConversationKey(c ogame.Conversation) conversationKey {
+	if c.AssociationID != 0 {
+		return conversationKey{associationID: c.AssociationID}
+	}
+	return conversationKey{playerID: c.PlayerID}
+}
+
+// conversationStore unifies the messages received through the chat websocket, the messages we sent
+// and the history loaded from the chat page.
+type conversationStore struct {
+	sync.Mutex
+	conversations map[conversationKey]*ogame.Conversation
+}
+
+func newConversationStore() *conversationStore {
+	return &conversationStore{conversations: make(map[conversationKey]*ogame.Conversation)}
+}
+
+// add merges the messages in the stored conversation and returns a copy of it
+func (s *conversationStore) add(c ogame.Conversation, msgs ...ogame.ConversationMessage) ogame.Conversation {
+	s.Lock()
+	defer s.Unlock()
+	key := newConversationKey(c)
+	stored, ok := s.conversations[key]
+	if !ok {
+		stored = &ogame.Conversation{PlayerID: key.playerID, AssociationID: key.associationID}
+		s.conversations[key] = stored
+	}
+	if c.Name != "" {
+		stored.Name = c.Name
+	}
+	stored.Unread = c.Unread
+	if c.Preview.Text != "" {
+		stored.Preview = c.Preview
+	}
+	stored.Add(c.Messages...)
+	stored.Add(msgs...)
+	out := *stored
+	out.Messages = append([]ogame.ConversationMessage(nil), stored.Messages...)
+	return out
+}
+
+// all returns a copy of every stored conversation
+func (s *conversationStore) all() []ogame.Conversation {
+	s.Lock()
+	defer s.Unlock()
+	out := make([]ogame.Conversation, 0, len(s.conversations))
+	for _, c := range s.conversations {
+		cpy := *c
+		cpy.Messages = append([]ogame.ConversationMessage(nil), c.Messages...)
+		out = append(out, cpy)
+	}
+	return out
+}
+
+// conversationFromChatMsg returns the conversation a chat message received from the websocket belongs to
+func conversationFromChatMsg(msg ogame.ChatMsg) ogame.Conversation {
+	if msg.AssociationID != 0 {
+		return ogame.Conversation{AssociationID: msg.AssociationID}
+	}
+	return ogame.Conversation{PlayerID: msg.SenderID, Name: msg.SenderName, Unread: true}
+}
@@ -0,0 +1,43 @@
+package wrapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversationStore(t *testing.T) {
+	now := time.Now()
+	s := newConversationStore()
+	received := ogame.ChatMsg{SenderID: 100247, SenderName: "Jalmag", ID: 3, Text: "hi", Date: now.Unix()}
+	c := s.add(conversationFromChatMsg(received), received.ConversationMessage())
+	assert.Equal(t, int64(100247), c.PlayerID)
+	assert.Equal(t, "Jalmag", c.Name)
+	assert.True(t, c.Unread)
+
+	// The preview of the conversations list is kept apart from the history
+	preview := ogame.ConversationMessage{SenderID: 100247, Text: "hi", Date: time.Unix(now.Unix(), 0)}
+	c = s.add(ogame.Conversation{PlayerID: 100247, Preview: preview})
+	assert.Equal(t, 1, len(c.Messages))
+	assert.Equal(t, preview, c.Preview)
+
+	history := []ogame.ConversationMessage{{ID: 1, Date: now.Add(-time.Hour)}, {ID: 3, Date: time.Unix(now.Unix(), 0)}}
+	c = s.add(ogame.Conversation{PlayerID: 100247}, history...)
+	assert.Equal(t, "Jalmag", c.Name)
+	assert.Equal(t, 2, len(c.Messages))
+	assert.Equal(t, int64(1), c.Messages[0].ID)
+	assert.Equal(t, preview, c.Preview)
+
+	alliance := ogame.ChatMsg{SenderID: 100311, AssociationID: 5012, ID: 4, Date: now.Unix()}
+	c = s.add(conversationFromChatMsg(alliance), alliance.ConversationMessage())
+	assert.True(t, c.IsAlliance())
+	assert.Equal(t, 2, len(s.all()))
+}
+
+func TestGetMessages_ConversationsTab(t *testing.T) {
+	_, _, err := (&OGame{}).getMessages(ConversationsMessagesTabID, 1)
+	assert.ErrorIs(t, err, ErrConversationsTab)
+	assert.NotContains(t, MessagesTabIDs, ConversationsMessagesTabID)
+}
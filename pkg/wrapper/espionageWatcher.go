@@ -0,0 +1,186 @@
+package wrapper
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// ProbeWave foreign espionage actions received on the same celestial.
+// Attacks holds the hostile fleets (other than espionage) heading to that celestial when the wave was detected,
+// an empty list means the celestial was only probed.
+type ProbeWave struct {
+	Target   ogame.Coordinate
+	Messages []ogame.EspionageActionMessage
+	Attacks  []ogame.AttackEvent
+}
+
+// IsAttacked returns true if a hostile fleet was heading to the probed celestial
+func (w ProbeWave) IsAttacked() bool {
+	return len(w.Attacks) > 0
+}
+
+// EspionageWatcher polls the espionage messages and streams the new foreign espionage actions.
+// Messages already in the inbox when the watcher starts are not streamed.
+type EspionageWatcher struct {
+	sync.Mutex
+	b               Wrapper
+	priority        taskRunner.Priority
+	interval        time.Duration
+	lastMsgID       int64
+	initialized     bool
+	stopCh          chan struct{}
+	actionCallbacks []func(ogame.EspionageActionMessage)
+	waveCallbacks   []func(ProbeWave)
+	errorCallbacks  []func(error)
+}
+
+// NewEspionageWatcher ...
+func NewEspionageWatcher(b Wrapper) *EspionageWatcher {
+	w := new(EspionageWatcher)
+	w.b = b
+	w.priority = taskRunner.Normal
+	w.interval = time.Minute
+	return w
+}
+
+// SetPriority ...
+func (w *EspionageWatcher) SetPriority(priority taskRunner.Priority) *EspionageWatcher {
+	w.priority = priority
+	return w
+}
+
+// SetInterval set the time between two polls of the espionage messages
+func (w *EspionageWatcher) SetInterval(interval time.Duration) *EspionageWatcher {
+	w.interval = interval
+	return w
+}
+
+// OnEspionageAction register a callback called for every new foreign espionage action
+func (w *EspionageWatcher) OnEspionageAction(clb func(ogame.EspionageActionMessage)) *EspionageWatcher {
+	w.actionCallbacks = append(w.actionCallbacks, clb)
+	return w
+}
+
+// OnProbeWave register a callback called once per probed celestial, with the new espionage actions of a poll
+func (w *EspionageWatcher) OnProbeWave(clb func(ProbeWave)) *EspionageWatcher {
+	w.waveCallbacks = append(w.waveCallbacks, clb)
+	return w
+}
+
+// OnError register a callback called when a poll failed
+func (w *EspionageWatcher) OnError(clb func(error)) *EspionageWatcher {
+	w.errorCallbacks = append(w.errorCallbacks, clb)
+	return w
+}
+
+// Start polls the espionage messages at every interval in a goroutine
+func (w *EspionageWatcher) Start() {
+	w.Lock()
+	defer w.Unlock()
+	if w.stopCh != nil {
+		return
+	}
+	w.stopCh = make(chan struct{})
+	go func(stopCh chan struct{}) {
+		for {
+			if err := w.Poll(); err != nil {
+				for _, clb := range w.errorCallbacks {
+					clb(err)
+				}
+			}
+			select {
+			case <-time.After(w.interval):
+			case <-stopCh:
+				return
+			}
+		}
+	}(w.stopCh)
+}
+
+// Stop ...
+func (w *EspionageWatcher) Stop() {
+	w.Lock()
+	defer w.Unlock()
+	if w.stopCh != nil {
+		close(w.stopCh)
+		w.stopCh = nil
+	}
+}
+
+// Poll fetches the espionage messages and calls the callbacks for the new espionage actions
+func (w *EspionageWatcher) Poll() error {
+	msgs, err := w.b.WithPriority(w.priority).GetEspionageActionMessages()
+	if err != nil {
+		return err
+	}
+	newMsgs := w.newMessages(msgs)
+	if len(newMsgs) == 0 {
+		return nil
+	}
+	for _, msg := range newMsgs {
+		for _, clb := range w.actionCallbacks {
+			clb(msg)
+		}
+	}
+	if len(w.waveCallbacks) == 0 {
+		return nil
+	}
+	attacks, err := w.b.WithPriority(w.priority).GetAttacks()
+	if err != nil {
+		return err
+	}
+	for _, wave := range probeWaves(newMsgs, attacks) {
+		for _, clb := range w.waveCallbacks {
+			clb(wave)
+		}
+	}
+	return nil
+}
+
+// newMessages returns the messages received since last poll, oldest first
+func (w *EspionageWatcher) newMessages(msgs []ogame.EspionageActionMessage) []ogame.EspionageActionMessage {
+	w.Lock()
+	defer w.Unlock()
+	out := make([]ogame.EspionageActionMessage, 0)
+	lastMsgID := w.lastMsgID
+	for _, msg := range msgs {
+		if msg.ID > lastMsgID {
+			lastMsgID = msg.ID
+		}
+		if w.initialized && msg.ID > w.lastMsgID {
+			out = append(out, msg)
+		}
+	}
+	w.lastMsgID = lastMsgID
+	w.initialized = true
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// probeWaves groups the espionage actions by probed celestial, and attaches the hostile fleets heading to it
+func probeWaves(msgs []ogame.EspionageActionMessage, attacks []ogame.AttackEvent) []ProbeWave {
+	out := make([]ProbeWave, 0)
+	idx := make(map[ogame.Coordinate]int)
+	for _, msg := range msgs {
+		i, ok := idx[msg.Target]
+		if !ok {
+			i = len(out)
+			idx[msg.Target] = i
+			out = append(out, ProbeWave{Target: msg.Target})
+		}
+		out[i].Messages = append(out[i].Messages, msg)
+	}
+	for _, attack := range attacks {
+		if attack.MissionType == ogame.Spy {
+			continue
+		}
+		if i, ok := idx[attack.Destination]; ok {
+			out[i].Attacks = append(out[i].Attacks, attack)
+		}
+	}
+	return out
+}
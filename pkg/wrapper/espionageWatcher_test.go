@@ -0,0 +1,35 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEspionageWatcher_newMessages(t *testing.T) {
+	w := NewEspionageWatcher(nil)
+	msgs := []ogame.EspionageActionMessage{{ID: 12}, {ID: 10}}
+	assert.Equal(t, 0, len(w.newMessages(msgs)))
+	msgs = []ogame.EspionageActionMessage{{ID: 15}, {ID: 14}, {ID: 12}, {ID: 10}}
+	assert.Equal(t, []ogame.EspionageActionMessage{{ID: 14}, {ID: 15}}, w.newMessages(msgs))
+	assert.Equal(t, 0, len(w.newMessages(msgs)))
+}
+
+func TestProbeWaves(t *testing.T) {
+	planet := ogame.Coordinate{Galaxy: 1, System: 100, Position: 8, Type: ogame.PlanetType}
+	moon := planet.Moon()
+	msgs := []ogame.EspionageActionMessage{{ID: 1, Target: planet}, {ID: 2, Target: moon}, {ID: 3, Target: planet}}
+	attacks := []ogame.AttackEvent{
+		{MissionType: ogame.Spy, Destination: moon},
+		{MissionType: ogame.Attack, Destination: planet},
+	}
+	waves := probeWaves(msgs, attacks)
+	assert.Equal(t, 2, len(waves))
+	assert.Equal(t, planet, waves[0].Target)
+	assert.Equal(t, 2, len(waves[0].Messages))
+	assert.True(t, waves[0].IsAttacked())
+	assert.Equal(t, moon, waves[1].Target)
+	assert.Equal(t, 1, len(waves[1].Messages))
+	assert.False(t, waves[1].IsAttacked())
+}
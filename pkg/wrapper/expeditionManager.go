@@ -0,0 +1,458 @@
+package wrapper
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
+// ExpeditionSystemStats aggregated expeditions outcomes for a system
+type ExpeditionSystemStats struct {
+	Expeditions int64
+	Outcomes    map[ogame.ExpeditionOutcomeType]int64
+	Resources   ogame.Resources
+	Ships       ogame.ShipsInfos
+	DarkMatter  int64
+	Items       int64
+	Losses      ogame.ShipsInfos
+	Delays      int64
+}
+
+func newExpeditionSystemStats() *ExpeditionSystemStats {
+	return &ExpeditionSystemStats{Outcomes: make(map[ogame.ExpeditionOutcomeType]int64)}
+}
+
+type sentExpedition struct {
+	fleetID   ogame.FleetID
+	ships     ogame.ShipsInfos
+	eventTime time.Time // end of the holding time, when the expedition message is created
+}
+
+// expeditionMessageTolerance maximum difference between the message date and the end of the holding time of its fleet
+const expeditionMessageTolerance = 5 * time.Minute
+
+// ExpeditionManager keeps expedition slots busy, rotates the target systems, harvests expedition debris
+// and aggregates the expeditions outcomes per system.
+type ExpeditionManager struct {
+	sync.Mutex
+	b             Wrapper
+	priority      taskRunner.Priority
+	origin        Celestial
+	nbSlots       int64
+	systems       []int64
+	systemIdx     int
+	cargoID       ogame.ID
+	extraShips    ogame.ShipsInfos
+	holdingTime   int64
+	harvestDebris bool
+	interval      time.Duration
+	lastMsgID     int64
+	seeded        bool
+	sent          map[ogame.Coordinate][]sentExpedition
+	harvesting    map[ogame.Coordinate]ogame.FleetID
+	stats         map[ogame.Coordinate]*ExpeditionSystemStats
+	stopCh        chan struct{}
+}
+
+// NewExpeditionManager ...
+func NewExpeditionManager(b Wrapper) *ExpeditionManager {
+	m := new(ExpeditionManager)
+	m.b = b
+	m.priority = taskRunner.Normal
+	m.cargoID = ogame.LargeCargoID
+	m.holdingTime = 1
+	m.interval = 5 * time.Minute
+	m.sent = make(map[ogame.Coordinate][]sentExpedition)
+	m.harvesting = make(map[ogame.Coordinate]ogame.FleetID)
+	m.stats = make(map[ogame.Coordinate]*ExpeditionSystemStats)
+	return m
+}
+
+// SetPriority ...
+func (m *ExpeditionManager) SetPriority(priority taskRunner.Priority) *ExpeditionManager {
+	m.priority = priority
+	return m
+}
+
+// SetOrigin ...
+func (m *ExpeditionManager) SetOrigin(v any) *ExpeditionManager {
+	m.origin = m.b.GetCachedCelestial(v)
+	return m
+}
+
+// SetSlots set the number of expedition slots to keep busy, 0 means all of them
+func (m *ExpeditionManager) SetSlots(nbr int64) *ExpeditionManager {
+	m.nbSlots = nbr
+	return m
+}
+
+// SetSystems set the systems the expeditions rotate on, by default the origin system only
+func (m *ExpeditionManager) SetSystems(systems []int64) *ExpeditionManager {
+	m.systems = systems
+	return m
+}
+
+// SetSystemsAround rotates the expeditions on the systems within radius of the origin system
+func (m *ExpeditionManager) SetSystemsAround(radius int64) *ExpeditionManager {
+	if m.origin == nil {
+		return m
+	}
+	nbSystems := m.b.GetNbSystems()
+	origin := m.origin.GetCoordinate().System
+	m.systems = make([]int64, 0)
+	for i := -radius; i <= radius; i++ {
+		system := origin + i
+		if m.b.IsDonutSystem() {
+			system = (system-1+nbSystems)%nbSystems + 1
+		} else if system < 1 || system > nbSystems {
+			continue
+		}
+		m.systems = append(m.systems, system)
+	}
+	return m
+}
+
+// SetCargo set the ship used to carry what the expedition finds (large cargo by default)
+func (m *ExpeditionManager) SetCargo(shipID ogame.ID) *ExpeditionManager {
+	m.cargoID = shipID
+	return m
+}
+
+// SetExtraShips ships sent in every expedition on top of the cargos (eg: a pathfinder and a probe)
+func (m *ExpeditionManager) SetExtraShips(ships ogame.ShipsInfos) *ExpeditionManager {
+	m.extraShips = ships
+	return m
+}
+
+// SetDuration set expeditions holding time
+func (m *ExpeditionManager) SetDuration(holdingTime int64) *ExpeditionManager {
+	m.holdingTime = holdingTime
+	return m
+}
+
+// SetHarvestDebris send pathfinders to the expedition debris (position 16) of the rotated systems
+func (m *ExpeditionManager) SetHarvestDebris(harvest bool) *ExpeditionManager {
+	m.harvestDebris = harvest
+	return m
+}
+
+// SetInterval set the time between two rounds
+func (m *ExpeditionManager) SetInterval(interval time.Duration) *ExpeditionManager {
+	m.interval = interval
+	return m
+}
+
+// Stats returns a copy of the aggregated outcomes per system
+func (m *ExpeditionManager) Stats() map[ogame.Coordinate]ExpeditionSystemStats {
+	m.Lock()
+	defer m.Unlock()
+	out := make(map[ogame.Coordinate]ExpeditionSystemStats, len(m.stats))
+	for coord, s := range m.stats {
+		cpy := *s
+		cpy.Outcomes = make(map[ogame.ExpeditionOutcomeType]int64, len(s.Outcomes))
+		for k, v := range s.Outcomes {
+			cpy.Outcomes[k] = v
+		}
+		out[coord] = cpy
+	}
+	return out
+}
+
+// Start runs a round at every interval in a goroutine.
+// The messages already in the inbox when the first round runs are not counted in the stats.
+func (m *ExpeditionManager) Start() {
+	m.Lock()
+	defer m.Unlock()
+	if m.stopCh != nil {
+		return
+	}
+	m.stopCh = make(chan struct{})
+	go func(stopCh chan struct{}) {
+		for {
+			_ = m.Round()
+			select {
+			case <-time.After(m.interval):
+			case <-stopCh:
+				return
+			}
+		}
+	}(m.stopCh)
+}
+
+// Stop ...
+func (m *ExpeditionManager) Stop() {
+	m.Lock()
+	defer m.Unlock()
+	if m.stopCh != nil {
+		close(m.stopCh)
+		m.stopCh = nil
+	}
+}
+
+// Round collects the new expedition messages, harvests debris and fills the free expedition slots.
+// The galaxy scan of the harvest runs outside of any transaction, the bot is only locked around the sends.
+func (m *ExpeditionManager) Round() error {
+	if m.origin == nil {
+		return errors.New("invalid origin")
+	}
+	if err := m.b.WithPriority(m.priority).Tx(m.collect); err != nil {
+		return err
+	}
+	if m.harvestDebris {
+		if err := m.harvest(); err != nil {
+			return err
+		}
+	}
+	return m.b.WithPriority(m.priority).Tx(m.fillSlots)
+}
+
+func (m *ExpeditionManager) collect(tx Prioritizable) error {
+	msgs, err := tx.GetExpeditionMessages()
+	if err != nil {
+		return err
+	}
+	fleets, _ := tx.GetFleets()
+	inFlight := make(map[ogame.FleetID]ogame.Fleet, len(fleets))
+	for _, fleet := range fleets {
+		inFlight[fleet.ID] = fleet
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ID < msgs[j].ID })
+	m.Lock()
+	defer m.Unlock()
+	lastMsgID := m.lastMsgID
+	for _, msg := range msgs {
+		if msg.ID <= m.lastMsgID {
+			continue
+		}
+		if msg.ID > lastMsgID {
+			lastMsgID = msg.ID
+		}
+		// The first round only remembers where the inbox stands
+		if m.seeded {
+			m.record(msg, inFlight)
+		}
+	}
+	m.lastMsgID = lastMsgID
+	m.seeded = true
+	return nil
+}
+
+// record adds the message outcome to the stats of its system.
+// The message is matched with the fleet sent to the system whose holding time ended the closest to the message date.
+// A black hole destroys the whole fleet, after a fight the losses are the ships missing from the returning fleet.
+func (m *ExpeditionManager) record(msg ogame.ExpeditionMessage, inFlight map[ogame.FleetID]ogame.Fleet) {
+	coord := msg.Coordinate
+	outcome := msg.Outcome
+	coord.Position = 16
+	stats, ok := m.stats[coord]
+	if !ok {
+		stats = newExpeditionSystemStats()
+		m.stats[coord] = stats
+	}
+	stats.Expeditions++
+	stats.Outcomes[outcome.Type]++
+	stats.Resources = stats.Resources.Add(outcome.Resources)
+	stats.Ships.Add(outcome.Ships)
+	stats.DarkMatter += outcome.DarkMatter
+	switch outcome.Type {
+	case ogame.ItemFound:
+		stats.Items++
+	case ogame.LateReturn:
+		stats.Delays++
+	}
+
+	sent := m.sent[coord]
+	idx := -1
+	best := expeditionMessageTolerance
+	for i, exp := range sent {
+		diff := exp.eventTime.Sub(msg.CreatedAt)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= best {
+			idx, best = i, diff
+		}
+	}
+	if idx == -1 {
+		return
+	}
+	exp := sent[idx]
+	m.sent[coord] = append(sent[:idx:idx], sent[idx+1:]...)
+	switch outcome.Type {
+	case ogame.BlackHole:
+		stats.Losses.Add(exp.ships)
+	case ogame.PiratesAttack, ogame.AliensAttack:
+		fleet, ok := inFlight[exp.fleetID]
+		if !ok {
+			return
+		}
+		for _, ship := range ogame.Ships {
+			id := ship.GetID()
+			if lost := exp.ships.ByID(id) - fleet.Ships.ByID(id); lost > 0 {
+				stats.Losses.Set(id, stats.Losses.ByID(id)+lost)
+			}
+		}
+	}
+}
+
+// harvest scans the rotated systems, then sends pathfinders to the expedition debris
+// that no harvest fleet is already flying to, as long as pathfinders are left on the origin.
+func (m *ExpeditionManager) harvest() error {
+	origin := m.origin.GetCoordinate()
+	needed := make(map[ogame.Coordinate]int64)
+	for _, system := range m.rotation() {
+		infos, err := m.b.WithPriority(m.priority).GalaxyInfos(origin.Galaxy, system)
+		if err != nil || infos.ExpeditionDebris.PathfindersNeeded <= 0 {
+			continue
+		}
+		dest := ogame.Coordinate{Galaxy: origin.Galaxy, System: system, Position: 16, Type: ogame.DebrisType}
+		needed[dest] = infos.ExpeditionDebris.PathfindersNeeded
+	}
+	if len(needed) == 0 {
+		return nil
+	}
+	return m.b.WithPriority(m.priority).Tx(func(tx Prioritizable) error {
+		fleets, slots := tx.GetFleets()
+		outbound := make(map[ogame.FleetID]bool, len(fleets))
+		for _, fleet := range fleets {
+			if !fleet.ReturnFlight {
+				outbound[fleet.ID] = true
+			}
+		}
+		m.Lock()
+		for dest, fleetID := range m.harvesting {
+			if !outbound[fleetID] {
+				delete(m.harvesting, dest)
+			}
+		}
+		m.Unlock()
+
+		available, err := tx.GetShips(m.origin.GetID())
+		if err != nil {
+			return err
+		}
+		pathfinders := available.Pathfinder
+		for _, system := range m.rotation() {
+			dest := ogame.Coordinate{Galaxy: origin.Galaxy, System: system, Position: 16, Type: ogame.DebrisType}
+			nbr := utils.MinInt(needed[dest], pathfinders)
+			if nbr <= 0 {
+				continue
+			}
+			m.Lock()
+			_, busy := m.harvesting[dest]
+			m.Unlock()
+			if busy {
+				continue
+			}
+			if slots.InUse >= slots.Total {
+				return nil
+			}
+			var ships ogame.ShipsInfos
+			ships.Set(ogame.PathfinderID, nbr)
+			fleet, err := NewFleetBuilder(m.b).
+				SetTx(tx).
+				SetOrigin(m.origin).
+				SetDestination(dest).
+				SetMission(ogame.RecycleDebrisField).
+				SetShips(ships).
+				SendNow()
+			if err != nil {
+				continue
+			}
+			m.Lock()
+			m.harvesting[dest] = fleet.ID
+			m.Unlock()
+			pathfinders -= nbr
+			slots.InUse++
+		}
+		return nil
+	})
+}
+
+func (m *ExpeditionManager) fillSlots(tx Prioritizable) error {
+	slots := tx.GetSlots()
+	expTotal := slots.ExpTotal
+	if m.nbSlots > 0 && m.nbSlots < expTotal {
+		expTotal = m.nbSlots
+	}
+	for i := slots.ExpInUse; i < expTotal && slots.InUse < slots.Total; i++ {
+		ships, err := m.composeFleet(tx)
+		if err != nil {
+			return err
+		}
+		dest := m.nextDestination()
+		fleet, err := NewFleetBuilder(m.b).
+			SetTx(tx).
+			SetOrigin(m.origin).
+			SetDestination(dest).
+			SetMission(ogame.Expedition).
+			SetDuration(m.holdingTime).
+			SetShips(ships).
+			SendNow()
+		if err != nil {
+			return err
+		}
+		eventTime := fleet.ArrivalTime.Add(time.Duration(m.holdingTime) * time.Hour)
+		m.Lock()
+		m.sent[dest] = append(m.sent[dest], sentExpedition{fleetID: fleet.ID, ships: ships, eventTime: eventTime})
+		m.Unlock()
+		slots.InUse++
+	}
+	return nil
+}
+
+// composeFleet returns the extra ships plus enough cargos to carry the maximum find of an expedition
+func (m *ExpeditionManager) composeFleet(tx Prioritizable) (ogame.ShipsInfos, error) {
+	highscore, err := tx.Highscore(1, 0, 1)
+	if err != nil {
+		return ogame.ShipsInfos{}, err
+	}
+	var top1Points int64
+	if len(highscore.Players) > 0 {
+		top1Points = highscore.Players[0].Score
+	}
+	lfBonuses := tx.GetCachedLfBonuses(m.origin.GetCoordinate())
+	maxFind := lfBonuses.ExpeditionMaxFind(top1Points, m.b.GetUniverseSpeed(), m.b.CharacterClass() == ogame.Discoverer)
+
+	ships := m.extraShips
+	cargo, ok := ogame.Objs.ByID(m.cargoID).(ogame.Ship)
+	if !ok {
+		return ships, nil
+	}
+	researches := tx.GetCachedResearch()
+	probeRaids := m.b.GetServer().Settings.EspionageProbeRaids == 1
+	isCollector := m.b.CharacterClass() == ogame.Collector
+	capacity := maxFind - lfBonuses.FleetCargo(ships, researches, probeRaids, isCollector, m.b.IsPioneers())
+	unitCargo := lfBonuses.CargoCapacity(cargo, researches, probeRaids, isCollector, m.b.IsPioneers())
+	nbr := shipsForCapacity(capacity, unitCargo)
+
+	available, err := tx.GetShips(m.origin.GetID())
+	if err != nil {
+		return ogame.ShipsInfos{}, err
+	}
+	nbr = utils.MinInt(nbr, available.ByID(m.cargoID)-ships.ByID(m.cargoID))
+	ships.Set(m.cargoID, ships.ByID(m.cargoID)+utils.MaxInt(nbr, 0))
+	return ships, nil
+}
+
+func (m *ExpeditionManager) rotation() []int64 {
+	if len(m.systems) == 0 {
+		return []int64{m.origin.GetCoordinate().System}
+	}
+	return m.systems
+}
+
+func (m *ExpeditionManager) nextDestination() ogame.Coordinate {
+	m.Lock()
+	defer m.Unlock()
+	systems := m.rotation()
+	system := systems[m.systemIdx%len(systems)]
+	m.systemIdx++
+	origin := m.origin.GetCoordinate()
+	return ogame.Coordinate{Galaxy: origin.Galaxy, System: system, Position: 16, Type: ogame.PlanetType}
+}
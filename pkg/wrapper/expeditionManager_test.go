@@ -0,0 +1,34 @@
+package wrapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpeditionManager_record(t *testing.T) {
+	m := NewExpeditionManager(nil)
+	coord := ogame.Coordinate{Galaxy: 1, System: 2, Position: 16, Type: ogame.PlanetType}
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.sent[coord] = []sentExpedition{
+		{fleetID: 1, ships: ogame.ShipsInfos{LargeCargo: 10}, eventTime: now},
+		{fleetID: 2, ships: ogame.ShipsInfos{LargeCargo: 20}, eventTime: now.Add(time.Hour)},
+		{fleetID: 3, ships: ogame.ShipsInfos{LargeCargo: 30, Pathfinder: 1}, eventTime: now.Add(2 * time.Hour)},
+	}
+	inFlight := map[ogame.FleetID]ogame.Fleet{3: {ID: 3, ReturnFlight: true, Ships: ogame.ShipsInfos{LargeCargo: 25, Pathfinder: 1}}}
+	// Matched with the second fleet by date, not with the oldest one
+	m.record(ogame.ExpeditionMessage{Coordinate: coord, CreatedAt: now.Add(time.Hour + time.Second), Outcome: ogame.ExpeditionOutcome{Type: ogame.BlackHole}}, inFlight)
+	m.record(ogame.ExpeditionMessage{Coordinate: coord, CreatedAt: now, Outcome: ogame.ExpeditionOutcome{Type: ogame.ResourcesFound, Resources: ogame.Resources{Metal: 10}}}, inFlight)
+	m.record(ogame.ExpeditionMessage{Coordinate: coord, CreatedAt: now.Add(2 * time.Hour), Outcome: ogame.ExpeditionOutcome{Type: ogame.PiratesAttack}}, inFlight)
+	// No fleet left to match, still counted
+	m.record(ogame.ExpeditionMessage{Coordinate: coord, CreatedAt: now.Add(3 * time.Hour), Outcome: ogame.ExpeditionOutcome{Type: ogame.LateReturn}}, inFlight)
+	stats := m.Stats()[coord]
+	assert.Equal(t, int64(4), stats.Expeditions)
+	assert.Equal(t, int64(10), stats.Resources.Metal)
+	assert.Equal(t, ogame.ShipsInfos{LargeCargo: 25}, stats.Losses)
+	assert.Equal(t, int64(1), stats.Delays)
+	assert.Equal(t, int64(1), stats.Outcomes[ogame.BlackHole])
+	assert.Empty(t, m.sent[coord])
+}
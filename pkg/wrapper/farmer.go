@@ -0,0 +1,372 @@
+package wrapper
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/simulator"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+	"github.com/alaingilbert/ogame/pkg/utils"
+)
+
+// ErrTargetDefended returned when the espionage report shows a target that cannot be safely attacked
+var ErrTargetDefended = errors.New("target is defended")
+
+// ErrLootTooLow returned when the loot of a target is below the configured minimum
+var ErrLootTooLow = errors.New("loot too low")
+
+// ErrEspionageReportTimeout returned when the espionage report did not arrive in time
+var ErrEspionageReportTimeout = errors.New("espionage report timeout")
+
+// FarmResult outcome of farming a single target
+type FarmResult struct {
+	Target       ogame.Coordinate
+	Report       ogame.EspionageReport
+	Loot         ogame.Resources
+	AttackFleet  ogame.Fleet
+	RecycleFleet ogame.Fleet
+	Err          error
+}
+
+// Farmer probes a list of targets, attacks the ones that are worth it and optionally recycles the debris.
+// Every call to the server goes through the task runner using the configured priority.
+type Farmer struct {
+	b               Wrapper
+	priority        taskRunner.Priority
+	origin          Celestial
+	targets         []ogame.Coordinate
+	nbProbes        int64
+	maxProbes       int64
+	cargoID         ogame.ID
+	escort          ogame.ShipsInfos
+	speed           ogame.Speed
+	slotsBudget     int64
+	minLoot         int64
+	recycle         bool
+	minDebris       int64
+	simulations     int
+	minWinRate      int
+	reportTimeout   time.Duration
+	resultCallbacks []func(FarmResult)
+}
+
+// NewFarmer ...
+func NewFarmer(b Wrapper) *Farmer {
+	f := new(Farmer)
+	f.b = b
+	f.priority = taskRunner.Normal
+	f.nbProbes = 3
+	f.maxProbes = 12
+	f.cargoID = ogame.SmallCargoID
+	f.speed = ogame.HundredPercent
+	f.simulations = 10
+	f.minWinRate = 100
+	f.reportTimeout = 2 * time.Minute
+	return f
+}
+
+// SetPriority set the task runner priority used for every action of the farmer
+func (f *Farmer) SetPriority(priority taskRunner.Priority) *Farmer {
+	f.priority = priority
+	return f
+}
+
+// SetOrigin ...
+func (f *Farmer) SetOrigin(v any) *Farmer {
+	f.origin = f.b.GetCachedCelestial(v)
+	return f
+}
+
+// SetTargets ...
+func (f *Farmer) SetTargets(targets []ogame.Coordinate) *Farmer {
+	f.targets = targets
+	return f
+}
+
+// AddTarget ...
+func (f *Farmer) AddTarget(target ogame.Coordinate) *Farmer {
+	f.targets = append(f.targets, target)
+	return f
+}
+
+// SetProbes set the number of probes sent to each target (3 by default, enough to see the defenses
+// when both espionage technologies are equal)
+func (f *Farmer) SetProbes(nbr int64) *Farmer {
+	f.nbProbes = nbr
+	return f
+}
+
+// SetMaxProbes set the most probes sent to a target, when a report lacks the fleet or the defenses
+// the target is spied again with twice the probes until max is reached (12 by default)
+func (f *Farmer) SetMaxProbes(max int64) *Farmer {
+	f.maxProbes = max
+	return f
+}
+
+// SetCargo set the ship used to carry the loot (small cargo by default)
+func (f *Farmer) SetCargo(shipID ogame.ID) *Farmer {
+	f.cargoID = shipID
+	return f
+}
+
+// SetEscort set ships that are sent along with the cargos
+func (f *Farmer) SetEscort(ships ogame.ShipsInfos) *Farmer {
+	f.escort = ships
+	return f
+}
+
+// SetSpeed ...
+func (f *Farmer) SetSpeed(speed ogame.Speed) *Farmer {
+	f.speed = speed
+	return f
+}
+
+// SetSlotsBudget set the maximum number of fleet slots in use the farmer is allowed to reach.
+// 0 means all the slots can be used.
+func (f *Farmer) SetSlotsBudget(slots int64) *Farmer {
+	f.slotsBudget = slots
+	return f
+}
+
+// SetMinimumLoot targets with a lower loot are not attacked
+func (f *Farmer) SetMinimumLoot(minLoot int64) *Farmer {
+	f.minLoot = minLoot
+	return f
+}
+
+// SetRecycle send recyclers when the simulated debris field is at least minDebris
+func (f *Farmer) SetRecycle(recycle bool, minDebris int64) *Farmer {
+	f.recycle = recycle
+	f.minDebris = minDebris
+	return f
+}
+
+// SetSimulations set the number of combat simulations and the minimum attacker win rate (percentage)
+// required to attack a target that is not defenceless.
+func (f *Farmer) SetSimulations(simulations, minWinRate int) *Farmer {
+	f.simulations = simulations
+	f.minWinRate = minWinRate
+	return f
+}
+
+// SetReportTimeout set how long to wait for the espionage report once the probes arrived
+func (f *Farmer) SetReportTimeout(timeout time.Duration) *Farmer {
+	f.reportTimeout = timeout
+	return f
+}
+
+// OnResult register a callback called after each target is processed
+func (f *Farmer) OnResult(clb func(FarmResult)) *Farmer {
+	f.resultCallbacks = append(f.resultCallbacks, clb)
+	return f
+}
+
+// Run farm all the targets, one after the other
+func (f *Farmer) Run() []FarmResult {
+	results := make([]FarmResult, 0, len(f.targets))
+	for _, target := range f.targets {
+		res := f.farm(target)
+		for _, clb := range f.resultCallbacks {
+			clb(res)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func (f *Farmer) farm(target ogame.Coordinate) (res FarmResult) {
+	res.Target = target
+	if f.origin == nil {
+		res.Err = errors.New("invalid origin")
+		return
+	}
+	report, err := f.spy(target, f.nbProbes)
+	if err != nil {
+		res.Err = err
+		return
+	}
+	res.Report = report
+
+	res.Loot = report.Loot(f.b.CharacterClass())
+	if res.Loot.Total() < f.minLoot {
+		res.Err = ErrLootTooLow
+		return
+	}
+
+	for probes, ok := moreProbes(report, f.nbProbes, f.maxProbes); ok; probes, ok = moreProbes(report, probes, f.maxProbes) {
+		if report, err = f.spy(target, probes); err != nil {
+			res.Err = err
+			return
+		}
+		res.Report = report
+		res.Loot = report.Loot(f.b.CharacterClass())
+	}
+
+	researches := f.b.GetCachedResearch()
+	lfBonuses := f.b.GetCachedLfBonuses(f.origin.GetCoordinate())
+	ships := f.attackShips(researches, lfBonuses, res.Loot)
+	simRes, ok := f.canWin(researches, ships, report)
+	if !ok {
+		res.Err = ErrTargetDefended
+		return
+	}
+
+	if err := f.waitForSlot(); err != nil {
+		res.Err = err
+		return
+	}
+	res.AttackFleet, res.Err = f.send(NewFleetBuilder(f.b).
+		SetOrigin(f.origin).
+		SetDestination(target).
+		SetSpeed(f.speed).
+		SetMission(ogame.Attack).
+		SetShips(ships))
+	if res.Err != nil {
+		return
+	}
+
+	debris := int64(simRes.Debris.Metal + simRes.Debris.Crystal + simRes.Debris.Deuterium)
+	if f.recycle && debris > 0 && debris >= f.minDebris {
+		recyclerCargo := ogame.Recycler.GetCargoCapacity(researches, false, f.b.CharacterClass() == ogame.Collector, f.b.IsPioneers())
+		var recyclers ogame.ShipsInfos
+		recyclers.Set(ogame.RecyclerID, shipsForCapacity(debris, recyclerCargo))
+		if err := f.waitForSlot(); err != nil {
+			res.Err = err
+			return
+		}
+		res.RecycleFleet, res.Err = f.send(NewFleetBuilder(f.b).
+			SetOrigin(f.origin).
+			SetDestination(target.Debris()).
+			SetSpeed(f.speed).
+			SetMission(ogame.RecycleDebrisField).
+			SetShips(recyclers))
+	}
+	return
+}
+
+// send dispatches the fleet in its own transaction
+func (f *Farmer) send(fb *FleetBuilder) (fleet ogame.Fleet, err error) {
+	err = f.b.WithPriority(f.priority).Tx(func(tx Prioritizable) error {
+		fleet, err = fb.SetTx(tx).SendNow()
+		return err
+	})
+	return
+}
+
+// spy sends the probes and waits for the espionage report
+func (f *Farmer) spy(target ogame.Coordinate, nbProbes int64) (ogame.EspionageReport, error) {
+	if err := f.waitForSlot(); err != nil {
+		return ogame.EspionageReport{}, err
+	}
+	probes := []ogame.Quantifiable{{ID: ogame.EspionageProbeID, Nbr: nbProbes}}
+	fleet, err := f.b.WithPriority(f.priority).SendFleet(f.origin.GetID(), probes, ogame.HundredPercent, target, ogame.Spy, ogame.Resources{}, 0, 0)
+	if err != nil {
+		return ogame.EspionageReport{}, err
+	}
+	time.Sleep(time.Duration(fleet.ArriveIn+1) * time.Second)
+	deadline := time.Now().Add(f.reportTimeout)
+	for {
+		report, err := f.b.WithPriority(f.priority).GetEspionageReportFor(target)
+		if err == nil && !report.Date.Before(fleet.ArrivalTime.Add(-time.Minute)) {
+			return report, nil
+		}
+		if time.Now().After(deadline) {
+			return ogame.EspionageReport{}, ErrEspionageReportTimeout
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// attackShips returns the escort plus enough cargos to carry the loot
+func (f *Farmer) attackShips(researches ogame.Researches, lfBonuses ogame.LfBonuses, loot ogame.Resources) ogame.ShipsInfos {
+	ships := f.escort
+	cargo, ok := ogame.Objs.ByID(f.cargoID).(ogame.Ship)
+	if !ok {
+		return ships
+	}
+	probeRaids := f.b.GetServer().Settings.EspionageProbeRaids == 1
+	isCollector := f.b.CharacterClass() == ogame.Collector
+	capacity := loot.Total() - lfBonuses.FleetCargo(ships, researches, probeRaids, isCollector, f.b.IsPioneers())
+	unitCargo := lfBonuses.CargoCapacity(cargo, researches, probeRaids, isCollector, f.b.IsPioneers())
+	ships.Set(f.cargoID, ships.ByID(f.cargoID)+shipsForCapacity(capacity, unitCargo))
+	return ships
+}
+
+// canWin simulates the fight, a defenceless target is always won
+func (f *Farmer) canWin(researches ogame.Researches, ships ogame.ShipsInfos, report ogame.EspionageReport) (simulator.SimulatorResult, bool) {
+	if !report.HasFleetInformation || !report.HasDefensesInformation {
+		return simulator.SimulatorResult{}, false
+	}
+	defender := simulator.Defender{
+		Metal:         int(report.Metal),
+		Crystal:       int(report.Crystal),
+		Deuterium:     int(report.Deuterium),
+		ShipsInfos:    *report.ShipsInfos(),
+		DefensesInfos: *report.DefensesInfos(),
+	}
+	if r := report.Researches(); r != nil {
+		defender.Weapon = int(r.WeaponsTechnology)
+		defender.Shield = int(r.ShieldingTechnology)
+		defender.Armour = int(r.ArmourTechnology)
+	}
+	attacker := simulator.Attacker{
+		Weapon:     int(researches.WeaponsTechnology),
+		Shield:     int(researches.ShieldingTechnology),
+		Armour:     int(researches.ArmourTechnology),
+		ShipsInfos: ships,
+	}
+	simRes := simulator.Simulate(attacker, defender, simulator.SimulatorParams{Simulations: f.simulations})
+	if report.IsDefenceless() {
+		return simRes, true
+	}
+	return simRes, simRes.AttackerWin*100 >= f.minWinRate*simRes.Simulations
+}
+
+// waitForSlot blocks until the number of slots in use is below the budget, the bot is not locked while waiting
+func (f *Farmer) waitForSlot() error {
+	for {
+		slots := f.b.WithPriority(f.priority).GetSlots()
+		budget := slots.Total
+		if f.slotsBudget > 0 && f.slotsBudget < budget {
+			budget = f.slotsBudget
+		}
+		if budget <= 0 {
+			return ogame.ErrAllSlotsInUse
+		}
+		if slots.InUse < budget {
+			return nil
+		}
+		fleets, _ := f.b.WithPriority(f.priority).GetFleets()
+		time.Sleep(time.Duration(nextFleetBackIn(fleets)+1) * time.Second)
+	}
+}
+
+// moreProbes returns the number of probes to spy a target again with, when the report lacks the fleet or the defenses.
+// The probes are doubled up to max, returns false once max is reached or the report is complete enough.
+func moreProbes(report ogame.EspionageReport, probes, max int64) (int64, bool) {
+	if (report.HasFleetInformation && report.HasDefensesInformation) || probes >= max {
+		return probes, false
+	}
+	return utils.MinInt(utils.MaxInt(probes*2, 1), max), true
+}
+
+// nextFleetBackIn returns the number of seconds until the first fleet frees its slot
+func nextFleetBackIn(fleets []ogame.Fleet) int64 {
+	var secs int64 = 60
+	for _, fleet := range fleets {
+		if fleet.BackIn > 0 && fleet.BackIn < secs {
+			secs = fleet.BackIn
+		}
+	}
+	return secs
+}
+
+// shipsForCapacity returns the number of ships needed to carry capacity
+func shipsForCapacity(capacity, unitCargo int64) int64 {
+	if capacity <= 0 || unitCargo <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(float64(capacity) / float64(unitCargo)))
+}
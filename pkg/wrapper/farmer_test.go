@@ -0,0 +1,39 @@
+package wrapper
+
+import (
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipsForCapacity(t *testing.T) {
+	assert.Equal(t, int64(0), shipsForCapacity(0, 5000))
+	assert.Equal(t, int64(0), shipsForCapacity(-10, 5000))
+	assert.Equal(t, int64(0), shipsForCapacity(10, 0))
+	assert.Equal(t, int64(1), shipsForCapacity(5000, 5000))
+	assert.Equal(t, int64(2), shipsForCapacity(5001, 5000))
+}
+
+func TestMoreProbes(t *testing.T) {
+	partial := ogame.EspionageReport{HasFleetInformation: true}
+	probes, ok := moreProbes(partial, 3, 12)
+	assert.True(t, ok)
+	assert.Equal(t, int64(6), probes)
+	probes, ok = moreProbes(partial, 6, 12)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), probes)
+	_, ok = moreProbes(partial, 12, 12)
+	assert.False(t, ok)
+	probes, ok = moreProbes(partial, 0, 12)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), probes)
+
+	_, ok = moreProbes(ogame.EspionageReport{HasFleetInformation: true, HasDefensesInformation: true}, 3, 12)
+	assert.False(t, ok)
+}
+
+func TestNextFleetBackIn(t *testing.T) {
+	assert.Equal(t, int64(60), nextFleetBackIn(nil))
+	assert.Equal(t, int64(12), nextFleetBackIn([]ogame.Fleet{{BackIn: 30}, {BackIn: 12}, {BackIn: 0}}))
+}
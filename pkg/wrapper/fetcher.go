@@ -14,6 +14,7 @@ const (
 	DefensesPageName         = "defenses"
 	LfBuildingsPageName      = "lfbuildings"
 	LfResearchPageName       = "lfresearch"
+	LfSettingsPageName       = "lfsettings"
 	SuppliesPageName         = "supplies"
 	FacilitiesPageName       = "facilities"
 	FleetdispatchPageName    = "fleetdispatch"
@@ -34,6 +35,8 @@ const (
 	MessagesPageName         = "messages"
 	ChatPageName             = "chat"
 
+	CharacterClassSelectionPageName = "characterclassselection"
+
 	FetchTechsName         = "fetchTechs"
 	FetchResourcesPageName = "fetchResources"
 
@@ -88,6 +91,8 @@ func getPage[T parser.FullPagePages](b *OGame, opts ...Option) (T, error) {
 		pageName = LfBuildingsPageName
 	case parser.LfResearchPage:
 		pageName = LfResearchPageName
+	case parser.LfSettingsPage:
+		pageName = LfSettingsPageName
 	case parser.ShipyardPage:
 		pageName = ShipyardPageName
 	case parser.ResourcesSettingsPage:
@@ -98,6 +103,8 @@ func getPage[T parser.FullPagePages](b *OGame, opts ...Option) (T, error) {
 		pageName = MovementPageName
 	case parser.PreferencesPage:
 		pageName = PreferencesPageName
+	case parser.CharacterClassSelectionPage:
+		pageName = CharacterClassSelectionPageName
 	default:
 		panic("not implemented")
 	}
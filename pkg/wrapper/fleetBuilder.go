@@ -3,6 +3,7 @@ package wrapper
 import (
 	"errors"
 	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
 	"github.com/alaingilbert/ogame/pkg/utils"
 	"time"
 )
@@ -39,6 +40,8 @@ type FleetBuilder struct {
 	unionID          int64
 	allShips         bool
 	recallIn         int64
+	arriveAt         time.Time
+	returnAt         time.Time
 	successCallbacks []func(ogame.Fleet)
 	errorCallbacks   []func(error)
 }
@@ -192,6 +195,20 @@ func (f *FleetBuilder) SetRecallIn(secs int64) *FleetBuilder {
 	return f
 }
 
+// SetArriveAt set the time at which the fleet must reach its destination, used by SendTimed
+func (f *FleetBuilder) SetArriveAt(t time.Time) *FleetBuilder {
+	f.arriveAt = t
+	f.returnAt = time.Time{}
+	return f
+}
+
+// SetReturnAt set the time at which the fleet must be back to its origin, used by SendTimed
+func (f *FleetBuilder) SetReturnAt(t time.Time) *FleetBuilder {
+	f.returnAt = t
+	f.arriveAt = time.Time{}
+	return f
+}
+
 // FlightTime ...
 func (f *FleetBuilder) FlightTime() (secs, fuel int64) {
 	ships := f.ships
@@ -238,7 +255,8 @@ func (f *FleetBuilder) sendNow(tx Prioritizable) error {
 	if f.resources.Metal == -1 || f.resources.Crystal == -1 || f.resources.Deuterium == -1 {
 		// Calculate cargo
 		techs := tx.GetResearch()
-		cargoCapacity := f.ships.Cargo(techs, f.b.GetServer().Settings.EspionageProbeRaids == 1, f.b.CharacterClass() == ogame.Collector, f.b.IsPioneers())
+		lfBonuses := tx.GetCachedLfBonuses(f.origin.GetCoordinate())
+		cargoCapacity := lfBonuses.FleetCargo(f.ships, techs, f.b.GetServer().Settings.EspionageProbeRaids == 1, f.b.CharacterClass() == ogame.Collector, f.b.IsPioneers())
 		if f.minimumDeuterium <= 0 {
 			planetResources, _ = tx.GetResources(f.origin.GetID())
 		}
@@ -304,3 +322,99 @@ func (f *FleetBuilder) OnSuccess(clb func(ogame.Fleet)) *FleetBuilder {
 	f.successCallbacks = append(f.successCallbacks, clb)
 	return f
 }
+
+// TimedDispatch result of a timed fleet dispatch
+type TimedDispatch struct {
+	Fleet         ogame.Fleet
+	Speed         ogame.Speed
+	DepartureTime time.Time
+	TargetTime    time.Time
+	AchievedTime  time.Time
+	Offset        time.Duration // AchievedTime - TargetTime
+}
+
+// SendTimed picks the speed that gets the closest to the arrival (or return) time,
+// waits for the departure second and sends the fleet.
+// Unless a Tx is set, the dispatch is done in a critical priority transaction, the bot is not locked while waiting.
+func (f *FleetBuilder) SendTimed() (TimedDispatch, error) {
+	var res TimedDispatch
+	if f.origin == nil {
+		f.err = errors.New("invalid origin")
+		return res, f.err
+	}
+	isReturn := !f.returnAt.IsZero()
+	res.TargetTime = f.arriveAt
+	if isReturn {
+		res.TargetTime = f.returnAt
+	}
+	if res.TargetTime.IsZero() {
+		f.err = errors.New("arrival or return time not set")
+		return res, f.err
+	}
+	// Without a Tx, each call gets its own handle and the bot is only locked for the dispatch
+	call := func() Prioritizable {
+		if f.tx != nil {
+			return f.tx
+		}
+		return f.b.WithPriority(taskRunner.Critical)
+	}
+	if f.allShips {
+		f.ships, _ = call().GetShips(f.origin.GetID())
+		f.allShips = false
+	}
+
+	duration := func(speed ogame.Speed) int64 {
+		secs, _ := call().FlightTime(f.origin.GetCoordinate(), f.destination, speed, f.ships, f.mission)
+		if isReturn {
+			return 2*secs + f.holdingTime*3600
+		}
+		return secs
+	}
+	available := int64(time.Until(res.TargetTime).Seconds())
+	speed, secs, ok := closestSpeed(ogame.Speeds(f.b.CharacterClass()), available, duration)
+	if !ok {
+		f.err = errors.New("target time cannot be reached")
+		return res, f.err
+	}
+	res.Speed = speed
+	f.speed = speed
+
+	time.Sleep(time.Until(res.TargetTime.Add(-time.Duration(secs) * time.Second)))
+	res.DepartureTime = time.Now()
+	if f.tx != nil {
+		res.Fleet, f.err = f.SendNow()
+	} else {
+		f.err = f.b.WithPriority(taskRunner.Critical).Tx(func(tx Prioritizable) error {
+			f.tx = tx
+			defer func() { f.tx = nil }()
+			var err error
+			res.Fleet, err = f.SendNow()
+			return err
+		})
+	}
+	if f.err != nil {
+		return res, f.err
+	}
+	res.AchievedTime = res.Fleet.ArrivalTime
+	if isReturn {
+		res.AchievedTime = res.Fleet.BackTime
+	}
+	if res.AchievedTime.IsZero() {
+		res.AchievedTime = res.DepartureTime.Add(time.Duration(secs) * time.Second)
+	}
+	res.Offset = res.AchievedTime.Sub(res.TargetTime)
+	return res, nil
+}
+
+// closestSpeed returns the speed whose duration is the longest that still fits in the available seconds
+func closestSpeed(speeds []ogame.Speed, available int64, duration func(ogame.Speed) int64) (ogame.Speed, int64, bool) {
+	var bestSpeed ogame.Speed
+	var bestSecs int64 = -1
+	for _, speed := range speeds {
+		secs := duration(speed)
+		if secs <= available && secs > bestSecs {
+			bestSpeed, bestSecs = speed, secs
+		}
+	}
+	return bestSpeed, bestSecs, bestSecs != -1
+}
@@ -0,0 +1,24 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestSpeed(t *testing.T) {
+	duration := func(speed ogame.Speed) int64 { return int64(1000 / speed.Float64()) }
+	speed, secs, ok := closestSpeed(ogame.Speeds(ogame.Collector), 300, duration)
+	assert.True(t, ok)
+	assert.Equal(t, ogame.FourtyPercent, speed)
+	assert.Equal(t, int64(250), secs)
+
+	speed, secs, ok = closestSpeed(ogame.Speeds(ogame.General), 300, duration)
+	assert.True(t, ok)
+	assert.Equal(t, ogame.ThirtyFivePercent, speed)
+	assert.Equal(t, int64(285), secs)
+
+	_, _, ok = closestSpeed(ogame.Speeds(ogame.Collector), 50, duration)
+	assert.False(t, ok)
+}
@@ -0,0 +1,349 @@
+package wrapper
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/simulator"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// ErrFleetSaveNoDestination returned when a celestial has no sibling, no other owned celestial and no recycler to save its fleet
+var ErrFleetSaveNoDestination = errors.New("no fleet-save destination")
+
+// FleetSave information about a fleet that was saved from an attack
+type FleetSave struct {
+	Attack ogame.AttackEvent
+	Fleet  ogame.Fleet
+}
+
+// FleetSaveGuard watches the event list and saves the fleet and resources of celestials
+// that would lose against an incoming attack.
+type FleetSaveGuard struct {
+	sync.Mutex
+	b                  Wrapper
+	priority           taskRunner.Priority
+	leadTime           time.Duration
+	recallDelay        time.Duration
+	minInterval        time.Duration
+	maxInterval        time.Duration
+	simulations        int
+	minDefenderWinRate int
+	handled            map[ogame.Coordinate]time.Time
+	stopCh             chan struct{}
+	saveCallbacks      []func(FleetSave)
+	errorCallbacks     []func(ogame.AttackEvent, error)
+}
+
+// NewFleetSaveGuard ...
+func NewFleetSaveGuard(b Wrapper) *FleetSaveGuard {
+	g := new(FleetSaveGuard)
+	g.b = b
+	g.priority = taskRunner.Important
+	g.leadTime = 2 * time.Minute
+	g.recallDelay = 10 * time.Second
+	g.minInterval = 10 * time.Second
+	g.maxInterval = 5 * time.Minute
+	g.simulations = 10
+	g.minDefenderWinRate = 100
+	g.handled = make(map[ogame.Coordinate]time.Time)
+	return g
+}
+
+// SetPriority set the task runner priority used by the guard
+func (g *FleetSaveGuard) SetPriority(priority taskRunner.Priority) *FleetSaveGuard {
+	g.priority = priority
+	return g
+}
+
+// SetLeadTime set how long before the attack arrival the fleet-save is dispatched
+func (g *FleetSaveGuard) SetLeadTime(leadTime time.Duration) *FleetSaveGuard {
+	g.leadTime = leadTime
+	return g
+}
+
+// SetRecallDelay set how long after the attack arrival the saved fleet is recalled
+func (g *FleetSaveGuard) SetRecallDelay(delay time.Duration) *FleetSaveGuard {
+	g.recallDelay = delay
+	return g
+}
+
+// SetInterval set the bounds of the adaptive polling interval
+func (g *FleetSaveGuard) SetInterval(minInterval, maxInterval time.Duration) *FleetSaveGuard {
+	g.minInterval = minInterval
+	g.maxInterval = maxInterval
+	return g
+}
+
+// SetSimulations set the number of combat simulations and the defender win rate (percentage)
+// under which the fleet is saved.
+func (g *FleetSaveGuard) SetSimulations(simulations, minDefenderWinRate int) *FleetSaveGuard {
+	g.simulations = simulations
+	g.minDefenderWinRate = minDefenderWinRate
+	return g
+}
+
+// OnFleetSave register a callback called when a fleet-save is dispatched
+func (g *FleetSaveGuard) OnFleetSave(clb func(FleetSave)) *FleetSaveGuard {
+	g.saveCallbacks = append(g.saveCallbacks, clb)
+	return g
+}
+
+// OnError register a callback called when a fleet-save failed
+func (g *FleetSaveGuard) OnError(clb func(ogame.AttackEvent, error)) *FleetSaveGuard {
+	g.errorCallbacks = append(g.errorCallbacks, clb)
+	return g
+}
+
+// Start the guard in a goroutine
+func (g *FleetSaveGuard) Start() {
+	g.Lock()
+	defer g.Unlock()
+	if g.stopCh != nil {
+		return
+	}
+	g.stopCh = make(chan struct{})
+	go g.run(g.stopCh)
+}
+
+// Stop the guard. Fleets already saved are still recalled.
+func (g *FleetSaveGuard) Stop() {
+	g.Lock()
+	defer g.Unlock()
+	if g.stopCh != nil {
+		close(g.stopCh)
+		g.stopCh = nil
+	}
+}
+
+func (g *FleetSaveGuard) run(stopCh chan struct{}) {
+	for {
+		interval := g.check()
+		select {
+		case <-time.After(interval):
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// check looks at the incoming attacks, saves what needs to be saved and returns the time to wait before next check.
+// An attack is only handled once its save succeeded, a failed save is retried at every check until the attack lands.
+func (g *FleetSaveGuard) check() time.Duration {
+	attacks, err := g.b.WithPriority(g.priority).GetAttacks()
+	if err != nil {
+		return g.minInterval
+	}
+	now := time.Now()
+	interval := g.maxInterval
+	for _, attack := range groupAttacks(attacks) {
+		if g.isHandled(attack) {
+			continue
+		}
+		untilDispatch := attack.ArrivalTime.Add(-g.leadTime).Sub(now)
+		if untilDispatch > g.minInterval {
+			interval = fleetSaveInterval(interval, untilDispatch, g.minInterval)
+			continue
+		}
+		var fleet ogame.Fleet
+		err := g.b.WithPriority(g.priority).Tx(func(tx Prioritizable) (err error) {
+			fleet, err = g.save(tx, attack)
+			return err
+		})
+		if err != nil {
+			for _, clb := range g.errorCallbacks {
+				clb(attack, err)
+			}
+			interval = g.minInterval
+			continue
+		}
+		g.setHandled(attack)
+		if fleet.ID == 0 {
+			continue
+		}
+		g.scheduleRecall(attack, fleet)
+		for _, clb := range g.saveCallbacks {
+			clb(FleetSave{Attack: attack, Fleet: fleet})
+		}
+	}
+	return interval
+}
+
+func (g *FleetSaveGuard) isHandled(attack ogame.AttackEvent) bool {
+	g.Lock()
+	defer g.Unlock()
+	arrival, ok := g.handled[attack.Destination]
+	return ok && !attack.ArrivalTime.After(arrival)
+}
+
+func (g *FleetSaveGuard) setHandled(attack ogame.AttackEvent) {
+	g.Lock()
+	defer g.Unlock()
+	g.handled[attack.Destination] = attack.ArrivalTime
+	for coord, arrival := range g.handled {
+		if time.Since(arrival) > time.Hour {
+			delete(g.handled, coord)
+		}
+	}
+}
+
+// save simulates the attack and dispatch the fleet-save if needed.
+// Returns an empty fleet if the celestial can hold the attack.
+func (g *FleetSaveGuard) save(tx Prioritizable, attack ogame.AttackEvent) (ogame.Fleet, error) {
+	celestial := g.b.GetCachedCelestial(attack.Destination)
+	if celestial == nil {
+		return ogame.Fleet{}, ogame.ErrInvalidPlanetID
+	}
+	ships, err := tx.GetShips(celestial.GetID())
+	if err != nil {
+		return ogame.Fleet{}, err
+	}
+	ships.Set(ogame.SolarSatelliteID, 0)
+	ships.Set(ogame.CrawlerID, 0)
+	if !ships.HasFlyableShips() {
+		return ogame.Fleet{}, nil
+	}
+	if !g.wouldLose(tx, celestial, ships, attack) {
+		return ogame.Fleet{}, nil
+	}
+
+	owned := make([]ogame.Coordinate, 0)
+	for _, c := range g.b.GetCachedCelestials() {
+		owned = append(owned, c.GetCoordinate())
+	}
+	destination, mission, ok := fleetSaveDestination(attack.Destination, owned, ships, g.b.Distance)
+	if !ok {
+		return ogame.Fleet{}, ErrFleetSaveNoDestination
+	}
+	speed := ogame.TenPercent
+	if g.b.CharacterClass() == ogame.General {
+		speed = ogame.FivePercent
+	}
+	return NewFleetBuilder(g.b).
+		SetTx(tx).
+		SetOrigin(celestial).
+		SetDestination(destination).
+		SetSpeed(speed).
+		SetMission(mission).
+		SetShips(ships).
+		SetAllResources().
+		SendNow()
+}
+
+// wouldLose simulates the attack against the celestial ships and defenses.
+// The attacker technologies are unknown, ours are used instead.
+func (g *FleetSaveGuard) wouldLose(tx Prioritizable, celestial Celestial, ships ogame.ShipsInfos, attack ogame.AttackEvent) bool {
+	if attack.MissionType == ogame.MissileAttack {
+		return false
+	}
+	if attack.Ships == nil {
+		return true
+	}
+	defenses, err := tx.GetDefense(celestial.GetID())
+	if err != nil {
+		return true
+	}
+	resources, _ := tx.GetResources(celestial.GetID())
+	researches := tx.GetCachedResearch()
+	attacker := simulator.Attacker{
+		Weapon:     int(researches.WeaponsTechnology),
+		Shield:     int(researches.ShieldingTechnology),
+		Armour:     int(researches.ArmourTechnology),
+		ShipsInfos: *attack.Ships,
+	}
+	defender := simulator.Defender{
+		Metal:         int(resources.Metal),
+		Crystal:       int(resources.Crystal),
+		Deuterium:     int(resources.Deuterium),
+		Weapon:        int(researches.WeaponsTechnology),
+		Shield:        int(researches.ShieldingTechnology),
+		Armour:        int(researches.ArmourTechnology),
+		ShipsInfos:    ships,
+		DefensesInfos: defenses,
+	}
+	res := simulator.Simulate(attacker, defender, simulator.SimulatorParams{Simulations: g.simulations})
+	return res.DefenderWin*100 < g.minDefenderWinRate*res.Simulations
+}
+
+// scheduleRecall recalls the saved fleet once the attack has landed
+func (g *FleetSaveGuard) scheduleRecall(attack ogame.AttackEvent, fleet ogame.Fleet) {
+	recallAt := attack.ArrivalTime.Add(g.recallDelay)
+	go func() {
+		time.Sleep(time.Until(recallAt))
+		_ = g.b.WithPriority(g.priority).CancelFleet(fleet.ID)
+	}()
+}
+
+// groupAttacks merges the attacks arriving on the same celestial at the same time (ACS)
+// and only keeps the first wave for each celestial.
+func groupAttacks(attacks []ogame.AttackEvent) []ogame.AttackEvent {
+	out := make([]ogame.AttackEvent, 0)
+	idx := make(map[ogame.Coordinate]int)
+	for _, attack := range attacks {
+		i, ok := idx[attack.Destination]
+		if !ok {
+			idx[attack.Destination] = len(out)
+			out = append(out, attack)
+			continue
+		}
+		first := &out[i]
+		if attack.ArrivalTime.Before(first.ArrivalTime) {
+			*first = attack
+		} else if attack.ArrivalTime.Equal(first.ArrivalTime) {
+			if first.Ships == nil || attack.Ships == nil {
+				first.Ships = nil
+			} else {
+				ships := *first.Ships
+				ships.Add(*attack.Ships)
+				first.Ships = &ships
+			}
+		}
+	}
+	return out
+}
+
+// fleetSaveDestination finds where to deploy a fleet from origin.
+// Prefers the moon/planet at the same position, then the debris field if the fleet can recycle,
+// then the closest owned celestial. Returns false if there is nowhere to go.
+func fleetSaveDestination(origin ogame.Coordinate, owned []ogame.Coordinate, ships ogame.ShipsInfos,
+	distance func(origin, destination ogame.Coordinate) int64) (ogame.Coordinate, ogame.MissionID, bool) {
+	var sibling ogame.Coordinate
+	if origin.IsMoon() {
+		sibling = origin.Planet()
+	} else {
+		sibling = origin.Moon()
+	}
+	for _, c := range owned {
+		if c.Equal(sibling) {
+			return sibling, ogame.Park, true
+		}
+	}
+	if ships.Recycler > 0 || ships.Pathfinder > 0 {
+		return origin.Debris(), ogame.RecycleDebrisField, true
+	}
+	var closest ogame.Coordinate
+	var closestDistance int64 = -1
+	for _, c := range owned {
+		if c.Equal(origin) {
+			continue
+		}
+		if d := distance(origin, c); closestDistance == -1 || d < closestDistance {
+			closest, closestDistance = c, d
+		}
+	}
+	return closest, ogame.Park, closestDistance != -1
+}
+
+// fleetSaveInterval returns the next polling interval so the guard wakes up in time for the dispatch
+func fleetSaveInterval(interval, untilDispatch, minInterval time.Duration) time.Duration {
+	half := untilDispatch / 2
+	if half < minInterval {
+		half = minInterval
+	}
+	if half < interval {
+		return half
+	}
+	return interval
+}
@@ -0,0 +1,102 @@
+package wrapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+	"github.com/stretchr/testify/assert"
+)
+
+// fleetSaveWrapper serves the attacks checked by the guard and has no celestial, so every save fails
+type fleetSaveWrapper struct {
+	Wrapper
+	attacks []ogame.AttackEvent
+}
+
+func (w *fleetSaveWrapper) WithPriority(taskRunner.Priority) Prioritizable {
+	return &fleetSavePrioritizable{w: w}
+}
+
+func (w *fleetSaveWrapper) GetCachedCelestial(any) Celestial { return nil }
+
+type fleetSavePrioritizable struct {
+	Prioritizable
+	w *fleetSaveWrapper
+}
+
+func (p *fleetSavePrioritizable) GetAttacks(...Option) ([]ogame.AttackEvent, error) {
+	return p.w.attacks, nil
+}
+
+func (p *fleetSavePrioritizable) Tx(clb func(tx Prioritizable) error) error {
+	return clb(p)
+}
+
+func TestFleetSaveDestination(t *testing.T) {
+	distance := func(origin, destination ogame.Coordinate) int64 {
+		if origin.System > destination.System {
+			return origin.System - destination.System
+		}
+		return destination.System - origin.System
+	}
+	planet := ogame.Coordinate{Galaxy: 1, System: 100, Position: 8, Type: ogame.PlanetType}
+	other := ogame.Coordinate{Galaxy: 1, System: 120, Position: 4, Type: ogame.PlanetType}
+	far := ogame.Coordinate{Galaxy: 1, System: 300, Position: 4, Type: ogame.PlanetType}
+
+	dest, mission, ok := fleetSaveDestination(planet, []ogame.Coordinate{planet, planet.Moon(), other}, ogame.ShipsInfos{LargeCargo: 1}, distance)
+	assert.Equal(t, planet.Moon(), dest)
+	assert.Equal(t, ogame.Park, mission)
+	assert.True(t, ok)
+
+	dest, mission, ok = fleetSaveDestination(planet.Moon(), []ogame.Coordinate{planet, planet.Moon()}, ogame.ShipsInfos{LargeCargo: 1}, distance)
+	assert.Equal(t, planet, dest)
+	assert.Equal(t, ogame.Park, mission)
+	assert.True(t, ok)
+
+	dest, mission, ok = fleetSaveDestination(planet, []ogame.Coordinate{planet, other}, ogame.ShipsInfos{Recycler: 1}, distance)
+	assert.Equal(t, planet.Debris(), dest)
+	assert.Equal(t, ogame.RecycleDebrisField, mission)
+	assert.True(t, ok)
+
+	dest, mission, ok = fleetSaveDestination(planet, []ogame.Coordinate{far, planet, other}, ogame.ShipsInfos{LargeCargo: 1}, distance)
+	assert.Equal(t, other, dest)
+	assert.Equal(t, ogame.Park, mission)
+	assert.True(t, ok)
+
+	// Nowhere to go
+	_, _, ok = fleetSaveDestination(planet, []ogame.Coordinate{planet}, ogame.ShipsInfos{LargeCargo: 1}, distance)
+	assert.False(t, ok)
+}
+
+func TestGroupAttacks(t *testing.T) {
+	now := time.Now()
+	coord := ogame.Coordinate{Galaxy: 1, System: 1, Position: 1, Type: ogame.PlanetType}
+	attacks := groupAttacks([]ogame.AttackEvent{
+		{ID: 1, Destination: coord, ArrivalTime: now.Add(time.Hour), Ships: &ogame.ShipsInfos{Cruiser: 1}},
+		{ID: 2, Destination: coord, ArrivalTime: now, Ships: &ogame.ShipsInfos{Cruiser: 2}},
+		{ID: 3, Destination: coord, ArrivalTime: now, Ships: &ogame.ShipsInfos{Battleship: 3}},
+	})
+	assert.Equal(t, 1, len(attacks))
+	assert.Equal(t, int64(2), attacks[0].ID)
+	assert.Equal(t, ogame.ShipsInfos{Cruiser: 2, Battleship: 3}, *attacks[0].Ships)
+}
+
+func TestFleetSaveInterval(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, fleetSaveInterval(5*time.Minute, time.Hour, 10*time.Second))
+	assert.Equal(t, time.Minute, fleetSaveInterval(5*time.Minute, 2*time.Minute, 10*time.Second))
+	assert.Equal(t, 10*time.Second, fleetSaveInterval(5*time.Minute, 12*time.Second, 10*time.Second))
+}
+
+func TestFleetSaveGuard_checkRetriesFailedSave(t *testing.T) {
+	attack := ogame.AttackEvent{Destination: ogame.Coordinate{Galaxy: 1, System: 2, Position: 3, Type: ogame.PlanetType}, ArrivalTime: time.Now().Add(time.Minute)}
+	g := NewFleetSaveGuard(&fleetSaveWrapper{attacks: []ogame.AttackEvent{attack}})
+	errs := make([]error, 0)
+	g.OnError(func(_ ogame.AttackEvent, err error) { errs = append(errs, err) })
+
+	assert.Equal(t, g.minInterval, g.check())
+	assert.False(t, g.isHandled(attack))
+	assert.Equal(t, g.minInterval, g.check())
+	assert.Equal(t, []error{ogame.ErrInvalidPlanetID, ogame.ErrInvalidPlanetID}, errs)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/alaingilbert/ogame/pkg/ogame"
@@ -178,6 +179,61 @@ func GetCharacterClassHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(bot.CharacterClass()))
 }
 
+// GetCharacterClassSelectionHandler ...
+func GetCharacterClassSelectionHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	selection, err := bot.GetCharacterClassSelection()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(selection))
+}
+
+// SelectCharacterClassHandler ...
+func SelectCharacterClassHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	characterClass, err := utils.ParseI64(c.Param("characterClass"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid character class"))
+	}
+	if err := bot.SelectCharacterClass(ogame.CharacterClass(characterClass)); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// DeselectCharacterClassHandler ...
+func DeselectCharacterClassHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	if err := bot.DeselectCharacterClass(); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetAllianceClassSelectionHandler ...
+func GetAllianceClassSelectionHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	selection, err := bot.GetAllianceClassSelection()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(selection))
+}
+
+// SelectAllianceClassHandler ...
+func SelectAllianceClassHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	allianceClass, err := utils.ParseI64(c.Param("allianceClass"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid alliance class"))
+	}
+	if err := bot.SelectAllianceClass(ogame.AllianceClass(allianceClass)); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
 // HasCommanderHandler ...
 func HasCommanderHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -213,6 +269,16 @@ func HasTechnocratHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(hasTechnocrat))
 }
 
+// GetOfficersHandler ...
+func GetOfficersHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	officers, err := bot.GetOfficers()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(officers))
+}
+
 // GetEspionageReportMessagesHandler ...
 func GetEspionageReportMessagesHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -223,6 +289,26 @@ func GetEspionageReportMessagesHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(report))
 }
 
+// GetEspionageActionMessagesHandler ...
+func GetEspionageActionMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	msgs, err := bot.GetEspionageActionMessages()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// GetHarvestReportsHandler ...
+func GetHarvestReportsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	reports, err := bot.GetHarvestReports()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(reports))
+}
+
 // GetEspionageReportHandler ...
 func GetEspionageReportHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -420,6 +506,182 @@ func ActivateCelestialItemHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(nil))
 }
 
+// BuyCelestialItemHandler ...
+func BuyCelestialItemHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	ref := c.Param("itemRef")
+	if err := bot.BuyItem(ref, ogame.CelestialID(celestialID)); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetLfBonusesHandler ...
+func GetLfBonusesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	bonuses, err := bot.GetLfBonuses(ogame.PlanetID(planetID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(bonuses))
+}
+
+// GetLfSettingsHandler ...
+func GetLfSettingsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil || planetID < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	settings, err := bot.GetLfSettings(ogame.PlanetID(planetID))
+	if err != nil {
+		if err == ogame.ErrLifeformDisabled {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(settings))
+}
+
+// SelectLfSpeciesHandler ...
+func SelectLfSpeciesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil || planetID < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	lfType, err := utils.ParseI64(c.Param("lifeformType"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid lifeform type"))
+	}
+	if err := bot.SelectLfSpecies(ogame.PlanetID(planetID), ogame.LifeformType(lfType)); err != nil {
+		if err == ogame.ErrLifeformDisabled ||
+			err == ogame.ErrInvalidLifeformType ||
+			err == ogame.ErrLifeformNotDiscovered ||
+			err == ogame.ErrNotEnoughResources {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SetLfResearchSlotHandler ...
+func SetLfResearchSlotHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil || planetID < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	slot, err := utils.ParseI64(c.Param("slot"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid slot"))
+	}
+	techID, err := utils.ParseI64(c.Param("ogameID"))
+	if err != nil || !ogame.ID(techID).IsLfTech() {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid ogameID"))
+	}
+	if err := bot.SetLfResearchSlot(ogame.PlanetID(planetID), slot, ogame.ID(techID)); err != nil {
+		if err == ogame.ErrLifeformDisabled ||
+			err == ogame.ErrInvalidLfResearchSlot ||
+			err == ogame.ErrLfResearchSlotLocked {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetPlanetLayerHandler ...
+func GetPlanetLayerHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	layer, err := bot.GetPlanetLayer(ogame.CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(layer))
+}
+
+// RenameCelestialHandler ...
+func RenameCelestialHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	newName := c.Request().PostFormValue("name")
+	if err := bot.RenameCelestial(ogame.CelestialID(celestialID), newName); err != nil {
+		if err == ogame.ErrInvalidPlanetName {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// RelocatePlanetHandler ...
+func RelocatePlanetHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil || planetID < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	galaxy, err := utils.ParseI64(c.Request().PostFormValue("galaxy"))
+	if err != nil || galaxy < 1 || galaxy > bot.serverData.Galaxies {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid galaxy"))
+	}
+	system, err := utils.ParseI64(c.Request().PostFormValue("system"))
+	if err != nil || system < 1 || system > bot.serverData.Systems {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid system"))
+	}
+	position, err := utils.ParseI64(c.Request().PostFormValue("position"))
+	if err != nil || position < 1 || position > 15 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid position"))
+	}
+	coord := ogame.Coordinate{Type: ogame.PlanetType, Galaxy: galaxy, System: system, Position: position}
+	if err := bot.RelocatePlanet(ogame.PlanetID(planetID), coord); err != nil {
+		if err == ogame.ErrRelocationNotPossible ||
+			err == ogame.ErrRelocationCooldown ||
+			err == ogame.ErrRelocationInProgress ||
+			err == ogame.ErrRelocationSamePosition ||
+			err == ogame.ErrNotEnoughDarkMatter {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetRelocationStatusHandler ...
+func GetRelocationStatusHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil || planetID < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	status, err := bot.GetRelocationStatus(ogame.PlanetID(planetID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(map[string]any{
+		"Status":      status,
+		"Blockers":    status.Blockers(),
+		"CanRelocate": status.CanRelocate(),
+	}))
+}
+
 // GetPlanetHandler ...
 func GetPlanetHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -456,6 +718,48 @@ func GetPlanetByCoordHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(planet))
 }
 
+// GetResourcesProductionsHandler ...
+func GetResourcesProductionsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	breakdown, err := bot.GetResourcesProductionsBreakdown(ogame.PlanetID(planetID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(breakdown))
+}
+
+// GetUpgradeAdvicesHandler ...
+// Optional metal, crystal and deuterium query params set the trade ratio (default 3:2:1)
+func GetUpgradeAdvicesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := utils.ParseI64(c.Param("planetID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	ratio := ogame.DefaultResourceRatio
+	for _, param := range []struct {
+		name string
+		ptr  *float64
+	}{{"metal", &ratio.Metal}, {"crystal", &ratio.Crystal}, {"deuterium", &ratio.Deuterium}} {
+		if value := c.QueryParam(param.name); value != "" {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil || v <= 0 {
+				return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid "+param.name+" ratio"))
+			}
+			*param.ptr = v
+		}
+	}
+	advices, err := bot.GetUpgradeAdvices(ogame.PlanetID(planetID), ratio)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(advices))
+}
+
 // GetResourcesDetailsHandler ...
 func GetResourcesDetailsHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -856,6 +1160,24 @@ func GetRequirementsHandler(c echo.Context) error {
 	return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid ogameID"))
 }
 
+// GetUnlockPlanHandler ...
+func GetUnlockPlanHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	ogameID, err := utils.ParseI64(c.Param("ogameID"))
+	if err != nil || ogame.Objs.ByID(ogame.ID(ogameID)) == nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid ogameID"))
+	}
+	plan, err := bot.GetUnlockPlan(ogame.CelestialID(celestialID), ogame.ID(ogameID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(plan))
+}
+
 // GetPriceHandler ...
 func GetPriceHandler(c echo.Context) error {
 	ogameID, err := utils.ParseI64(c.Param("ogameID"))
@@ -1176,6 +1498,55 @@ func DeleteMessagesFromTabHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(nil))
 }
 
+// GetMessagesHandler ...
+func GetMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	tabIndex, err := utils.ParseI64(c.Param("tabIndex"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "must provide tabIndex"))
+	}
+	page, err := utils.ParseI64(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	msgs, nbPage, err := bot.GetMessages(ogame.MessagesTabID(tabIndex), page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(map[string]any{"Messages": msgs, "NbPage": nbPage}))
+}
+
+// MarkMessageReadHandler ...
+func MarkMessageReadHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	tabIndex, err := utils.ParseI64(c.Param("tabIndex"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "must provide tabIndex"))
+	}
+	messageID, err := utils.ParseI64(c.Param("messageID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid message id"))
+	}
+	if err := bot.MarkMessageRead(ogame.MessagesTabID(tabIndex), messageID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SetMessageFavoriteHandler ...
+func SetMessageFavoriteHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	messageID, err := utils.ParseI64(c.Param("messageID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid message id"))
+	}
+	favorite := c.Request().PostFormValue("favorite") != "false"
+	if err := bot.SetMessageFavorite(messageID, favorite); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
 // SendIPMHandler ...
 func SendIPMHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -1269,6 +1640,104 @@ func DoAuctionHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(nil))
 }
 
+// GetResourceTraderHandler ...
+func GetResourceTraderHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	trader, err := bot.GetResourceTrader(ogame.CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(trader))
+}
+
+// ExchangeResourcesHandler (`receive=1|2|3` `metal=123` `crystal=456` `deuterium=789`)
+func ExchangeResourcesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	receive, err := utils.ParseI64(c.Request().PostFormValue("receive"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid resource to receive"))
+	}
+	metal, _ := utils.ParseI64(c.Request().PostFormValue("metal"))
+	crystal, _ := utils.ParseI64(c.Request().PostFormValue("crystal"))
+	deuterium, _ := utils.ParseI64(c.Request().PostFormValue("deuterium"))
+	give := ogame.Resources{Metal: metal, Crystal: crystal, Deuterium: deuterium}
+	if err := bot.ExchangeResources(ogame.CelestialID(celestialID), give, ogame.TradeResource(receive)); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetScrapMerchantHandler ...
+func GetScrapMerchantHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	merchant, err := bot.GetScrapMerchant(ogame.CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(merchant))
+}
+
+// BargainScrapHandler ...
+func BargainScrapHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	merchant, err := bot.BargainScrap(ogame.CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(merchant))
+}
+
+// ScrapUnitsHandler (`units=202,10` `units=401,5` `minPercentage=50` `maxDarkMatter=10000`)
+func ScrapUnitsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := utils.ParseI64(c.Param("celestialID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	if err := c.Request().ParseForm(); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid form"))
+	}
+	var units []ogame.Quantifiable
+	for _, s := range c.Request().PostForm["units"] {
+		a := strings.Split(s, ",")
+		if len(a) != 2 {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid unit "+s))
+		}
+		unitID, err := utils.ParseI64(a[0])
+		if err != nil || !(ogame.ID(unitID).IsShip() || ogame.ID(unitID).IsDefense()) {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid unit id "+a[0]))
+		}
+		nbr, err := utils.ParseI64(a[1])
+		if err != nil || nbr < 0 {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid nbr "+a[1]))
+		}
+		units = append(units, ogame.Quantifiable{ID: ogame.ID(unitID), Nbr: nbr})
+	}
+	minPercentage, _ := utils.ParseI64(c.Request().PostFormValue("minPercentage"))
+	maxDarkMatter, _ := utils.ParseI64(c.Request().PostFormValue("maxDarkMatter"))
+	res, err := bot.ScrapUnits(ogame.CelestialID(celestialID), units, minPercentage, maxDarkMatter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(res))
+}
+
 // PhalanxHandler ...
 func PhalanxHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -0,0 +1,171 @@
+package wrapper
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// Inbox iterates over the messages of every tab, and keeps a cursor per tab
+// so only the messages received since last call are returned by NewSince.
+// The conversations tab is not a messages page, its messages are read with GetConversations and
+// GetConversation (or GetAllianceConversation), one request per conversation, and returned as messages
+// whose Title is the name of the conversation.
+type Inbox struct {
+	sync.Mutex
+	b        Wrapper
+	priority taskRunner.Priority
+	tabs     []ogame.MessagesTabID
+	cursors  map[ogame.MessagesTabID]int64
+}
+
+// NewInbox ...
+func NewInbox(b Wrapper) *Inbox {
+	i := new(Inbox)
+	i.b = b
+	i.priority = taskRunner.Normal
+	i.tabs = append(append([]ogame.MessagesTabID{}, MessagesTabIDs...), ConversationsMessagesTabID)
+	i.cursors = make(map[ogame.MessagesTabID]int64)
+	return i
+}
+
+// SetPriority ...
+func (i *Inbox) SetPriority(priority taskRunner.Priority) *Inbox {
+	i.priority = priority
+	return i
+}
+
+// SetTabs set the tabs followed by NewSince, MessagesTabIDs and the conversations by default
+func (i *Inbox) SetTabs(tabs []ogame.MessagesTabID) *Inbox {
+	i.tabs = tabs
+	return i
+}
+
+// SetCursor set the ID of the last message seen in a tab, eg: to resume from a persisted cursor
+func (i *Inbox) SetCursor(tabID ogame.MessagesTabID, msgID int64) *Inbox {
+	i.Lock()
+	defer i.Unlock()
+	i.cursors[tabID] = msgID
+	return i
+}
+
+// Cursors returns a copy of the ID of the last message seen in each tab
+func (i *Inbox) Cursors() map[ogame.MessagesTabID]int64 {
+	i.Lock()
+	defer i.Unlock()
+	out := make(map[ogame.MessagesTabID]int64, len(i.cursors))
+	for tabID, msgID := range i.cursors {
+		out[tabID] = msgID
+	}
+	return out
+}
+
+// Each calls fn for every message of a tab, newest first, page after page.
+// The iteration stops when fn returns false.
+func (i *Inbox) Each(tabID ogame.MessagesTabID, fn func(ogame.Message) bool) error {
+	if tabID == ConversationsMessagesTabID {
+		msgs, err := i.conversationsMessages()
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			if !fn(msg) {
+				return nil
+			}
+		}
+		return nil
+	}
+	var page int64 = 1
+	var nbPage int64 = 1
+	for page <= nbPage {
+		msgs, newNbPage, err := i.b.WithPriority(i.priority).GetMessages(tabID, page)
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			if !fn(msg) {
+				return nil
+			}
+		}
+		nbPage = newNbPage
+		page++
+	}
+	return nil
+}
+
+// NewSince returns the messages received in the followed tabs since the last call and advances the cursors.
+// The first call returns all the messages, unless the cursors were set.
+func (i *Inbox) NewSince() ([]ogame.Message, error) {
+	out := make([]ogame.Message, 0)
+	for _, tabID := range i.tabs {
+		cursor := i.cursor(tabID)
+		msgs := make([]ogame.Message, 0)
+		if err := i.Each(tabID, func(msg ogame.Message) bool {
+			if msg.ID <= cursor {
+				return false
+			}
+			msgs = append(msgs, msg)
+			return true
+		}); err != nil {
+			return out, err
+		}
+		i.advance(tabID, msgs)
+		out = append(out, msgs...)
+	}
+	return out, nil
+}
+
+// conversationsMessages returns the messages of every conversation, newest first.
+// Only the last message of an unread conversation is reported as unread.
+func (i *Inbox) conversationsMessages() ([]ogame.Message, error) {
+	conversations, err := i.b.WithPriority(i.priority).GetConversations()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ogame.Message, 0)
+	for _, c := range conversations {
+		var conversation ogame.Conversation
+		if c.IsAlliance() {
+			conversation, err = i.b.WithPriority(i.priority).GetAllianceConversation(c.AssociationID)
+		} else {
+			conversation, err = i.b.WithPriority(i.priority).GetConversation(c.PlayerID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for idx, m := range conversation.Messages {
+			if m.ID == 0 {
+				continue
+			}
+			out = append(out, ogame.Message{
+				ID:        m.ID,
+				TabID:     ConversationsMessagesTabID,
+				Title:     c.Name,
+				Sender:    m.SenderName,
+				Content:   m.Text,
+				CreatedAt: m.Date,
+				IsRead:    !c.Unread || idx < len(conversation.Messages)-1,
+			})
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].ID > out[b].ID })
+	return out, nil
+}
+
+func (i *Inbox) cursor(tabID ogame.MessagesTabID) int64 {
+	i.Lock()
+	defer i.Unlock()
+	return i.cursors[tabID]
+}
+
+func (i *Inbox) advance(tabID ogame.MessagesTabID, msgs []ogame.Message) {
+	i.Lock()
+	defer i.Unlock()
+	for _, msg := range msgs {
+		if msg.ID > i.cursors[tabID] {
+			i.cursors[tabID] = msg.ID
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package wrapper
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	v6 "github.com/alaingilbert/ogame/pkg/extractor/v6"
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+	"github.com/stretchr/testify/assert"
+)
+
+// inboxWrapper serves the messages pages and conversations read by Inbox, the other methods are not implemented
+type inboxWrapper struct {
+	Wrapper
+	pages         map[ogame.MessagesTabID][][]ogame.Message
+	conversations []ogame.Conversation
+	requests      []ogame.MessagesTabID
+}
+
+func (w *inboxWrapper) WithPriority(taskRunner.Priority) Prioritizable {
+	return &inboxPrioritizable{w: w}
+}
+
+type inboxPrioritizable struct {
+	Prioritizable
+	w *inboxWrapper
+}
+
+func (p *inboxPrioritizable) GetMessages(tabID ogame.MessagesTabID, page int64) ([]ogame.Message, int64, error) {
+	p.w.requests = append(p.w.requests, tabID)
+	if tabID == ConversationsMessagesTabID {
+		return nil, 0, ErrConversationsTab
+	}
+	pages := p.w.pages[tabID]
+	if int(page) > len(pages) {
+		return []ogame.Message{}, int64(len(pages)), nil
+	}
+	return pages[page-1], int64(len(pages)), nil
+}
+
+func (p *inboxPrioritizable) GetConversations() ([]ogame.Conversation, error) {
+	out := make([]ogame.Conversation, 0)
+	for _, c := range p.w.conversations {
+		c.Messages = nil
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (p *inboxPrioritizable) GetConversation(playerID int64) (ogame.Conversation, error) {
+	for _, c := range p.w.conversations {
+		if c.PlayerID == playerID && !c.IsAlliance() {
+			return c, nil
+		}
+	}
+	return ogame.Conversation{PlayerID: playerID}, nil
+}
+
+func (p *inboxPrioritizable) GetAllianceConversation(associationID int64) (ogame.Conversation, error) {
+	for _, c := range p.w.conversations {
+		if c.AssociationID == associationID {
+			return c, nil
+		}
+	}
+	return ogame.Conversation{AssociationID: associationID}, nil
+}
+
+func msgIDs(msgs []ogame.Message) []int64 {
+	out := make([]int64, 0)
+	for _, msg := range msgs {
+		out = append(out, msg.ID)
+	}
+	return out
+}
+
+func TestInbox_advance(t *testing.T) {
+	i := NewInbox(nil)
+	i.SetCursor(ExpeditionsMessagesTabID, 10)
+	i.advance(ExpeditionsMessagesTabID, []ogame.Message{{ID: 14}, {ID: 12}})
+	i.advance(CombatReportsMessagesTabID, []ogame.Message{{ID: 7}})
+	i.advance(EspionageMessagesTabID, nil)
+	assert.Equal(t, map[ogame.MessagesTabID]int64{ExpeditionsMessagesTabID: 14, CombatReportsMessagesTabID: 7}, i.Cursors())
+}
+
+func TestInbox_Each(t *testing.T) {
+	w := &inboxWrapper{pages: map[ogame.MessagesTabID][][]ogame.Message{
+		ExpeditionsMessagesTabID: {{{ID: 9}, {ID: 8}}, {{ID: 5}, {ID: 4}}, {{ID: 2}}},
+	}}
+	i := NewInbox(w)
+	seen := make([]ogame.Message, 0)
+	assert.NoError(t, i.Each(ExpeditionsMessagesTabID, func(msg ogame.Message) bool {
+		seen = append(seen, msg)
+		return true
+	}))
+	assert.Equal(t, []int64{9, 8, 5, 4, 2}, msgIDs(seen))
+	assert.Equal(t, 3, len(w.requests))
+
+	// Stopping early does not load the next pages
+	w.requests = nil
+	seen = seen[:0]
+	assert.NoError(t, i.Each(ExpeditionsMessagesTabID, func(msg ogame.Message) bool {
+		seen = append(seen, msg)
+		return msg.ID > 5
+	}))
+	assert.Equal(t, []int64{9, 8, 5}, msgIDs(seen))
+	assert.Equal(t, 2, len(w.requests))
+}
+
+// Missile attacks are reported in the combat reports tab, the first page is a real capture (1/3)
+func TestInbox_Each_MissileReports(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../samples/unversioned/combat_reports_missile_attack.html")
+	msgs, nbPage := v6.NewExtractor().ExtractMessages(pageHTMLBytes, CombatReportsMessagesTabID)
+	assert.Equal(t, int64(3), nbPage)
+	assert.Contains(t, MessagesTabIDs, CombatReportsMessagesTabID)
+	w := &inboxWrapper{pages: map[ogame.MessagesTabID][][]ogame.Message{CombatReportsMessagesTabID: {msgs}}}
+	i := NewInbox(w)
+	var first ogame.Message
+	assert.NoError(t, i.Each(CombatReportsMessagesTabID, func(msg ogame.Message) bool {
+		first = msg
+		return false
+	}))
+	assert.Equal(t, int64(6971242), first.ID)
+	assert.Equal(t, "Missile Attack", first.Title)
+	assert.Equal(t, CombatReportsMessagesTabID, first.TabID)
+}
+
+func TestInbox_Each_Conversations(t *testing.T) {
+	date := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	w := &inboxWrapper{conversations: []ogame.Conversation{
+		{PlayerID: 7, Name: "Bob", Unread: true, Messages: []ogame.ConversationMessage{
+			{ID: 101, SenderID: 7, SenderName: "Bob", Text: "hi", Date: date},
+			{ID: 105, SenderID: 7, SenderName: "Bob", Text: "still there?", Date: date.Add(time.Hour)},
+		}},
+		{AssociationID: 3, Name: "Alliance", Messages: []ogame.ConversationMessage{
+			{ID: 103, SenderID: 8, SenderName: "Eve", Text: "attack at 10", Date: date.Add(time.Minute)},
+		}},
+	}}
+	i := NewInbox(w)
+	seen := make([]ogame.Message, 0)
+	assert.NoError(t, i.Each(ConversationsMessagesTabID, func(msg ogame.Message) bool {
+		seen = append(seen, msg)
+		return true
+	}))
+	assert.Equal(t, []int64{105, 103, 101}, msgIDs(seen))
+	assert.Equal(t, ogame.Message{ID: 105, TabID: ConversationsMessagesTabID, Title: "Bob", Sender: "Bob", Content: "still there?", CreatedAt: date.Add(time.Hour)}, seen[0])
+	assert.True(t, seen[1].IsRead)
+	assert.Equal(t, "Alliance", seen[1].Title)
+	assert.True(t, seen[2].IsRead)
+	assert.Empty(t, w.requests)
+}
+
+func TestInbox_NewSince(t *testing.T) {
+	w := &inboxWrapper{
+		pages: map[ogame.MessagesTabID][][]ogame.Message{
+			ExpeditionsMessagesTabID:   {{{ID: 9}, {ID: 8}}, {{ID: 5}}},
+			CombatReportsMessagesTabID: {{{ID: 30}}, {{ID: 21}, {ID: 20}}},
+		},
+		conversations: []ogame.Conversation{{PlayerID: 7, Name: "Bob", Messages: []ogame.ConversationMessage{{ID: 101, SenderName: "Bob"}}}},
+	}
+	i := NewInbox(w)
+	i.SetCursor(CombatReportsMessagesTabID, 20)
+	msgs, err := i.NewSince()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{30, 21, 9, 8, 5, 101}, msgIDs(msgs))
+	assert.Equal(t, map[ogame.MessagesTabID]int64{ExpeditionsMessagesTabID: 9, CombatReportsMessagesTabID: 30, ConversationsMessagesTabID: 101}, i.Cursors())
+	assert.NotContains(t, w.requests, ConversationsMessagesTabID)
+
+	// New messages on the first page of a tab, the next pages are not loaded
+	w.pages[ExpeditionsMessagesTabID] = [][]ogame.Message{{{ID: 11}, {ID: 9}}, {{ID: 8}, {ID: 5}}}
+	w.conversations[0].Messages = append(w.conversations[0].Messages, ogame.ConversationMessage{ID: 110, SenderName: "Bob"})
+	w.requests = nil
+	msgs, err = i.NewSince()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{11, 110}, msgIDs(msgs))
+	assert.Equal(t, int64(11), i.Cursors()[ExpeditionsMessagesTabID])
+	assert.Equal(t, int64(110), i.Cursors()[ConversationsMessagesTabID])
+	expeditionRequests := 0
+	for _, tabID := range w.requests {
+		if tabID == ExpeditionsMessagesTabID {
+			expeditionRequests++
+		}
+	}
+	assert.Equal(t, 1, expeditionRequests)
+
+	// Only the followed tabs are read
+	i.SetTabs([]ogame.MessagesTabID{CombatReportsMessagesTabID})
+	w.requests = nil
+	msgs, err = i.NewSince()
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+	assert.Equal(t, []ogame.MessagesTabID{CombatReportsMessagesTabID}, w.requests)
+}
@@ -21,6 +21,7 @@ type Celestial interface {
 	BuildBuilding(buildingID ogame.ID) error
 	BuildDefense(defenseID ogame.ID, nbr int64) error
 	BuildTechnology(technologyID ogame.ID) error
+	BuyItem(string) error
 	CancelBuilding() error
 	CancelLfBuilding() error
 	CancelResearch() error
@@ -31,12 +32,15 @@ type Celestial interface {
 	GetItems() ([]ogame.Item, error)
 	GetLfBuildings(...Option) (ogame.LfBuildings, error)
 	GetLfResearch(...Option) (ogame.LfResearches, error)
+	GetPlanetLayer() (ogame.PlanetLayer, error)
 	GetProduction() ([]ogame.Quantifiable, int64, error)
 	GetResources() (ogame.Resources, error)
 	GetResourcesBuildings(...Option) (ogame.ResourcesBuildings, error)
 	GetResourcesDetails() (ogame.ResourcesDetails, error)
 	GetShips(...Option) (ogame.ShipsInfos, error)
 	GetTechs() (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error)
+	GetUnlockPlan(ogame.ID) (ogame.UnlockPlan, error)
+	Rename(string) error
 	SendFleet([]ogame.Quantifiable, ogame.Speed, ogame.Coordinate, ogame.MissionID, ogame.Resources, int64, int64) (ogame.Fleet, error)
 	TearDown(buildingID ogame.ID) error
 }
@@ -48,6 +52,7 @@ type Prioritizable interface {
 	ActivateItem(string, ogame.CelestialID) error
 	Begin() Prioritizable
 	BeginNamed(name string) Prioritizable
+	BuyItem(string, ogame.CelestialID) error
 	BuyMarketplace(itemID int64, celestialID ogame.CelestialID) error
 	BuyOfferOfTheDay() error
 	CancelFleet(ogame.FleetID) error
@@ -56,21 +61,29 @@ type Prioritizable interface {
 	CreateUnion(fleet ogame.Fleet, unionUsers []string) (int64, error)
 	DeleteAllMessagesFromTab(tabID ogame.MessagesTabID) error
 	DeleteMessage(msgID int64) error
+	DeselectCharacterClass() error
 	DoAuction(bid map[ogame.CelestialID]ogame.Resources) error
 	Done()
 	FlightTime(origin, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, mission ogame.MissionID) (secs, fuel int64)
 	GalaxyInfos(galaxy, system int64, opts ...Option) (ogame.SystemInfos, error)
 	GetActiveItems(ogame.CelestialID) ([]ogame.ActiveItem, error)
+	GetAllianceClassSelection() (ogame.AllianceClassSelection, error)
+	GetAllianceConversation(associationID int64) (ogame.Conversation, error)
 	GetAllResources() (map[ogame.CelestialID]ogame.Resources, error)
 	GetAttacks(...Option) ([]ogame.AttackEvent, error)
 	GetAuction() (ogame.Auction, error)
+	GetCachedLfBonuses(ogame.Coordinate) ogame.LfBonuses
 	GetCachedResearch() ogame.Researches
 	GetCelestial(any) (Celestial, error)
+	GetCharacterClassSelection() (ogame.CharacterClassSelection, error)
 	GetCelestials() ([]Celestial, error)
 	GetCombatReportSummaryFor(ogame.Coordinate) (ogame.CombatReportSummary, error)
+	GetConversation(playerID int64) (ogame.Conversation, error)
+	GetConversations() ([]ogame.Conversation, error)
 	GetDMCosts(ogame.CelestialID) (ogame.DMCosts, error)
 	GetEmpire(ogame.CelestialType) ([]ogame.EmpireCelestial, error)
 	GetEmpireJSON(nbr int64) (any, error)
+	GetEspionageActionMessages() ([]ogame.EspionageActionMessage, error)
 	GetEspionageReport(msgID int64) (ogame.EspionageReport, error)
 	GetEspionageReportFor(ogame.Coordinate) (ogame.EspionageReport, error)
 	GetEspionageReportMessages() ([]ogame.EspionageReportSummary, error)
@@ -78,9 +91,14 @@ type Prioritizable interface {
 	GetExpeditionMessages() ([]ogame.ExpeditionMessage, error)
 	GetFleets(...Option) ([]ogame.Fleet, ogame.Slots)
 	GetFleetsFromEventList() []ogame.Fleet
+	GetHarvestReportFor(ogame.Fleet) (ogame.HarvestReport, error)
+	GetHarvestReports() ([]ogame.HarvestReport, error)
 	GetItems(ogame.CelestialID) ([]ogame.Item, error)
+	GetMessages(tabID ogame.MessagesTabID, page int64) ([]ogame.Message, int64, error)
+	GetMessagesSince(tabID ogame.MessagesTabID, msgID int64) ([]ogame.Message, error)
 	GetMoon(any) (Moon, error)
 	GetMoons() []Moon
+	GetOfficers() (ogame.Officers, error)
 	GetPageContent(url.Values) ([]byte, error)
 	GetPlanet(any) (Planet, error)
 	GetPlanets() []Planet
@@ -94,19 +112,25 @@ type Prioritizable interface {
 	LoginWithBearerToken(token string) (bool, error)
 	LoginWithExistingCookies() (bool, error)
 	Logout()
+	MarkMessageRead(tabID ogame.MessagesTabID, msgID int64) error
 	OfferBuyMarketplace(itemID any, quantity, priceType, price, priceRange int64, celestialID ogame.CelestialID) error
 	OfferSellMarketplace(itemID any, quantity, priceType, price, priceRange int64, celestialID ogame.CelestialID) error
 	PostPageContent(url.Values, url.Values) ([]byte, error)
 	RecruitOfficer(typ, days int64) error
+	ReplyToConversation(conversation ogame.Conversation, message string) error
+	SelectAllianceClass(ogame.AllianceClass) error
+	SelectCharacterClass(ogame.CharacterClass) error
 	SendMessage(playerID int64, message string) error
 	SendMessageAlliance(associationID int64, message string) error
 	ServerTime() time.Time
+	SetMessageFavorite(msgID int64, favorite bool) error
 	SetInitiator(initiator string) Prioritizable
 	SetVacationMode() error
 	Tx(clb func(tx Prioritizable) error) error
 	UseDM(string, ogame.CelestialID) error
 
 	// Planet or Moon functions
+	BargainScrap(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error)
 	Build(celestialID ogame.CelestialID, id ogame.ID, nbr int64) error
 	BuildBuilding(celestialID ogame.CelestialID, buildingID ogame.ID) error
 	BuildCancelable(ogame.CelestialID, ogame.ID) error
@@ -119,26 +143,45 @@ type Prioritizable interface {
 	CancelResearch(ogame.CelestialID) error
 	ConstructionsBeingBuilt(ogame.CelestialID) (buildingID ogame.ID, buildingCountdown int64, researchID ogame.ID, researchCountdown int64, lfBuildingID ogame.ID, lfBuildingCountdown int64, lfResearchID ogame.ID, lfResearchCountdown int64)
 	EnsureFleet(celestialID ogame.CelestialID, ships []ogame.Quantifiable, speed ogame.Speed, where ogame.Coordinate, mission ogame.MissionID, resources ogame.Resources, holdingTime, unionID int64) (ogame.Fleet, error)
+	ExchangeResources(celestialID ogame.CelestialID, give ogame.Resources, receive ogame.TradeResource) error
 	GetDefense(ogame.CelestialID, ...Option) (ogame.DefensesInfos, error)
 	GetFacilities(ogame.CelestialID, ...Option) (ogame.Facilities, error)
 	GetLfBuildings(ogame.CelestialID, ...Option) (ogame.LfBuildings, error)
 	GetLfResearch(ogame.CelestialID, ...Option) (ogame.LfResearches, error)
+	GetPlanetLayer(ogame.CelestialID) (ogame.PlanetLayer, error)
 	GetProduction(ogame.CelestialID) ([]ogame.Quantifiable, int64, error)
+	GetResourceTrader(ogame.CelestialID) (ogame.ResourceTrader, error)
 	GetResources(ogame.CelestialID) (ogame.Resources, error)
 	GetResourcesBuildings(ogame.CelestialID, ...Option) (ogame.ResourcesBuildings, error)
 	GetResourcesDetails(ogame.CelestialID) (ogame.ResourcesDetails, error)
+	GetScrapMerchant(ogame.CelestialID) (ogame.ScrapMerchant, error)
 	GetShips(ogame.CelestialID, ...Option) (ogame.ShipsInfos, error)
 	GetTechs(celestialID ogame.CelestialID) (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error)
+	GetUnlockPlan(ogame.CelestialID, ogame.ID) (ogame.UnlockPlan, error)
+	RenameCelestial(ogame.CelestialID, string) error
+	ScrapUnits(celestialID ogame.CelestialID, units []ogame.Quantifiable, minPercentage, maxDarkMatter int64) (ogame.Resources, error)
 	SendFleet(celestialID ogame.CelestialID, ships []ogame.Quantifiable, speed ogame.Speed, where ogame.Coordinate, mission ogame.MissionID, resources ogame.Resources, holdingTime, unionID int64) (ogame.Fleet, error)
 	TearDown(celestialID ogame.CelestialID, id ogame.ID) error
 	TechnologyDetails(celestialID ogame.CelestialID, id ogame.ID) (ogame.TechnologyDetails, error)
 
 	// Planet specific functions
 	DestroyRockets(ogame.PlanetID, int64, int64) error
+	GetLfBonuses(ogame.PlanetID) (ogame.LfBonuses, error)
+	GetLfSettings(ogame.PlanetID) (ogame.LfSettings, error)
+	GetRelocationStatus(ogame.PlanetID) (ogame.RelocationStatus, error)
 	GetResourceSettings(ogame.PlanetID, ...Option) (ogame.ResourceSettings, error)
 	GetResourcesProductions(ogame.PlanetID) (ogame.Resources, error)
+	GetResourcesProductionsBreakdown(ogame.PlanetID) (ogame.ProductionBreakdown, error)
 	GetResourcesProductionsLight(ogame.ResourcesBuildings, ogame.Researches, ogame.ResourceSettings, ogame.Temperature) ogame.Resources
+	GetUpgradeAdvices(ogame.PlanetID, ogame.Multiplier) ([]ogame.UpgradeAdvice, error)
+	LfCargo(ogame.PlanetID, ogame.ShipsInfos) (int64, error)
+	LfConstructionTime(ogame.PlanetID, ogame.ID, int64) (time.Duration, error)
+	LfFlightTime(planetID ogame.PlanetID, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, mission ogame.MissionID) (secs, fuel int64, err error)
+	LfPrice(ogame.PlanetID, ogame.ID, int64) (ogame.Resources, error)
+	RelocatePlanet(ogame.PlanetID, ogame.Coordinate) error
+	SelectLfSpecies(ogame.PlanetID, ogame.LifeformType) error
 	SendIPM(ogame.PlanetID, ogame.Coordinate, int64, ogame.ID) (int64, error)
+	SetLfResearchSlot(planetID ogame.PlanetID, slot int64, techID ogame.ID) error
 	SetResourceSettings(ogame.PlanetID, ogame.ResourceSettings) error
 
 	// Moon specific functions
@@ -163,6 +206,7 @@ type Wrapper interface {
 	GetCachedCelestial(any) Celestial
 	GetCachedCelestials() []Celestial
 	GetCachedMoons() []Moon
+	GetCachedOfficers() ogame.Officers
 	GetCachedPlanets() []Planet
 	GetCachedPlayer() ogame.UserInfos
 	GetCachedPreferences() ogame.Preferences
@@ -197,6 +241,7 @@ type Wrapper interface {
 	ReconnectChat() bool
 	RegisterAuctioneerCallback(func(any))
 	RegisterChatCallback(func(ogame.ChatMsg))
+	RegisterConversationCallback(func(ogame.Conversation, ogame.ConversationMessage))
 	RegisterHTMLInterceptor(func(method, url string, params, payload url.Values, pageHTML []byte))
 	RegisterWSCallback(string, func([]byte))
 	RemoveWSCallback(string)
@@ -114,6 +114,21 @@ func (m Moon) ActivateItem(ref string) error {
 	return m.ogame.ActivateItem(ref, m.ID.Celestial())
 }
 
+// BuyItem buys an item from the dark matter shop
+func (m Moon) BuyItem(ref string) error {
+	return m.ogame.BuyItem(ref, m.ID.Celestial())
+}
+
+// GetPlanetLayer gets the rename/relocate/abandon information of the moon
+func (m Moon) GetPlanetLayer() (ogame.PlanetLayer, error) {
+	return m.ogame.GetPlanetLayer(m.ID.Celestial())
+}
+
+// Rename the moon
+func (m Moon) Rename(newName string) error {
+	return m.ogame.RenameCelestial(m.ID.Celestial(), newName)
+}
+
 //// BuildFacility build a facility
 //func (m *Moon) BuildFacility(ID) error {
 //	return nil
@@ -148,6 +163,11 @@ func (m Moon) GetLfResearch(options ...Option) (ogame.LfResearches, error) {
 	return m.ogame.GetLfResearch(m.ID.Celestial(), options...)
 }
 
+// GetUnlockPlan gets the ordered list of upgrades needed on the moon to unlock an ogame object
+func (m Moon) GetUnlockPlan(id ogame.ID) (ogame.UnlockPlan, error) {
+	return m.ogame.GetUnlockPlan(m.ID.Celestial(), id)
+}
+
 // GetTechs gets (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches)
 func (m Moon) GetTechs() (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error) {
 	return m.ogame.GetTechs(m.ID.Celestial())
@@ -0,0 +1,246 @@
+package wrapper
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/alaingilbert/ogame/pkg/taskRunner"
+)
+
+// ErrOfficerNotEnoughDarkMatter returned when an officer cannot be renewed without going under the dark matter to keep
+var ErrOfficerNotEnoughDarkMatter = errors.New("not enough dark matter to renew officer")
+
+// OfficerRenewal an officer recruited (or extended) by the keeper
+type OfficerRenewal struct {
+	Officer   ogame.OfficerType
+	Days      int64
+	Price     int64
+	Remaining time.Duration // Time that was left before the renewal
+}
+
+// OfficerKeeper periodically checks the officers and extends them before they expire,
+// as long as the dark matter allows it.
+type OfficerKeeper struct {
+	sync.Mutex
+	b              Wrapper
+	priority       taskRunner.Priority
+	keep           map[ogame.OfficerType]int64
+	renewBefore    time.Duration
+	keepDarkMatter int64
+	interval       time.Duration
+	stopCh         chan struct{}
+	renewCallbacks []func(OfficerRenewal)
+	errorCallbacks []func(ogame.OfficerType, error)
+}
+
+// NewOfficerKeeper ...
+func NewOfficerKeeper(b Wrapper) *OfficerKeeper {
+	k := new(OfficerKeeper)
+	k.b = b
+	k.priority = taskRunner.Normal
+	k.keep = make(map[ogame.OfficerType]int64)
+	k.renewBefore = 24 * time.Hour
+	k.interval = time.Hour
+	return k
+}
+
+// SetPriority set the task runner priority used by the keeper
+func (k *OfficerKeeper) SetPriority(priority taskRunner.Priority) *OfficerKeeper {
+	k.priority = priority
+	return k
+}
+
+// Keep the officer recruited, renewing it for the given number of days (7 or 90)
+func (k *OfficerKeeper) Keep(officer ogame.OfficerType, days int64) *OfficerKeeper {
+	k.Lock()
+	defer k.Unlock()
+	k.keep[officer] = days
+	return k
+}
+
+// KeepCommandingStaff keep all the officers recruited, they are renewed together with the commanding staff bundle
+// when they are all due
+func (k *OfficerKeeper) KeepCommandingStaff(days int64) *OfficerKeeper {
+	for _, officer := range ogame.OfficerTypes {
+		k.Keep(officer, days)
+	}
+	return k
+}
+
+// SetRenewBefore set how long before the expiry the officers are renewed
+func (k *OfficerKeeper) SetRenewBefore(renewBefore time.Duration) *OfficerKeeper {
+	k.renewBefore = renewBefore
+	return k
+}
+
+// SetKeepDarkMatter set the amount of dark matter the keeper never spends
+func (k *OfficerKeeper) SetKeepDarkMatter(keepDarkMatter int64) *OfficerKeeper {
+	k.keepDarkMatter = keepDarkMatter
+	return k
+}
+
+// SetInterval set the time between two checks
+func (k *OfficerKeeper) SetInterval(interval time.Duration) *OfficerKeeper {
+	k.interval = interval
+	return k
+}
+
+// OnRenew register a callback called when an officer is renewed
+func (k *OfficerKeeper) OnRenew(clb func(OfficerRenewal)) *OfficerKeeper {
+	k.renewCallbacks = append(k.renewCallbacks, clb)
+	return k
+}
+
+// OnError register a callback called when an officer could not be renewed
+func (k *OfficerKeeper) OnError(clb func(ogame.OfficerType, error)) *OfficerKeeper {
+	k.errorCallbacks = append(k.errorCallbacks, clb)
+	return k
+}
+
+// Start the keeper in a goroutine
+func (k *OfficerKeeper) Start() {
+	k.Lock()
+	defer k.Unlock()
+	if k.stopCh != nil {
+		return
+	}
+	k.stopCh = make(chan struct{})
+	go k.run(k.stopCh)
+}
+
+// Stop the keeper
+func (k *OfficerKeeper) Stop() {
+	k.Lock()
+	defer k.Unlock()
+	if k.stopCh != nil {
+		close(k.stopCh)
+		k.stopCh = nil
+	}
+}
+
+func (k *OfficerKeeper) run(stopCh chan struct{}) {
+	for {
+		k.Check()
+		select {
+		case <-time.After(k.interval):
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// Check renews the officers that are about to expire, returns the renewals done
+func (k *OfficerKeeper) Check() []OfficerRenewal {
+	k.Lock()
+	keep := make(map[ogame.OfficerType]int64, len(k.keep))
+	for officer, days := range k.keep {
+		keep[officer] = days
+	}
+	k.Unlock()
+	if len(keep) == 0 {
+		return nil
+	}
+	type failure struct {
+		officer ogame.OfficerType
+		err     error
+	}
+	var failures []failure
+	done := make([]OfficerRenewal, 0)
+	err := k.b.WithPriority(k.priority).Tx(func(tx Prioritizable) error {
+		officers, err := tx.GetOfficers()
+		if err != nil {
+			return err
+		}
+		celestials := k.b.GetCachedCelestials()
+		if len(celestials) == 0 {
+			return ogame.ErrInvalidPlanetID
+		}
+		details, err := tx.GetResourcesDetails(celestials[0].GetID())
+		if err != nil {
+			return err
+		}
+		renewals, skipped := planOfficerRenewals(officers, keep, k.renewBefore, details.Darkmatter.Available, k.keepDarkMatter)
+		for _, officer := range skipped {
+			failures = append(failures, failure{officer, ErrOfficerNotEnoughDarkMatter})
+		}
+		for _, renewal := range renewals {
+			if err := tx.RecruitOfficer(int64(renewal.Officer), renewal.Days); err != nil {
+				failures = append(failures, failure{renewal.Officer, err})
+				continue
+			}
+			done = append(done, renewal)
+		}
+		return nil
+	})
+	if err != nil {
+		k.error(0, err)
+		return nil
+	}
+	// Callbacks are called once the bot is unlocked, they may use it
+	for _, f := range failures {
+		k.error(f.officer, f.err)
+	}
+	for _, renewal := range done {
+		for _, clb := range k.renewCallbacks {
+			clb(renewal)
+		}
+	}
+	return done
+}
+
+func (k *OfficerKeeper) error(officer ogame.OfficerType, err error) {
+	for _, clb := range k.errorCallbacks {
+		clb(officer, err)
+	}
+}
+
+// planOfficerRenewals returns the officers to renew, those expiring first are renewed first.
+// When all the officers are kept for the same days and they are all due, the commanding staff bundle is renewed instead.
+// Officers that cannot be paid without going under keepDarkMatter are returned as skipped.
+func planOfficerRenewals(officers ogame.Officers, keep map[ogame.OfficerType]int64, renewBefore time.Duration,
+	darkMatter, keepDarkMatter int64) (renewals []OfficerRenewal, skipped []ogame.OfficerType) {
+	candidates := make([]OfficerRenewal, 0)
+	for _, officer := range ogame.OfficerTypes {
+		days, ok := keep[officer]
+		if !ok {
+			continue
+		}
+		status := officers.ByType(officer)
+		if status.Active && status.Remaining > renewBefore {
+			continue
+		}
+		candidates = append(candidates, OfficerRenewal{Officer: officer, Days: days, Price: officer.Price(days), Remaining: status.Remaining})
+	}
+	if isCommandingStaffRenewal(candidates) {
+		days := candidates[0].Days
+		staff := OfficerRenewal{Officer: ogame.CommandingStaffOfficer, Days: days, Price: ogame.CommandingStaffOfficer.Price(days),
+			Remaining: officers.CommandingStaff().Remaining}
+		candidates = []OfficerRenewal{staff}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Remaining < candidates[j].Remaining })
+	for _, renewal := range candidates {
+		if darkMatter-renewal.Price < keepDarkMatter {
+			skipped = append(skipped, renewal.Officer)
+			continue
+		}
+		darkMatter -= renewal.Price
+		renewals = append(renewals, renewal)
+	}
+	return
+}
+
+// isCommandingStaffRenewal returns true if the candidates are all the officers, renewed for the same days
+func isCommandingStaffRenewal(candidates []OfficerRenewal) bool {
+	if len(candidates) != len(ogame.OfficerTypes) {
+		return false
+	}
+	for _, c := range candidates {
+		if c.Days != candidates[0].Days {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package wrapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alaingilbert/ogame/pkg/ogame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanOfficerRenewals(t *testing.T) {
+	officers := ogame.Officers{
+		Commander: ogame.OfficerStatus{Type: ogame.CommanderOfficer, Active: true, Remaining: 20 * time.Hour},
+		Geologist: ogame.OfficerStatus{Type: ogame.GeologistOfficer, Active: true, Remaining: 2 * time.Hour},
+		Admiral:   ogame.OfficerStatus{Type: ogame.AdmiralOfficer, Active: true, Remaining: 10 * 24 * time.Hour},
+	}
+	keep := map[ogame.OfficerType]int64{ogame.CommanderOfficer: 7, ogame.GeologistOfficer: 7, ogame.AdmiralOfficer: 7}
+
+	renewals, skipped := planOfficerRenewals(officers, keep, 24*time.Hour, 50000, 0)
+	assert.Empty(t, skipped)
+	assert.Equal(t, []OfficerRenewal{
+		{Officer: ogame.GeologistOfficer, Days: 7, Price: 12500, Remaining: 2 * time.Hour},
+		{Officer: ogame.CommanderOfficer, Days: 7, Price: 10000, Remaining: 20 * time.Hour},
+	}, renewals)
+
+	renewals, skipped = planOfficerRenewals(officers, keep, 24*time.Hour, 20000, 5000)
+	assert.Equal(t, []ogame.OfficerType{ogame.CommanderOfficer}, skipped)
+	assert.Equal(t, 1, len(renewals))
+	assert.Equal(t, ogame.GeologistOfficer, renewals[0].Officer)
+
+	keep[ogame.TechnocratOfficer] = 90
+	renewals, _ = planOfficerRenewals(officers, keep, time.Hour, 1000000, 0)
+	assert.Equal(t, []OfficerRenewal{{Officer: ogame.TechnocratOfficer, Days: 90, Price: 125000}}, renewals)
+}
+
+func TestPlanOfficerRenewals_CommandingStaff(t *testing.T) {
+	officers := ogame.Officers{
+		Commander:  ogame.OfficerStatus{Type: ogame.CommanderOfficer, Active: true, Remaining: 20 * time.Hour},
+		Admiral:    ogame.OfficerStatus{Type: ogame.AdmiralOfficer, Active: true, Remaining: 20 * time.Hour},
+		Engineer:   ogame.OfficerStatus{Type: ogame.EngineerOfficer, Active: true, Remaining: 20 * time.Hour},
+		Geologist:  ogame.OfficerStatus{Type: ogame.GeologistOfficer, Active: true, Remaining: 10 * time.Hour},
+		Technocrat: ogame.OfficerStatus{Type: ogame.TechnocratOfficer, Active: true, Remaining: 20 * time.Hour},
+	}
+	keep := make(map[ogame.OfficerType]int64)
+	for _, officer := range ogame.OfficerTypes {
+		keep[officer] = 7
+	}
+	renewals, skipped := planOfficerRenewals(officers, keep, 24*time.Hour, 100000, 0)
+	assert.Empty(t, skipped)
+	assert.Equal(t, []OfficerRenewal{{Officer: ogame.CommandingStaffOfficer, Days: 7, Price: 60000, Remaining: 10 * time.Hour}}, renewals)
+
+	renewals, skipped = planOfficerRenewals(officers, keep, 24*time.Hour, 50000, 0)
+	assert.Empty(t, renewals)
+	assert.Equal(t, []ogame.OfficerType{ogame.CommandingStaffOfficer}, skipped)
+
+	// One officer is not due yet, the others are renewed one by one
+	officers.Admiral.Remaining = 3 * 24 * time.Hour
+	renewals, _ = planOfficerRenewals(officers, keep, 24*time.Hour, 100000, 0)
+	assert.Equal(t, 4, len(renewals))
+	assert.Equal(t, ogame.GeologistOfficer, renewals[0].Officer)
+
+	// Officers kept for different days are renewed one by one
+	officers.Admiral.Remaining = 20 * time.Hour
+	keep[ogame.TechnocratOfficer] = 90
+	renewals, _ = planOfficerRenewals(officers, keep, 24*time.Hour, 1000000, 0)
+	assert.Equal(t, 5, len(renewals))
+}
@@ -27,8 +27,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/alaingilbert/clockwork"
+	"github.com/alaingilbert/ogame/pkg/chatClient"
 	"github.com/alaingilbert/ogame/pkg/exponentialBackoff"
 	"github.com/alaingilbert/ogame/pkg/extractor"
 	v6 "github.com/alaingilbert/ogame/pkg/extractor/v6"
@@ -50,7 +52,6 @@ import (
 	"github.com/pkg/errors"
 	lua "github.com/yuin/gopher-lua"
 	"golang.org/x/net/proxy"
-	"golang.org/x/net/websocket"
 )
 
 // OGame is a client for ogame.org. It is safe for concurrent use by
@@ -71,6 +72,8 @@ type OGame struct {
 	CachedPreferences     ogame.Preferences
 	isVacationModeEnabled bool
 	researches            *ogame.Researches
+	lfBonuses             map[ogame.CelestialID]ogame.LfBonuses
+	lfBonusesMu           sync.Mutex
 	planets               []Planet
 	planetsMu             sync.RWMutex
 	ajaxChatToken         string
@@ -83,7 +86,6 @@ type OGame struct {
 	playerID              int64
 	lobby                 string
 	ogameSession          string
-	sessionChatCounter    int64
 	server                Server
 	serverData            ServerData
 	location              *time.Location
@@ -91,11 +93,13 @@ type OGame struct {
 	client                *httpclient.Client
 	logger                *log.Logger
 	chatCallbacks         []func(msg ogame.ChatMsg)
+	conversationCallbacks []func(ogame.Conversation, ogame.ConversationMessage)
+	conversations         *conversationStore
 	wsCallbacks           map[string]func(msg []byte)
 	auctioneerCallbacks   []func(any)
 	interceptorCallbacks  []func(method, url string, params, payload url.Values, pageHTML []byte)
 	closeChatCh           chan struct{}
-	ws                    *websocket.Conn
+	chatClient            *chatClient.Client
 	taskRunnerInst        *taskRunner.TaskRunner[*Prioritize]
 	loginWrapper          func(func() (bool, error)) error
 	getServerDataWrapper  func(func() (ServerData, error)) (ServerData, error)
@@ -108,6 +112,8 @@ type OGame struct {
 	hasEngineer           bool
 	hasGeologist          bool
 	hasTechnocrat         bool
+	officers              ogame.Officers
+	officersUpdatedAt     time.Time
 	captchaCallback       CaptchaCallback
 }
 
@@ -251,6 +257,7 @@ func NewNoLogin(username, password, otpSecret, bearerToken, universe, lang, cook
 	b.taskRunnerInst = taskRunner.NewTaskRunner(context.Background(), factory)
 
 	b.wsCallbacks = make(map[string]func([]byte))
+	b.conversations = newConversationStore()
 
 	return b, nil
 }
@@ -640,8 +647,6 @@ func (b *OGame) loginPart3(userAccount Account, page parser.OverviewPage) error
 		b.error("failed to parse ogame version: " + err.Error())
 	}
 
-	b.sessionChatCounter = 1
-
 	b.debug("logged in as " + userAccount.Name + " on " + b.Universe + "-" + b.language)
 
 	b.debug("extract information from html")
@@ -665,20 +670,24 @@ func (b *OGame) loginPart3(userAccount Account, page parser.OverviewPage) error
 
 	if atomic.CompareAndSwapInt32(&b.chatConnectedAtom, 0, 1) {
 		b.closeChatCh = make(chan struct{})
-		go func(b *OGame) {
+		chatRetry := exponentialBackoff.New(context.Background(), clockwork.NewRealClock(), 60)
+		b.chatClient = b.newChatClient(chatRetry, chatHost, chatPort)
+		go func(b *OGame, client *chatClient.Client) {
 			defer atomic.StoreInt32(&b.chatConnectedAtom, 0)
-			chatRetry := exponentialBackoff.New(context.Background(), clockwork.NewRealClock(), 60)
 			chatRetry.LoopForever(func() bool {
 				select {
 				case <-b.closeChatCh:
 					return false
 				default:
-					b.connectChat(chatRetry, chatHost, chatPort)
+					if err := client.Run(); err != nil {
+						b.error("chat error:", err)
+					}
 				}
 				return true
 			})
-		}(b)
+		}(b, b.chatClient)
 	} else {
+		b.chatClient.SetSession(b.ogameSession)
 		b.ReconnectChat()
 	}
 
@@ -767,6 +776,8 @@ func (b *OGame) cacheFullPageInfo(page parser.IFullPage) {
 	b.hasEngineer = page.ExtractEngineer()
 	b.hasGeologist = page.ExtractGeologist()
 	b.hasTechnocrat = page.ExtractTechnocrat()
+	b.officers = page.ExtractOfficers()
+	b.officersUpdatedAt = time.Now()
 
 	switch castedPage := page.(type) {
 	case parser.OverviewPage:
@@ -921,378 +932,43 @@ func (b *OGame) SetProxy(proxyAddress, username, password, proxyType string, log
 	return b.setProxy(proxyAddress, username, password, proxyType, loginOnly, config)
 }
 
-func (b *OGame) connectChat(chatRetry *exponentialBackoff.ExponentialBackoff, host, port string) {
+func (b *OGame) newChatClient(chatRetry *exponentialBackoff.ExponentialBackoff, host, port string) *chatClient.Client {
+	chatVersion := chatClient.V7
 	if b.IsV8() || b.IsV9() {
-		b.connectChatV8(chatRetry, host, port)
-	} else {
-		b.connectChatV7(chatRetry, host, port)
-	}
-}
-
-// Socket IO v3 timestamp encoding
-// https://github.com/unshiftio/yeast/blob/28d15f72fc5a4273592bc209056c328a54e2b522/index.js#L17
-// fmt.Println(yeast(time.Now().UnixNano() / 1000000))
-func yeast(num int64) (encoded string) {
-	alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_"
-	length := int64(len(alphabet))
-	for num > 0 {
-		encoded = string(alphabet[int(num%length)]) + encoded
-		num = int64(math.Floor(float64(num / length)))
+		chatVersion = chatClient.V8
 	}
-	return
-}
-
-func (b *OGame) connectChatV8(chatRetry *exponentialBackoff.ExponentialBackoff, host, port string) {
-	token := yeast(time.Now().UnixNano() / 1000000)
-	req, err := http.NewRequest(http.MethodGet, "https://"+host+":"+port+"/socket.io/?EIO=4&transport=polling&t="+token, nil)
-	if err != nil {
-		b.error("failed to create request:", err)
-		return
-	}
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		b.error("failed to get socket.io token:", err)
-		return
-	}
-	defer resp.Body.Close()
-	chatRetry.Reset()
-	by, _ := ioutil.ReadAll(resp.Body)
-	m := regexp.MustCompile(`"sid":"([^"]+)"`).FindSubmatch(by)
-	if len(m) != 2 {
-		b.error("failed to get websocket sid:", err)
-		return
-	}
-	sid := string(m[1])
-
-	origin := "https://" + host + ":" + port + "/"
-	wssURL := "wss://" + host + ":" + port + "/socket.io/?EIO=4&transport=websocket&sid=" + sid
-	b.ws, err = websocket.Dial(wssURL, "", origin)
-	if err != nil {
-		b.error("failed to dial websocket:", err)
-		return
-	}
-	_ = websocket.Message.Send(b.ws, "2probe")
-
-	// Recv msgs
-LOOP:
-	for {
-		select {
-		case <-b.closeChatCh:
-			break LOOP
-		default:
-		}
-
-		var buf string
-		if err := b.ws.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
-			b.error("failed to set read deadline:", err)
-		}
-		err := websocket.Message.Receive(b.ws, &buf)
-		if err != nil {
-			if err == io.EOF {
-				b.error("chat eof:", err)
-				break
-			} else if strings.HasSuffix(err.Error(), "use of closed network connection") {
-				break
-			} else if strings.HasSuffix(err.Error(), "i/o timeout") {
-				continue
-			} else {
-				b.error("chat unexpected error", err)
-				// connection reset by peer
-				break
+	return chatClient.New("https://"+host+":"+port, b.ogameSession, chatVersion).
+		OnRaw(func(msg []byte) {
+			for _, clb := range b.wsCallbacks {
+				go clb(msg)
 			}
-		}
-		for _, clb := range b.wsCallbacks {
-			go clb([]byte(buf))
-		}
-		if buf == "3probe" {
-			_ = websocket.Message.Send(b.ws, "5")
-			_ = websocket.Message.Send(b.ws, "40/chat,")
-			_ = websocket.Message.Send(b.ws, "40/auctioneer,")
-		} else if buf == "2" {
-			_ = websocket.Message.Send(b.ws, "3")
-		} else if regexp.MustCompile(`40/auctioneer,{"sid":"[^"]+"}`).MatchString(buf) {
-			b.debug("got auctioneer sid")
-		} else if regexp.MustCompile(`40/chat,{"sid":"[^"]+"}`).MatchString(buf) {
-			b.debug("got chat sid")
-			_ = websocket.Message.Send(b.ws, `42/chat,`+utils.FI64(b.sessionChatCounter)+`["authorize","`+b.ogameSession+`"]`)
-			b.sessionChatCounter++
-		} else if regexp.MustCompile(`43/chat,\d+\[true]`).MatchString(buf) {
+		}).
+		OnConnected(func() {
+			chatRetry.Reset()
 			b.debug("chat connected")
-		} else if regexp.MustCompile(`43/chat,\d+\[false]`).MatchString(buf) {
-			b.error("Failed to connect to chat")
-		} else if strings.HasPrefix(buf, `42/chat,["chat",`) {
-			payload := strings.TrimPrefix(buf, `42/chat,["chat",`)
-			payload = strings.TrimSuffix(payload, `]`)
-			var chatMsg ogame.ChatMsg
-			if err := json.Unmarshal([]byte(payload), &chatMsg); err != nil {
-				b.error("Unable to unmarshal chat payload", err, payload)
-				continue
-			}
+		}).
+		OnChatMessage(func(chatMsg ogame.ChatMsg) {
+			b.receiveChatMsg(chatMsg)
 			for _, clb := range b.chatCallbacks {
 				clb(chatMsg)
 			}
-		} else if regexp.MustCompile(`^\d+/auctioneer`).MatchString(buf) {
-			// 42/auctioneer,["timeLeft","<span style=\"color:#99CC00;\"><b>approx. 30m</b></span> remaining until the auction ends"] // every minute
-			// 42/auctioneer,["timeLeft","Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">117</span>"]
-			// 42/auctioneer,["new bid",{"player":{"id":219657,"name":"Payback","link":"https://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"},"sum":5000,"price":6000,"bids":5,"auctionId":"42894"}]
-			// 42/auctioneer,["new auction",{"info":"<span style=\"color:#99CC00;\"><b>approx. 35m</b></span> remaining until the auction ends","item":{"uuid":"0968999df2fe956aa4a07aea74921f860af7d97f","image":"55d4b1750985e4843023d7d0acd2b9bafb15f0b7","rarity":"rare"},"oldAuction":{"item":{"uuid":"3c9f85221807b8d593fa5276cdf7af9913c4a35d","imageSmall":"286f3eaf6072f55d8858514b159d1df5f16a5654","rarity":"common"},"time":"20.05.2021 08:42:07","bids":5,"sum":5000,"player":{"id":219657,"name":"Payback","link":"http://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"}},"auctionId":42895}]
-			// 42/auctioneer,["auction finished",{"sum":5000,"player":{"id":219657,"name":"Payback","link":"http://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"},"bids":5,"info":"Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">1072</span>","time":"08:42"}]
-			parts := strings.SplitN(buf, ",", 2)
-			msg := parts[1]
-			var pck any = msg
-			var out []any
-			_ = json.Unmarshal([]byte(msg), &out)
-			if len(out) == 0 {
-				b.error("unknown message received:", buf)
-				continue
-			}
-			if name, ok := out[0].(string); ok {
-				arg := out[1]
-				if name == "new bid" {
-					if firstArg, ok := arg.(map[string]any); ok {
-						auctionID := utils.DoParseI64(utils.DoCastStr(firstArg["auctionId"]))
-						pck1 := ogame.AuctioneerNewBid{
-							Sum:       int64(utils.DoCastF64(firstArg["sum"])),
-							Price:     int64(utils.DoCastF64(firstArg["price"])),
-							Bids:      int64(utils.DoCastF64(firstArg["bids"])),
-							AuctionID: auctionID,
-						}
-						if player, ok := firstArg["player"].(map[string]any); ok {
-							pck1.Player.ID = int64(utils.DoCastF64(player["id"]))
-							pck1.Player.Name = utils.DoCastStr(player["name"])
-							pck1.Player.Link = utils.DoCastStr(player["link"])
-						}
-						pck = pck1
-					}
-				} else if name == "timeLeft" {
-					if timeLeftMsg, ok := arg.(string); ok {
-						if strings.Contains(timeLeftMsg, "color:") {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("b").Text())
-							approx := utils.DoParseI64(txt)
-							pck = ogame.AuctioneerTimeRemaining{Approx: approx * 60}
-						} else if strings.Contains(timeLeftMsg, "nextAuction") {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("span").Text())
-							secs := utils.DoParseI64(txt)
-							pck = ogame.AuctioneerNextAuction{Secs: secs}
-						}
-					}
-				} else if name == "new auction" {
-					if firstArg, ok := arg.(map[string]any); ok {
-						pck1 := ogame.AuctioneerNewAuction{
-							AuctionID: int64(utils.DoCastF64(firstArg["auctionId"])),
-						}
-						if infoMsg, ok := firstArg["info"].(string); ok {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(infoMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("b").Text())
-							approx := utils.DoParseI64(txt)
-							pck1.Approx = approx * 60
-						}
-						pck = pck1
-					}
-				} else if name == "auction finished" {
-					if firstArg, ok := arg.(map[string]any); ok {
-						pck1 := ogame.AuctioneerAuctionFinished{
-							Sum:  int64(utils.DoCastF64(firstArg["sum"])),
-							Bids: int64(utils.DoCastF64(firstArg["bids"])),
-						}
-						if player, ok := firstArg["player"].(map[string]any); ok {
-							pck1.Player.ID = int64(utils.DoCastF64(player["id"]))
-							pck1.Player.Name = utils.DoCastStr(player["name"])
-							pck1.Player.Link = utils.DoCastStr(player["link"])
-						}
-						pck = pck1
-					}
-				}
-			}
+		}).
+		OnAuctioneer(func(pck any) {
 			for _, clb := range b.auctioneerCallbacks {
 				clb(pck)
 			}
-		} else {
-			b.error("unknown message received:", buf)
-			time.Sleep(time.Second)
-		}
-	}
-}
-
-func (b *OGame) connectChatV7(chatRetry *exponentialBackoff.ExponentialBackoff, host, port string) {
-	req, err := http.NewRequest(http.MethodGet, "https://"+host+":"+port+"/socket.io/1/?t="+utils.FI64(time.Now().UnixNano()/int64(time.Millisecond)), nil)
-	if err != nil {
-		b.error("failed to create request:", err)
-		return
-	}
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		b.error("failed to get socket.io token:", err)
-		return
-	}
-	defer resp.Body.Close()
-	chatRetry.Reset()
-	by, _ := ioutil.ReadAll(resp.Body)
-	token := strings.Split(string(by), ":")[0]
-
-	origin := "https://" + host + ":" + port + "/"
-	wssURL := "wss://" + host + ":" + port + "/socket.io/1/websocket/" + token
-	b.ws, err = websocket.Dial(wssURL, "", origin)
-	if err != nil {
-		b.error("failed to dial websocket:", err)
-		return
-	}
-
-	// Recv msgs
-LOOP:
-	for {
-		select {
-		case <-b.closeChatCh:
-			break LOOP
-		default:
-		}
-
-		var buf = make([]byte, 1024*1024)
-		if err := b.ws.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
-			b.error("failed to set read deadline:", err)
-		}
-		n, err := b.ws.Read(buf)
-		if err != nil {
-			if err == io.EOF {
-				b.error("chat eof:", err)
-				break
-			} else if strings.HasSuffix(err.Error(), "use of closed network connection") {
-				break
-			} else if strings.HasSuffix(err.Error(), "i/o timeout") {
-				continue
-			} else {
-				b.error("chat unexpected error", err)
-				// connection reset by peer
-				break
-			}
-		}
-		for _, clb := range b.wsCallbacks {
-			go clb(buf[0:n])
-		}
-		msg := bytes.Trim(buf, "\x00")
-		if bytes.Equal(msg, []byte("1::")) {
-			_, _ = b.ws.Write([]byte("1::/chat"))       // subscribe to chat events
-			_, _ = b.ws.Write([]byte("1::/auctioneer")) // subscribe to auctioneer events
-		} else if bytes.Equal(msg, []byte("1::/chat")) {
-			authMsg := `5:` + utils.FI64(b.sessionChatCounter) + `+:/chat:{"name":"authorize","args":["` + b.ogameSession + `"]}`
-			_, _ = b.ws.Write([]byte(authMsg))
-			b.sessionChatCounter++
-		} else if bytes.Equal(msg, []byte("2::")) {
-			_, _ = b.ws.Write([]byte("2::"))
-		} else if regexp.MustCompile(`\d+::/auctioneer`).Match(msg) {
-			// 5::/auctioneer:{"name":"timeLeft","args":["Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">598</span>"]}
-			// 5::/auctioneer:{"name":"timeLeft","args":["<span style=\"color:#FFA500;\"><b>approx. 10m</b></span> remaining until the auction ends"]} // every minute
-			// 5::/auctioneer:{"name":"new auction","args":[{"info":"<span style=\"color:#99CC00;\"><b>approx. 45m</b></span> remaining until the auction ends","item":{"uuid":"118d34e685b5d1472267696d1010a393a59aed03","image":"bdb4508609de1df58bf4a6108fff73078c89f777","rarity":"rare"},"oldAuction":{"item":{"uuid":"8a4f9e8309e1078f7f5ced47d558d30ae15b4a1b","imageSmall":"014827f6d1d5b78b1edd0d4476db05639e7d9367","rarity":"rare"},"time":"06.01.2021 17:35:05","bids":1,"sum":1000,"player":{"id":111106,"name":"Governor Skat","link":"http://s152-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=1&system=218"}},"auctionId":18550}]}
-			// 5::/auctioneer:{"name":"new bid","args":[{"player":{"id":106734,"name":"Someone","link":"https://s152-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=4&system=116"},"sum":2000,"price":3000,"bids":2,"auctionId":"13355"}]}
-			// 5::/auctioneer:{"name":"auction finished","args":[{"sum":2000,"player":{"id":106734,"name":"Someone","link":"http://s152-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=4&system=116"},"bids":2,"info":"Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">1390</span>","time":"06:36"}]}
-			msg = bytes.TrimPrefix(msg, []byte("5::/auctioneer:"))
-			var pck any = string(msg)
-			var out map[string]any
-			_ = json.Unmarshal(msg, &out)
-			if args, ok := out["args"].([]any); ok {
-				if len(args) > 0 {
-					if name, ok := out["name"].(string); ok && name == "new bid" {
-						if firstArg, ok := args[0].(map[string]any); ok {
-							auctionID := utils.DoParseI64(utils.DoCastStr(firstArg["auctionId"]))
-							pck1 := ogame.AuctioneerNewBid{
-								Sum:       int64(utils.DoCastF64(firstArg["sum"])),
-								Price:     int64(utils.DoCastF64(firstArg["price"])),
-								Bids:      int64(utils.DoCastF64(firstArg["bids"])),
-								AuctionID: auctionID,
-							}
-							if player, ok := firstArg["player"].(map[string]any); ok {
-								pck1.Player.ID = int64(utils.DoCastF64(player["id"]))
-								pck1.Player.Name = utils.DoCastStr(player["name"])
-								pck1.Player.Link = utils.DoCastStr(player["link"])
-							}
-							pck = pck1
-						}
-					} else if name, ok := out["name"].(string); ok && name == "timeLeft" {
-						if timeLeftMsg, ok := args[0].(string); ok {
-							if strings.Contains(timeLeftMsg, "color:") {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("b").Text())
-								approx := utils.DoParseI64(txt)
-								pck = ogame.AuctioneerTimeRemaining{Approx: approx * 60}
-							} else if strings.Contains(timeLeftMsg, "nextAuction") {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("span").Text())
-								secs := utils.DoParseI64(txt)
-								pck = ogame.AuctioneerNextAuction{Secs: secs}
-							}
-						}
-					} else if name, ok := out["name"].(string); ok && name == "new auction" {
-						if firstArg, ok := args[0].(map[string]any); ok {
-							pck1 := ogame.AuctioneerNewAuction{
-								AuctionID: int64(utils.DoCastF64(firstArg["auctionId"])),
-							}
-							if infoMsg, ok := firstArg["info"].(string); ok {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(infoMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("b").Text())
-								approx := utils.DoParseI64(txt)
-								pck1.Approx = approx * 60
-							}
-							pck = pck1
-						}
-					} else if name, ok := out["name"].(string); ok && name == "auction finished" {
-						if firstArg, ok := args[0].(map[string]any); ok {
-							pck1 := ogame.AuctioneerAuctionFinished{
-								Sum:  int64(utils.DoCastF64(firstArg["sum"])),
-								Bids: int64(utils.DoCastF64(firstArg["bids"])),
-							}
-							if player, ok := firstArg["player"].(map[string]any); ok {
-								pck1.Player.ID = int64(utils.DoCastF64(player["id"]))
-								pck1.Player.Name = utils.DoCastStr(player["name"])
-								pck1.Player.Link = utils.DoCastStr(player["link"])
-							}
-							pck = pck1
-						}
-					}
-				}
-			}
-			for _, clb := range b.auctioneerCallbacks {
-				clb(pck)
-			}
-		} else if regexp.MustCompile(`6::/chat:\d+\+\[true]`).Match(msg) {
-			b.debug("chat connected")
-		} else if regexp.MustCompile(`6::/chat:\d+\+\[false]`).Match(msg) {
-			b.error("Failed to connect to chat")
-		} else if bytes.HasPrefix(msg, []byte("5::/chat:")) {
-			payload := bytes.TrimPrefix(msg, []byte("5::/chat:"))
-			var chatPayload ogame.ChatPayload
-			if err := json.Unmarshal(payload, &chatPayload); err != nil {
-				b.error("Unable to unmarshal chat payload", err, payload)
-				continue
-			}
-			for _, chatMsg := range chatPayload.Args {
-				for _, clb := range b.chatCallbacks {
-					clb(chatMsg)
-				}
-			}
-		} else {
-			b.error("unknown message received:", string(buf))
-			time.Sleep(time.Second)
-		}
-	}
+		}).
+		OnError(func(err error) {
+			b.error(err)
+		})
 }
 
 // ReconnectChat ...
 func (b *OGame) ReconnectChat() bool {
-	if b.ws == nil {
+	if b.chatClient == nil {
 		return false
 	}
-	_ = websocket.Message.Send(b.ws, "1::/chat")
-	return true
+	return b.chatClient.Reconnect()
 }
 
 func (b *OGame) logout() {
@@ -1303,8 +979,8 @@ func (b *OGame) logout() {
 		case <-b.closeChatCh:
 		default:
 			close(b.closeChatCh)
-			if b.ws != nil {
-				_ = b.ws.Close()
+			if b.chatClient != nil {
+				b.chatClient.Close()
 			}
 		}
 	}
@@ -1815,7 +1491,7 @@ func (b *OGame) getCelestial(v any) (Celestial, error) {
 }
 
 func (b *OGame) recruitOfficer(typ, days int64) error {
-	if typ != 2 && typ != 3 && typ != 4 && typ != 5 && typ != 6 {
+	if typ != 2 && typ != 3 && typ != 4 && typ != 5 && typ != 6 && typ != 12 {
 		return errors.New("invalid officer type")
 	}
 	if days != 7 && days != 90 {
@@ -1837,6 +1513,138 @@ func (b *OGame) recruitOfficer(typ, days int64) error {
 	return nil
 }
 
+func (b *OGame) getCachedOfficers() ogame.Officers {
+	return b.officers.Elapse(time.Since(b.officersUpdatedAt))
+}
+
+func (b *OGame) getOfficers() (ogame.Officers, error) {
+	if _, err := getPage[parser.OverviewPage](b); err != nil {
+		return ogame.Officers{}, err
+	}
+	return b.getCachedOfficers(), nil
+}
+
+func (b *OGame) getCharacterClassSelection() (ogame.CharacterClassSelection, error) {
+	page, err := getPage[parser.CharacterClassSelectionPage](b)
+	if err != nil {
+		return ogame.CharacterClassSelection{}, err
+	}
+	return page.ExtractCharacterClassSelection()
+}
+
+// characterClassActionVals returns the parameters of the select/deselect character class endpoints,
+// the same as selectCharacterClassEndpoint/deselectCharacterClassEndpoint of the technology details script
+func characterClassActionVals(class ogame.CharacterClass, action string) url.Values {
+	return url.Values{"page": {"ingame"}, "component": {CharacterClassSelectionPageName},
+		"characterClassId": {utils.FI64(class)}, "action": {action}, "ajax": {"1"}, "asJson": {"1"}}
+}
+
+// classSelectionAction post a select/deselect action to the character/alliance class selection
+func (b *OGame) classSelectionAction(vals url.Values, token string) error {
+	by, err := b.postPageContent(vals, url.Values{"token": {token}})
+	if err != nil {
+		return err
+	}
+	var res traderResponse
+	if err := json.Unmarshal(by, &res); err != nil {
+		return err
+	}
+	if res.Error {
+		return errors.New(res.Message)
+	}
+	return nil
+}
+
+// checkDarkMatter returns ogame.ErrNotEnoughDarkMatter if the account cannot pay price
+func (b *OGame) checkDarkMatter(price int64) error {
+	if price <= 0 {
+		return nil
+	}
+	details, err := b.fetchResources(0)
+	if err != nil {
+		return err
+	}
+	if details.Darkmatter.Available < price {
+		return ogame.ErrNotEnoughDarkMatter
+	}
+	return nil
+}
+
+func (b *OGame) selectCharacterClass(class ogame.CharacterClass) error {
+	if !class.IsCollector() && !class.IsGeneral() && !class.IsDiscoverer() {
+		return ogame.ErrInvalidCharacterClass
+	}
+	selection, err := b.getCharacterClassSelection()
+	if err != nil {
+		return err
+	}
+	if selection.Current == class {
+		return nil
+	}
+	option, ok := selection.Option(class)
+	if !ok {
+		return ogame.ErrInvalidCharacterClass
+	}
+	if err := b.checkDarkMatter(option.Price); err != nil {
+		return err
+	}
+	if err := b.classSelectionAction(characterClassActionVals(class, "selectClass"), selection.Token); err != nil {
+		return err
+	}
+	b.characterClass = class
+	return nil
+}
+
+func (b *OGame) deselectCharacterClass() error {
+	selection, err := b.getCharacterClassSelection()
+	if err != nil {
+		return err
+	}
+	if selection.Current == ogame.NoClass {
+		return nil
+	}
+	if err := b.classSelectionAction(characterClassActionVals(selection.Current, "deselectClass"), selection.Token); err != nil {
+		return err
+	}
+	b.characterClass = ogame.NoClass
+	return nil
+}
+
+func (b *OGame) getAllianceClassSelection() (ogame.AllianceClassSelection, error) {
+	vals := url.Values{"page": {"ingame"}, "component": {AlliancePageName}, "tab": {"classselection"}, "ajax": {"1"}}
+	pageHTML, err := b.getPageContent(vals)
+	if err != nil {
+		return ogame.AllianceClassSelection{}, err
+	}
+	return b.extractor.ExtractAllianceClassSelection(pageHTML)
+}
+
+func (b *OGame) selectAllianceClass(class ogame.AllianceClass) error {
+	if !class.IsWarrior() && !class.IsTrader() && !class.IsResearcher() {
+		return ogame.ErrInvalidAllianceClass
+	}
+	selection, err := b.getAllianceClassSelection()
+	if err != nil {
+		return err
+	}
+	if selection.Current == class {
+		return nil
+	}
+	if !selection.CanChange {
+		return ogame.ErrNotAllianceFounder
+	}
+	option, ok := selection.Option(class)
+	if !ok {
+		return ogame.ErrInvalidAllianceClass
+	}
+	if err := b.checkDarkMatter(option.Price); err != nil {
+		return err
+	}
+	vals := url.Values{"page": {"ingame"}, "component": {AlliancePageName},
+		"allianceClassId": {utils.FI64(class)}, "action": {"selectClass"}, "ajax": {"1"}, "asJson": {"1"}}
+	return b.classSelectionAction(vals, selection.Token)
+}
+
 func (b *OGame) abandon(v any) error {
 	page, err := getPage[parser.OverviewPage](b)
 	if err != nil {
@@ -1864,6 +1672,150 @@ func (b *OGame) abandon(v any) error {
 	return err
 }
 
+func (b *OGame) getPlanetLayer(celestialID ogame.CelestialID) (ogame.PlanetLayer, error) {
+	overview, err := getPage[parser.OverviewPage](b, ChangePlanet(celestialID))
+	if err != nil {
+		return ogame.PlanetLayer{}, err
+	}
+	relocation, err := overview.ExtractRelocation()
+	if err != nil {
+		return ogame.PlanetLayer{}, err
+	}
+	pageHTML, err := b.getPage(PlanetlayerPageName, ChangePlanet(celestialID))
+	if err != nil {
+		return ogame.PlanetLayer{}, err
+	}
+	layer, err := b.extractor.ExtractPlanetLayer(pageHTML)
+	if err != nil {
+		return ogame.PlanetLayer{}, err
+	}
+	layer.Relocation = relocation
+	return layer, nil
+}
+
+// ajaxStatusResponse parse the json response of actions answering with a status and an errorbox (rename, relocate, lifeform settings)
+// {"status":false,"errorbox":{"type":"fadeBox","text":"...","failed":1}}
+func ajaxStatusResponse(by []byte) error {
+	var res struct {
+		Status   bool
+		Errorbox struct {
+			Type   string
+			Text   string
+			Failed int64
+		}
+	}
+	if err := json.Unmarshal(by, &res); err != nil {
+		return err
+	}
+	if !res.Status || res.Errorbox.Failed != 0 {
+		return errors.New(res.Errorbox.Text)
+	}
+	return nil
+}
+
+func (b *OGame) renameCelestial(celestialID ogame.CelestialID, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if nameLen := utf8.RuneCountInString(newName); nameLen < 2 || nameLen > 20 {
+		return ogame.ErrInvalidPlanetName
+	}
+	layer, err := b.getPlanetLayer(celestialID)
+	if err != nil {
+		return err
+	}
+	if layer.Name == newName {
+		return nil
+	}
+	// Same payload as the rename form of the planet layer, which only holds the new name
+	payload := url.Values{"newPlanetName": {newName}}
+	by, err := b.postPageContent(url.Values{"page": {PlanetRenameAjaxPageName}, "ajax": {"1"}}, payload, ChangePlanet(celestialID))
+	if err != nil {
+		return err
+	}
+	return ajaxStatusResponse(by)
+}
+
+// relocatePlanet reserves the relocation with the prepareMove request of the relocate link of the galaxy
+// (page=planetMove&action=prepareMove&galaxy=..&system=..&position=..&ajax=1).
+// This is the only request sent: the game moves the planet itself when the 24h countdown ends.
+// Whether the game asks for a confirmation request after prepareMove is not verified.
+func (b *OGame) relocatePlanet(celestialID ogame.CelestialID, coord ogame.Coordinate) error {
+	celestial, err := b.getCelestial(celestialID)
+	if err != nil {
+		return err
+	}
+	if celestial.GetType() != ogame.PlanetType {
+		return ogame.ErrRelocationNotPossible
+	}
+	if celestial.GetCoordinate().Equal(coord.Planet()) {
+		return ogame.ErrRelocationSamePosition
+	}
+	layer, err := b.getPlanetLayer(celestialID)
+	if err != nil {
+		return err
+	}
+	if !layer.Relocation.Possible {
+		return ogame.ErrRelocationNotPossible
+	}
+	if layer.Relocation.InProgress {
+		return ogame.ErrRelocationInProgress
+	}
+	if layer.Relocation.Cooldown > 0 {
+		return ogame.ErrRelocationCooldown
+	}
+	if err := b.checkDarkMatter(layer.Relocation.Cost); err != nil {
+		return err
+	}
+	params := url.Values{
+		"page":     {"planetMove"},
+		"action":   {"prepareMove"},
+		"galaxy":   {utils.FI64(coord.Galaxy)},
+		"system":   {utils.FI64(coord.System)},
+		"position": {utils.FI64(coord.Position)},
+		"ajax":     {"1"},
+	}
+	by, err := b.postPageContent(params, url.Values{"token": {layer.Token}}, ChangePlanet(celestialID))
+	if err != nil {
+		return err
+	}
+	return ajaxStatusResponse(by)
+}
+
+// relocationFleets returns the fleets sent from, or heading to, the planet at coord
+func relocationFleets(fleets []ogame.Fleet, coord ogame.Coordinate) []ogame.FleetID {
+	out := make([]ogame.FleetID, 0)
+	for _, fleet := range fleets {
+		if fleet.Origin.Equal(coord) || fleet.Destination.Equal(coord) {
+			out = append(out, fleet.ID)
+		}
+	}
+	return out
+}
+
+func (b *OGame) getRelocationStatus(celestialID ogame.CelestialID) (ogame.RelocationStatus, error) {
+	status := ogame.RelocationStatus{CelestialID: celestialID}
+	celestial, err := b.getCelestial(celestialID)
+	if err != nil {
+		return status, err
+	}
+	layer, err := b.getPlanetLayer(celestialID)
+	if err != nil {
+		return status, err
+	}
+	status.PlanetRelocation = layer.Relocation
+	// getFleets hides the page errors, an unknown movement must not be reported as no fleet in flight
+	movement, err := getPage[parser.MovementPage](b)
+	if err != nil {
+		return status, err
+	}
+	status.FleetsInFlight = relocationFleets(movement.ExtractFleets(), celestial.GetCoordinate())
+	status.BuildingID, status.BuildingCountdown, status.ResearchID, status.ResearchCountdown,
+		status.LfBuildingID, status.LfBuildingCountdown, status.LfResearchID, status.LfResearchCountdown = b.constructionsBeingBuilt(celestialID)
+	if _, status.ShipyardCountdown, err = b.getProduction(celestialID); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
 func (b *OGame) serverTime() time.Time {
 	page, err := getPage[parser.OverviewPage](b)
 	serverTime, err := page.ExtractServerTime()
@@ -1922,9 +1874,75 @@ func (b *OGame) sendMessage(id int64, message string, isPlayer bool) error {
 		return err
 	}
 	b.ajaxChatToken = res.NewToken
+	conversation := ogame.Conversation{PlayerID: id}
+	if !isPlayer {
+		conversation = ogame.Conversation{AssociationID: id}
+	}
+	b.conversations.add(conversation, ogame.ConversationMessage{
+		ID:         int64(res.ID),
+		SenderID:   int64(res.SenderID),
+		SenderName: b.Player.PlayerName,
+		Text:       res.Text,
+		Date:       time.Unix(res.Date, 0),
+	})
 	return nil
 }
 
+// receiveChatMsg stores a message received through the chat websocket and calls the conversation callbacks
+func (b *OGame) receiveChatMsg(chatMsg ogame.ChatMsg) {
+	if chatMsg.SenderID == b.playerID && chatMsg.AssociationID == 0 {
+		return // Our own message sent from somewhere else, the recipient is unknown
+	}
+	msg := chatMsg.ConversationMessage()
+	conversation := b.conversations.add(conversationFromChatMsg(chatMsg), msg)
+	for _, clb := range b.conversationCallbacks {
+		clb(conversation, msg)
+	}
+}
+
+func (b *OGame) getConversations() ([]ogame.Conversation, error) {
+	pageHTML, err := b.getPageContent(url.Values{"page": {"chat"}})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ogame.Conversation, 0)
+	for _, c := range b.extractor.ExtractConversations(pageHTML) {
+		out = append(out, b.conversations.add(c))
+	}
+	return out, nil
+}
+
+func (b *OGame) getConversation(playerID int64) (ogame.Conversation, error) {
+	pageHTML, err := b.getPageContent(url.Values{"page": {"chat"}, "playerId": {utils.FI64(playerID)}})
+	if err != nil {
+		return ogame.Conversation{}, err
+	}
+	msgs := b.extractor.ExtractConversationMessages(pageHTML, b.playerID)
+	return b.conversations.add(ogame.Conversation{PlayerID: playerID}, msgs...), nil
+}
+
+// getAllianceConversation reads the alliance conversation from the chat bar of the overview page.
+// The chat bar only holds the last messages, the older ones are not loaded.
+func (b *OGame) getAllianceConversation(associationID int64) (ogame.Conversation, error) {
+	pageHTML, err := b.getPage(OverviewPageName)
+	if err != nil {
+		return ogame.Conversation{}, err
+	}
+	for _, c := range b.extractor.ExtractChatBarConversations(pageHTML, b.playerID) {
+		if c.AssociationID == associationID {
+			return b.conversations.add(c), nil
+		}
+	}
+	return b.conversations.add(ogame.Conversation{AssociationID: associationID}), nil
+}
+
+func (b *OGame) replyToConversation(conversation ogame.Conversation, message string) error {
+	if conversation.IsAlliance() {
+		return b.sendMessage(conversation.AssociationID, message, false)
+	}
+	return b.sendMessage(conversation.PlayerID, message, true)
+}
+
 func (b *OGame) getFleetsFromEventList() []ogame.Fleet {
 	pageHTML, _ := b.getPageContent(url.Values{"eventList": {"movement"}, "ajax": {"1"}})
 	return b.extractor.ExtractFleetsFromEventList(pageHTML)
@@ -2006,13 +2024,13 @@ func Distance(c1, c2 ogame.Coordinate, universeSize, nbSystems int64, donutGalax
 	return 5
 }
 
-func findSlowestSpeed(ships ogame.ShipsInfos, techs ogame.Researches, isCollector, isGeneral bool) int64 {
+func findSlowestSpeed(ships ogame.ShipsInfos, techs ogame.Researches, isCollector, isGeneral bool, bonuses ogame.LfBonuses) int64 {
 	var minSpeed int64 = math.MaxInt64
 	for _, ship := range ogame.Ships {
 		if ship.GetID() == ogame.SolarSatelliteID || ship.GetID() == ogame.CrawlerID {
 			continue
 		}
-		shipSpeed := ship.GetSpeed(techs, isCollector, isGeneral)
+		shipSpeed := bonuses.Speed(ship, techs, isCollector, isGeneral)
 		if ships.ByID(ship.GetID()) > 0 && shipSpeed < minSpeed {
 			minSpeed = shipSpeed
 		}
@@ -2020,7 +2038,7 @@ func findSlowestSpeed(ships ogame.ShipsInfos, techs ogame.Researches, isCollecto
 	return minSpeed
 }
 
-func calcFuel(ships ogame.ShipsInfos, dist, duration int64, universeSpeedFleet, fleetDeutSaveFactor float64, techs ogame.Researches, isCollector, isGeneral bool) (fuel int64) {
+func calcFuel(ships ogame.ShipsInfos, dist, duration int64, universeSpeedFleet, fleetDeutSaveFactor float64, techs ogame.Researches, isCollector, isGeneral bool, bonuses ogame.LfBonuses) (fuel int64) {
 	tmpFn := func(baseFuel, nbr, shipSpeed int64) float64 {
 		tmpSpeed := (35000 / (float64(duration)*universeSpeedFleet - 10)) * math.Sqrt(float64(dist)*10/float64(shipSpeed))
 		return float64(baseFuel*nbr*dist) / 35000 * math.Pow(tmpSpeed/10+1, 2)
@@ -2032,31 +2050,40 @@ func calcFuel(ships ogame.ShipsInfos, dist, duration int64, universeSpeedFleet,
 		}
 		nbr := ships.ByID(ship.GetID())
 		if nbr > 0 {
-			tmpFuel += tmpFn(ship.GetFuelConsumption(techs, fleetDeutSaveFactor, isGeneral), nbr, ship.GetSpeed(techs, isCollector, isGeneral))
+			tmpFuel += tmpFn(bonuses.FuelConsumption(ship, techs, fleetDeutSaveFactor, isGeneral), nbr, bonuses.Speed(ship, techs, isCollector, isGeneral))
 		}
 	}
 	fuel = int64(1 + math.Round(tmpFuel))
 	return
 }
 
-// CalcFlightTime ...
+// CalcFlightTime calculates the flight time and the fuel consumption without the lifeform bonuses,
+// see CalcLfFlightTime to apply the bonuses of the origin planet.
 func CalcFlightTime(origin, destination ogame.Coordinate, universeSize, nbSystems int64, donutGalaxy, donutSystem bool,
 	fleetDeutSaveFactor, speed float64, universeSpeedFleet int64, ships ogame.ShipsInfos, techs ogame.Researches, characterClass ogame.CharacterClass) (secs, fuel int64) {
+	return CalcLfFlightTime(origin, destination, universeSize, nbSystems, donutGalaxy, donutSystem, fleetDeutSaveFactor, speed,
+		universeSpeedFleet, ships, techs, characterClass, ogame.LfBonuses{})
+}
+
+// CalcLfFlightTime same as CalcFlightTime with the lifeform bonuses of the origin planet applied to the ships speed and fuel
+func CalcLfFlightTime(origin, destination ogame.Coordinate, universeSize, nbSystems int64, donutGalaxy, donutSystem bool,
+	fleetDeutSaveFactor, speed float64, universeSpeedFleet int64, ships ogame.ShipsInfos, techs ogame.Researches, characterClass ogame.CharacterClass,
+	bonuses ogame.LfBonuses) (secs, fuel int64) {
 	if !ships.HasShips() {
 		return
 	}
 	isCollector := characterClass == ogame.Collector
 	isGeneral := characterClass == ogame.General
 	s := speed
-	v := float64(findSlowestSpeed(ships, techs, isCollector, isGeneral))
+	v := float64(findSlowestSpeed(ships, techs, isCollector, isGeneral, bonuses))
 	a := float64(universeSpeedFleet)
 	d := float64(Distance(origin, destination, universeSize, nbSystems, donutGalaxy, donutSystem))
 	secs = int64(math.Round(((3500/s)*math.Sqrt(d*10/v) + 10) / a))
-	fuel = calcFuel(ships, int64(d), secs, float64(universeSpeedFleet), fleetDeutSaveFactor, techs, isCollector, isGeneral)
+	fuel = calcFuel(ships, int64(d), secs, float64(universeSpeedFleet), fleetDeutSaveFactor, techs, isCollector, isGeneral, bonuses)
 	return
 }
 
-// CalcFlightTime calculates the flight time and the fuel consumption
+// CalcFlightTime calculates the flight time and the fuel consumption, without the lifeform bonuses
 func (b *OGame) CalcFlightTime(origin, destination ogame.Coordinate, speed float64, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64) {
 	return CalcFlightTime(origin, destination, b.serverData.Galaxies, b.serverData.Systems, b.serverData.DonutGalaxy,
 		b.serverData.DonutSystem, b.serverData.GlobalDeuteriumSaveFactor, speed, GetFleetSpeedForMission(b.serverData, missionID), ships,
@@ -2561,15 +2588,20 @@ func (b *OGame) activateItem(ref string, celestialID ogame.CelestialID) error {
 		"referrerPage": {"ingame"},
 		"item":         {ref},
 	}
+	by, err := b.postPageContent(params, payload)
+	if err != nil {
+		return err
+	}
+	return inventoryResponse(by)
+}
+
+// inventoryResponse checks the json response of the inventory/shop actions
+func inventoryResponse(by []byte) error {
 	var res struct {
 		Message  any    `json:"message"`
 		Error    bool   `json:"error"`
 		NewToken string `json:"newToken"`
 	}
-	by, err := b.postPageContent(params, payload)
-	if err != nil {
-		return err
-	}
 	if err := json.Unmarshal(by, &res); err != nil {
 		return err
 	}
@@ -2579,7 +2611,36 @@ func (b *OGame) activateItem(ref string, celestialID ogame.CelestialID) error {
 		}
 		return errors.New("unknown error")
 	}
-	return err
+	return nil
+}
+
+// buyItem buys an item from the dark matter shop, the item is added to the inventory
+func (b *OGame) buyItem(ref string, celestialID ogame.CelestialID) error {
+	params := url.Values{"page": {"buffActivation"}, "ajax": {"1"}, "type": {"1"}}
+	pageHTML, _ := b.getPageContent(params, ChangePlanet(celestialID))
+	token, items, err := b.extractor.ExtractBuffActivation(pageHTML)
+	if err != nil {
+		return err
+	}
+	item, ok := ogame.ItemCatalog(items).ByRef(ref)
+	if !ok {
+		return ogame.ErrItemNotFound
+	}
+	if !item.Buyable {
+		return ogame.ErrItemNotBuyable
+	}
+	params = url.Values{"page": {"buyitem"}}
+	payload := url.Values{
+		"ajax":         {"1"},
+		"token":        {token},
+		"referrerPage": {"shop"},
+		"item":         {ref},
+	}
+	by, err := b.postPageContent(params, payload)
+	if err != nil {
+		return err
+	}
+	return inventoryResponse(by)
 }
 
 func (b *OGame) getAuction(celestialID ogame.CelestialID) (ogame.Auction, error) {
@@ -2599,7 +2660,7 @@ func (b *OGame) doAuction(celestialID ogame.CelestialID, bid map[ogame.Celestial
 	}
 
 	if auction.HasFinished {
-		return errors.New("auction completed")
+		return ErrAuctionFinished
 	}
 
 	payload := url.Values{}
@@ -2747,6 +2808,166 @@ func (b *OGame) buyOfferOfTheDay() error {
 	return nil
 }
 
+func (b *OGame) getResourceTrader(celestialID ogame.CelestialID) (ogame.ResourceTrader, error) {
+	pageHTML, err := b.postPageContent(url.Values{"page": {"ajax"}, "component": {"traderresources"}}, url.Values{"show": {"resources"}, "ajax": {"1"}}, ChangePlanet(celestialID))
+	if err != nil {
+		return ogame.ResourceTrader{}, err
+	}
+	return b.extractor.ExtractResourceTrader(pageHTML)
+}
+
+// traderResponse json answer of the trader ajax actions
+type traderResponse struct {
+	Message      string
+	Error        bool
+	NewAjaxToken string
+}
+
+func postTraderAction(b *OGame, component, action string, payload url.Values) error {
+	by, err := b.postPageContent(url.Values{"page": {"ajax"}, "component": {component}, "ajax": {"1"}, "action": {action}, "asJson": {"1"}}, payload)
+	if err != nil {
+		return err
+	}
+	var res traderResponse
+	if err := json.Unmarshal(by, &res); err != nil {
+		return err
+	}
+	if res.Error {
+		return errors.New(res.Message)
+	}
+	return nil
+}
+
+func (b *OGame) exchangeResources(celestialID ogame.CelestialID, give ogame.Resources, receive ogame.TradeResource) error {
+	switch receive {
+	case ogame.TradeMetal:
+		give.Metal = 0
+	case ogame.TradeCrystal:
+		give.Crystal = 0
+	case ogame.TradeDeuterium:
+		give.Deuterium = 0
+	default:
+		return errors.New("invalid resource to receive")
+	}
+	if give.Metal < 0 || give.Crystal < 0 || give.Deuterium < 0 || give.Total() == 0 {
+		return errors.New("nothing to exchange")
+	}
+	trader, err := b.getResourceTrader(celestialID)
+	if err != nil {
+		return err
+	}
+	payload := url.Values{
+		"resourceType":    {utils.FI64(receive)},
+		"give[metal]":     {utils.FI64(give.Metal)},
+		"give[crystal]":   {utils.FI64(give.Crystal)},
+		"give[deuterium]": {utils.FI64(give.Deuterium)},
+		"token":           {trader.Token},
+		"ajax":            {"1"},
+	}
+	if celestialID != 0 {
+		payload.Set("cp", utils.FI64(celestialID))
+	}
+	return postTraderAction(b, "traderresources", "trade", payload)
+}
+
+func (b *OGame) getScrapMerchant(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	pageHTML, err := b.postPageContent(url.Values{"page": {"ajax"}, "component": {"traderscrap"}}, url.Values{"show": {"scrap"}, "ajax": {"1"}}, ChangePlanet(celestialID))
+	if err != nil {
+		return ogame.ScrapMerchant{}, err
+	}
+	return b.extractor.ExtractScrapMerchant(pageHTML)
+}
+
+// bargainScrap pays dark matter to raise the scrap merchant percentage once, and returns the new offer
+func (b *OGame) bargainScrap(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	merchant, err := b.getScrapMerchant(celestialID)
+	if err != nil {
+		return merchant, err
+	}
+	if !merchant.CanBargain() {
+		return merchant, ogame.ErrScrapMaxPercentage
+	}
+	if err := postTraderAction(b, "traderscrap", "bargain", url.Values{"token": {merchant.Token}, "ajax": {"1"}}); err != nil {
+		return merchant, err
+	}
+	return b.getScrapMerchant(celestialID)
+}
+
+// maxScrapBargains bounds the bargains of a scrap, whatever the merchant page says
+const maxScrapBargains = 20
+
+// shouldBargainScrap returns true if the merchant percentage is under minPercentage
+// and a bargain fits in the dark matter budget. A bargain with an unknown cost is never paid.
+func shouldBargainScrap(merchant ogame.ScrapMerchant, minPercentage, spent, maxDarkMatter int64) (bool, error) {
+	if merchant.Percentage >= minPercentage || !merchant.CanBargain() {
+		return false, nil
+	}
+	if merchant.BargainCost <= 0 {
+		return false, ogame.ErrScrapUnknownBargainCost
+	}
+	return spent+merchant.BargainCost <= maxDarkMatter, nil
+}
+
+// scrapUnits sells ships/defenses to the scrap merchant.
+// The merchant is bargained with until the percentage reaches minPercentage, spending at most maxDarkMatter.
+// Nothing is sold if the percentage cannot reach minPercentage.
+func (b *OGame) scrapUnits(celestialID ogame.CelestialID, units []ogame.Quantifiable, minPercentage, maxDarkMatter int64) (ogame.Resources, error) {
+	merchant, err := b.getScrapMerchant(celestialID)
+	if err != nil {
+		return ogame.Resources{}, err
+	}
+	var spent int64
+	for i := 0; i < maxScrapBargains; i++ {
+		bargain, err := shouldBargainScrap(merchant, minPercentage, spent, maxDarkMatter)
+		if err != nil {
+			return ogame.Resources{}, err
+		} else if !bargain {
+			break
+		}
+		spent += merchant.BargainCost
+		if merchant, err = b.bargainScrap(celestialID); err != nil {
+			return ogame.Resources{}, err
+		}
+	}
+	if merchant.Percentage < minPercentage {
+		return ogame.Resources{}, ogame.ErrScrapPercentageTooLow
+	}
+	payload, toScrap, err := scrapPayload(units, merchant.Units)
+	if err != nil {
+		return ogame.Resources{}, err
+	}
+	payload.Set("token", merchant.Token)
+	payload.Set("ajax", "1")
+	if err := postTraderAction(b, "traderscrap", "trade", payload); err != nil {
+		return ogame.Resources{}, err
+	}
+	return merchant.Offer(toScrap), nil
+}
+
+// scrapPayload builds the units parameters of a scrap, checking they are available on the celestial
+func scrapPayload(units []ogame.Quantifiable, available map[ogame.ID]int64) (url.Values, map[ogame.ID]int64, error) {
+	toScrap := make(map[ogame.ID]int64)
+	for _, unit := range units {
+		if !unit.ID.IsShip() && !unit.ID.IsDefense() {
+			return nil, nil, errors.New("cannot scrap " + unit.ID.String())
+		}
+		if unit.Nbr > 0 {
+			toScrap[unit.ID] += unit.Nbr
+		}
+	}
+	if len(toScrap) == 0 {
+		return nil, nil, errors.New("nothing to scrap")
+	}
+	payload := url.Values{}
+	for id, nbr := range toScrap {
+		if nbr > available[id] {
+			return nil, nil, errors.New("not enough " + id.String() + " to scrap")
+		}
+		payload.Set("params["+utils.FI64(id)+"]", utils.FI64(nbr))
+	}
+	return payload, toScrap, nil
+}
+
 // Hack fix: When moon name is >12, the moon image disappear from the EventsBox
 // and attacks are detected on planet instead.
 func fixAttackEvents(attacks []ogame.AttackEvent, planets []Planet) {
@@ -2844,57 +3065,255 @@ func (b *OGame) setResourceSettings(planetID ogame.PlanetID, settings ogame.Reso
 		"last212":      {utils.FI64(settings.SolarSatellite)},
 		"last217":      {utils.FI64(settings.Crawler)},
 	}
-	url2 := b.serverURL + "/game/index.php?page=resourceSettings"
-	resp, err := b.client.PostForm(url2, payload)
+	url2 := b.serverURL + "/game/index.php?page=resourceSettings"
+	resp, err := b.client.PostForm(url2, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+func (b *OGame) getCachedResearch() ogame.Researches {
+	if b.researches == nil {
+		return b.getResearch()
+	}
+	return *b.researches
+}
+
+func (b *OGame) getResearch() ogame.Researches {
+	page, err := getPage[parser.ResearchPage](b)
+	if err != nil {
+		return ogame.Researches{}
+	}
+	researches := page.ExtractResearch()
+	b.researches = &researches
+	return researches
+}
+
+func (b *OGame) getResourcesBuildings(celestialID ogame.CelestialID, options ...Option) (ogame.ResourcesBuildings, error) {
+	options = append(options, ChangePlanet(celestialID))
+	page, err := getPage[parser.SuppliesPage](b, options...)
+	if err != nil {
+		return ogame.ResourcesBuildings{}, err
+	}
+	return page.ExtractResourcesBuildings()
+}
+
+func (b *OGame) getLfBuildings(celestialID ogame.CelestialID, options ...Option) (ogame.LfBuildings, error) {
+	options = append(options, ChangePlanet(celestialID))
+	page, err := getPage[parser.LfBuildingsPage](b, options...)
+	if err != nil {
+		return ogame.LfBuildings{}, err
+	}
+	return page.ExtractLfBuildings()
+}
+
+func (b *OGame) getLfResearch(celestialID ogame.CelestialID, options ...Option) (ogame.LfResearches, error) {
+	options = append(options, ChangePlanet(celestialID))
+	page, err := getPage[parser.LfResearchPage](b, options...)
+	if err != nil {
+		return ogame.LfResearches{}, err
+	}
+	return page.ExtractLfResearch()
+}
+
+func (b *OGame) getLfSettings(celestialID ogame.CelestialID) (ogame.LfSettings, error) {
+	if !b.extractor.GetLifeformEnabled() {
+		return ogame.LfSettings{}, ogame.ErrLifeformDisabled
+	}
+	page, err := getPage[parser.LfSettingsPage](b, ChangePlanet(celestialID))
+	if err != nil {
+		return ogame.LfSettings{}, err
+	}
+	return page.ExtractLfSettings()
+}
+
+// getLfBonuses computes the lifeform bonuses of a planet, no bonus at all when lifeforms are disabled.
+// The bonuses are cached for the calculations that only have a coordinate (see getCachedLfBonuses).
+func (b *OGame) getLfBonuses(celestialID ogame.CelestialID) (ogame.LfBonuses, error) {
+	if !b.extractor.GetLifeformEnabled() {
+		return ogame.LfBonuses{}, nil
+	}
+	lfBuildings, err := b.getLfBuildings(celestialID)
+	if err != nil {
+		return ogame.LfBonuses{}, err
+	}
+	lfResearches, err := b.getLfResearch(celestialID)
+	if err != nil {
+		return ogame.LfBonuses{}, err
+	}
+	var speciesLevel int64
+	if settings, err := b.getLfSettings(celestialID); err == nil {
+		if species, ok := settings.SpeciesByType(settings.Current); ok {
+			speciesLevel = species.Level
+		}
+	}
+	bonuses := ogame.GetLfBonuses(lfBuildings, lfResearches, speciesLevel)
+	b.lfBonusesMu.Lock()
+	if b.lfBonuses == nil {
+		b.lfBonuses = make(map[ogame.CelestialID]ogame.LfBonuses)
+	}
+	b.lfBonuses[celestialID] = bonuses
+	b.lfBonusesMu.Unlock()
+	return bonuses, nil
+}
+
+// getCachedLfBonuses returns the lifeform bonuses of the planet at coord, or of the planet of the moon at coord.
+// They are loaded the first time and cached until the next getLfBonuses of the planet.
+// No bonus when the coordinate is not one of our planets or its lifeform pages cannot be loaded.
+func (b *OGame) getCachedLfBonuses(coord ogame.Coordinate) ogame.LfBonuses {
+	planet := b.GetCachedCelestialByCoord(coord.Planet())
+	if planet == nil {
+		return ogame.LfBonuses{}
+	}
+	b.lfBonusesMu.Lock()
+	bonuses, ok := b.lfBonuses[planet.GetID()]
+	b.lfBonusesMu.Unlock()
+	if ok {
+		return bonuses
+	}
+	bonuses, err := b.getLfBonuses(planet.GetID())
+	if err != nil {
+		b.error(err)
+	}
+	return bonuses
+}
+
+// lfConstructionTime returns the duration to build anything on a planet,
+// with the reductions granted by the lifeform bonuses of the planet
+func (b *OGame) lfConstructionTime(celestialID ogame.CelestialID, id ogame.ID, nbr int64) (time.Duration, error) {
+	obj := ogame.Objs.ByID(id)
+	if obj == nil {
+		return 0, ogame.ErrInvalidOgameID
+	}
+	bonuses, err := b.getLfBonuses(celestialID)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer resp.Body.Close()
-	return nil
+	facilities, err := b.getFacilities(celestialID)
+	if err != nil {
+		return 0, err
+	}
+	return bonuses.ConstructionTime(obj, nbr, b.getUniverseSpeed(), facilities, b.hasTechnocrat, b.isDiscoverer()), nil
 }
 
-func (b *OGame) getCachedResearch() ogame.Researches {
-	if b.researches == nil {
-		return b.getResearch()
+// lfFlightTime returns the flight time and fuel consumption of ships leaving a planet,
+// with the speed and fuel bonuses granted by the lifeform bonuses of the planet
+func (b *OGame) lfFlightTime(planetID ogame.PlanetID, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64, err error) {
+	celestial := b.getCachedCelestial(planetID)
+	if celestial == nil {
+		return 0, 0, ogame.ErrInvalidPlanetID
 	}
-	return *b.researches
+	bonuses, err := b.getLfBonuses(planetID.Celestial())
+	if err != nil {
+		return 0, 0, err
+	}
+	secs, fuel = CalcLfFlightTime(celestial.GetCoordinate(), destination, b.serverData.Galaxies, b.serverData.Systems,
+		b.serverData.DonutGalaxy, b.serverData.DonutSystem, b.serverData.GlobalDeuteriumSaveFactor,
+		float64(speed)/10, GetFleetSpeedForMission(b.serverData, missionID), ships, b.getCachedResearch(), b.characterClass, bonuses)
+	return secs, fuel, nil
 }
 
-func (b *OGame) getResearch() ogame.Researches {
-	page, err := getPage[parser.ResearchPage](b)
+// lfCargo returns the total cargo of ships leaving a planet, with the lifeform bonuses of the planet
+func (b *OGame) lfCargo(planetID ogame.PlanetID, ships ogame.ShipsInfos) (int64, error) {
+	bonuses, err := b.getLfBonuses(planetID.Celestial())
 	if err != nil {
-		return ogame.Researches{}
+		return 0, err
 	}
-	researches := page.ExtractResearch()
-	b.researches = &researches
-	return researches
+	return bonuses.FleetCargo(ships, b.getCachedResearch(), b.server.Settings.EspionageProbeRaids == 1, b.isCollector(), b.IsPioneers()), nil
 }
 
-func (b *OGame) getResourcesBuildings(celestialID ogame.CelestialID, options ...Option) (ogame.ResourcesBuildings, error) {
-	options = append(options, ChangePlanet(celestialID))
-	page, err := getPage[parser.SuppliesPage](b, options...)
+// lfPrice returns the price of an object on a planet, technologies get the research cost reduction of the planet
+func (b *OGame) lfPrice(planetID ogame.PlanetID, id ogame.ID, nbr int64) (ogame.Resources, error) {
+	obj := ogame.Objs.ByID(id)
+	if obj == nil {
+		return ogame.Resources{}, ogame.ErrInvalidOgameID
+	}
+	price := obj.GetPrice(nbr)
+	if !id.IsTech() {
+		return price, nil
+	}
+	bonuses, err := b.getLfBonuses(planetID.Celestial())
 	if err != nil {
-		return ogame.ResourcesBuildings{}, err
+		return ogame.Resources{}, err
 	}
-	return page.ExtractResourcesBuildings()
+	return bonuses.ResearchCost(price), nil
 }
 
-func (b *OGame) getLfBuildings(celestialID ogame.CelestialID, options ...Option) (ogame.LfBuildings, error) {
-	options = append(options, ChangePlanet(celestialID))
-	page, err := getPage[parser.LfBuildingsPage](b, options...)
+func (b *OGame) getUnlockPlan(celestialID ogame.CelestialID, id ogame.ID) (ogame.UnlockPlan, error) {
+	resBuildings, facilities, _, _, researches, lfBuildings, err := b.getTechs(celestialID)
 	if err != nil {
-		return ogame.LfBuildings{}, err
+		return ogame.UnlockPlan{}, err
 	}
-	return page.ExtractLfBuildings()
+	levels := ogame.UnlockLevels{ResourcesBuildings: resBuildings, Facilities: facilities, Researches: researches, LfBuildings: lfBuildings}
+	if b.extractor.GetLifeformEnabled() && (id.IsLfBuilding() || id.IsLfTech()) {
+		if levels.LfResearches, err = b.getLfResearch(celestialID); err != nil {
+			return ogame.UnlockPlan{}, err
+		}
+	}
+	return ogame.ResolveUnlockPlan(id, levels, b.getUniverseSpeed(), b.hasTechnocrat, b.isDiscoverer())
 }
 
-func (b *OGame) getLfResearch(celestialID ogame.CelestialID, options ...Option) (ogame.LfResearches, error) {
-	options = append(options, ChangePlanet(celestialID))
-	page, err := getPage[parser.LfResearchPage](b, options...)
+func (b *OGame) selectLfSpecies(celestialID ogame.CelestialID, lfType ogame.LifeformType) error {
+	if lfType != ogame.Humans && lfType != ogame.Rocktal && lfType != ogame.Mechas && lfType != ogame.Kaelesh {
+		return ogame.ErrInvalidLifeformType
+	}
+	settings, err := b.getLfSettings(celestialID)
 	if err != nil {
-		return ogame.LfResearches{}, err
+		return err
 	}
-	return page.ExtractLfResearch()
+	if settings.Current == lfType {
+		return nil
+	}
+	species, ok := settings.SpeciesByType(lfType)
+	if !ok || !species.Discovered {
+		return ogame.ErrLifeformNotDiscovered
+	}
+	// Nothing to pay when the planet has no species yet
+	if settings.Current != ogame.NoneLfType && settings.ChangeCost.Total() > 0 {
+		resources, err := b.getResources(celestialID)
+		if err != nil {
+			return err
+		}
+		if !resources.CanAfford(settings.ChangeCost) {
+			return ogame.ErrNotEnoughResources
+		}
+	}
+	vals := url.Values{"page": {"ingame"}, "component": {LfSettingsPageName},
+		"action": {"selectLifeform"}, "lifeformId": {utils.FI64(lfType)}, "asJson": {"1"}}
+	by, err := b.postPageContent(vals, url.Values{"token": {settings.Token}}, ChangePlanet(celestialID))
+	if err != nil {
+		return err
+	}
+	return ajaxStatusResponse(by)
+}
+
+func (b *OGame) setLfResearchSlot(celestialID ogame.CelestialID, slot int64, techID ogame.ID) error {
+	if !ogame.IsLfResearchSlotTech(slot, techID) {
+		return ogame.ErrInvalidLfResearchSlot
+	}
+	settings, err := b.getLfSettings(celestialID)
+	if err != nil {
+		return err
+	}
+	researchSlot, ok := settings.ResearchSlot(slot)
+	if !ok {
+		return ogame.ErrInvalidLfResearchSlot
+	}
+	if researchSlot.Locked {
+		return ogame.ErrLfResearchSlotLocked
+	}
+	if researchSlot.TechID == techID {
+		return nil
+	}
+	vals := url.Values{"page": {"ingame"}, "component": {LfSettingsPageName},
+		"action": {"selectResearch"}, "slot": {utils.FI64(slot)}, "technologyId": {utils.FI64(techID)}, "asJson": {"1"}}
+	by, err := b.postPageContent(vals, url.Values{"token": {settings.Token}}, ChangePlanet(celestialID))
+	if err != nil {
+		return err
+	}
+	return ajaxStatusResponse(by)
 }
 
 func (b *OGame) getDefense(celestialID ogame.CelestialID, options ...Option) (ogame.DefensesInfos, error) {
@@ -3493,7 +3912,11 @@ func (b *OGame) sendFleet(celestialID ogame.CelestialID, ships []ogame.Quantifia
 		return ogame.Fleet{}, errors.New("target is not ok")
 	}
 
-	cargo := ogame.ShipsInfos{}.FromQuantifiables(ships).Cargo(b.getCachedResearch(), b.server.Settings.EspionageProbeRaids == 1, b.isCollector(), b.IsPioneers())
+	var lfBonuses ogame.LfBonuses
+	if origin := b.GetCachedCelestialByID(celestialID); origin != nil {
+		lfBonuses = b.getCachedLfBonuses(origin.GetCoordinate())
+	}
+	cargo := lfBonuses.FleetCargo(ogame.ShipsInfos{}.FromQuantifiables(ships), b.getCachedResearch(), b.server.Settings.EspionageProbeRaids == 1, b.isCollector(), b.IsPioneers())
 	newResources := ogame.Resources{}
 	if resources.Total() > cargo {
 		newResources.Deuterium = int64(math.Min(float64(resources.Deuterium), float64(cargo)))
@@ -3611,6 +4034,73 @@ func (b *OGame) getPageMessages(page int64, tabid ogame.MessagesTabID) ([]byte,
 	return b.postPageContent(url.Values{"page": {"messages"}}, payload)
 }
 
+func (b *OGame) getMessages(tabID ogame.MessagesTabID, page int64) ([]ogame.Message, int64, error) {
+	if tabID == ConversationsMessagesTabID {
+		return nil, 0, ErrConversationsTab
+	}
+	pageHTML, err := b.getPageMessages(page, tabID)
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs, nbPage := b.extractor.ExtractMessages(pageHTML, tabID)
+	return msgs, nbPage, nil
+}
+
+// getMessagesSince returns the messages of a tab that are newer than msgID, newest first.
+// Messages are sorted newest first, so pages are fetched until an older message is found.
+func (b *OGame) getMessagesSince(tabID ogame.MessagesTabID, msgID int64) ([]ogame.Message, error) {
+	var page int64 = 1
+	var nbPage int64 = 1
+	msgs := make([]ogame.Message, 0)
+	for page <= nbPage {
+		newMessages, newNbPage, err := b.getMessages(tabID, page)
+		if err != nil {
+			return msgs, err
+		}
+		for _, m := range newMessages {
+			if m.ID <= msgID {
+				return msgs, nil
+			}
+			msgs = append(msgs, m)
+		}
+		nbPage = newNbPage
+		page++
+	}
+	return msgs, nil
+}
+
+// markMessageRead opens the message details, which is how the game marks a message as read
+func (b *OGame) markMessageRead(tabID ogame.MessagesTabID, msgID int64) error {
+	_, err := b.getPageContent(url.Values{"page": {"messages"}, "messageId": {utils.FI64(msgID)}, "tabid": {utils.FI64(tabID)}, "ajax": {"1"}})
+	return err
+}
+
+func (b *OGame) setMessageFavorite(msgID int64, favorite bool) error {
+	/*
+		Request URL: https://$ogame/game/index.php?page=messages
+		Request Method: POST
+
+		action: 101 => mark as favourite
+		action: 102 => remove from favourites
+	*/
+	token, err := b.getDeleteMessagesToken()
+	if err != nil {
+		return err
+	}
+	action := "102"
+	if favorite {
+		action = "101"
+	}
+	payload := url.Values{
+		"messageId": {utils.FI64(msgID)},
+		"action":    {action},
+		"ajax":      {"1"},
+		"token":     {token},
+	}
+	_, err = b.postPageContent(url.Values{"page": {"messages"}}, payload)
+	return err
+}
+
 func (b *OGame) getEspionageReportMessages() ([]ogame.EspionageReportSummary, error) {
 	var page int64 = 1
 	var nbPage int64 = 1
@@ -3625,6 +4115,23 @@ func (b *OGame) getEspionageReportMessages() ([]ogame.EspionageReportSummary, er
 	return msgs, nil
 }
 
+func (b *OGame) getEspionageActionMessages() ([]ogame.EspionageActionMessage, error) {
+	var page int64 = 1
+	var nbPage int64 = 1
+	msgs := make([]ogame.EspionageActionMessage, 0)
+	for page <= nbPage {
+		pageHTML, err := b.getPageMessages(page, EspionageMessagesTabID)
+		if err != nil {
+			return msgs, err
+		}
+		newMessages, newNbPage := b.extractor.ExtractEspionageActionMessages(pageHTML)
+		msgs = append(msgs, newMessages...)
+		nbPage = newNbPage
+		page++
+	}
+	return msgs, nil
+}
+
 func (b *OGame) getCombatReportMessages() ([]ogame.CombatReportSummary, error) {
 	var page int64 = 1
 	var nbPage int64 = 1
@@ -3749,6 +4256,63 @@ LOOP:
 	return ogame.ExpeditionMessage{}, errors.New("expedition message not found for " + t.String())
 }
 
+func (b *OGame) getHarvestReports() ([]ogame.HarvestReport, error) {
+	var page int64 = 1
+	var nbPage int64 = 1
+	msgs := make([]ogame.HarvestReport, 0)
+	for page <= nbPage {
+		pageHTML, err := b.getPageMessages(page, OtherMessagesTabID)
+		if err != nil {
+			return msgs, err
+		}
+		newMessages, newNbPage, err := b.extractor.ExtractHarvestReports(pageHTML)
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, newMessages...)
+		nbPage = newNbPage
+		page++
+	}
+	return msgs, nil
+}
+
+// getHarvestReportFor finds the harvest report of a recycle fleet,
+// the report is created when the fleet reaches the debris field.
+func (b *OGame) getHarvestReportFor(fleet ogame.Fleet) (ogame.HarvestReport, error) {
+	reports, err := b.getHarvestReports()
+	if err != nil {
+		return ogame.HarvestReport{}, err
+	}
+	if report, ok := findHarvestReport(reports, fleet); ok {
+		return report, nil
+	}
+	return ogame.HarvestReport{}, errors.New("harvest report not found for " + fleet.Destination.String())
+}
+
+// findHarvestReport returns the report on the fleet destination that is the closest to the fleet arrival time
+func findHarvestReport(reports []ogame.HarvestReport, fleet ogame.Fleet) (ogame.HarvestReport, bool) {
+	var found ogame.HarvestReport
+	var foundDiff time.Duration = -1
+	for _, r := range reports {
+		if r.Coordinate.Galaxy != fleet.Destination.Galaxy ||
+			r.Coordinate.System != fleet.Destination.System ||
+			r.Coordinate.Position != fleet.Destination.Position {
+			continue
+		}
+		if fleet.ArrivalTime.IsZero() {
+			return r, true
+		}
+		diff := r.CreatedAt.Sub(fleet.ArrivalTime)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= time.Minute && (foundDiff == -1 || diff < foundDiff) {
+			found, foundDiff = r, diff
+		}
+	}
+	return found, foundDiff != -1
+}
+
 func (b *OGame) getCombatReportFor(coord ogame.Coordinate) (ogame.CombatReportSummary, error) {
 	var page int64 = 1
 	var nbPage int64 = 1
@@ -3834,15 +4398,41 @@ func (b *OGame) deleteMessage(msgID int64) error {
 }
 
 const (
-	EspionageMessagesTabID            ogame.MessagesTabID = 20
-	CombatReportsMessagesTabID        ogame.MessagesTabID = 21
-	ExpeditionsMessagesTabID          ogame.MessagesTabID = 22
-	UnionsTransportMessagesTabID      ogame.MessagesTabID = 23
-	OtherMessagesTabID                ogame.MessagesTabID = 24
-	MarketplacePurchasesMessagesTabID ogame.MessagesTabID = 26
-	MarketplaceSalesMessagesTabID     ogame.MessagesTabID = 27
+	EconomyMessagesTabID                ogame.MessagesTabID = 3
+	UniverseMessagesTabID               ogame.MessagesTabID = 4
+	SystemMessagesTabID                 ogame.MessagesTabID = 5
+	FavoritesMessagesTabID              ogame.MessagesTabID = 6
+	ConversationsMessagesTabID          ogame.MessagesTabID = 10
+	InformationMessagesTabID            ogame.MessagesTabID = 11
+	SharedCombatReportsMessagesTabID    ogame.MessagesTabID = 12
+	SharedEspionageReportsMessagesTabID ogame.MessagesTabID = 13
+	EspionageMessagesTabID              ogame.MessagesTabID = 20
+	CombatReportsMessagesTabID          ogame.MessagesTabID = 21
+	ExpeditionsMessagesTabID            ogame.MessagesTabID = 22
+	UnionsTransportMessagesTabID        ogame.MessagesTabID = 23
+	OtherMessagesTabID                  ogame.MessagesTabID = 24
+	MarketplacePurchasesMessagesTabID   ogame.MessagesTabID = 26
+	MarketplaceSalesMessagesTabID       ogame.MessagesTabID = 27
 )
 
+// MessagesTabIDs all the tabs of the inbox, except the favorites which duplicates messages from other tabs
+// and the conversations which are read with GetConversations (Inbox follows them too)
+var MessagesTabIDs = []ogame.MessagesTabID{
+	InformationMessagesTabID,
+	SharedCombatReportsMessagesTabID,
+	SharedEspionageReportsMessagesTabID,
+	EspionageMessagesTabID,
+	CombatReportsMessagesTabID,
+	ExpeditionsMessagesTabID,
+	UnionsTransportMessagesTabID,
+	OtherMessagesTabID,
+	MarketplacePurchasesMessagesTabID,
+	MarketplaceSalesMessagesTabID,
+	EconomyMessagesTabID,
+	UniverseMessagesTabID,
+	SystemMessagesTabID,
+}
+
 func (b *OGame) deleteAllMessagesFromTab(tabID ogame.MessagesTabID) error {
 	/*
 		Request URL: https://$ogame/game/index.php?page=messages
@@ -3878,61 +4468,83 @@ func (b *OGame) deleteAllMessagesFromTab(tabID ogame.MessagesTabID) error {
 	return err
 }
 
-func energyProduced(temp ogame.Temperature, resourcesBuildings ogame.ResourcesBuildings, resSettings ogame.ResourceSettings, energyTechnology int64) int64 {
-	energyProduced := int64(float64(ogame.SolarPlant.Production(resourcesBuildings.SolarPlant)) * (float64(resSettings.SolarPlant) / 100))
-	energyProduced += int64(float64(ogame.FusionReactor.Production(energyTechnology, resourcesBuildings.FusionReactor)) * (float64(resSettings.FusionReactor) / 100))
-	energyProduced += int64(float64(ogame.SolarSatellite.Production(temp, resourcesBuildings.SolarSatellite, false)) * (float64(resSettings.SolarSatellite) / 100))
-	return energyProduced
+// productionParams returns the production parameters of a planet that depend on the account (universe, class, officers)
+func (b *OGame) productionParams(resBuildings ogame.ResourcesBuildings, researches ogame.Researches,
+	resSettings ogame.ResourceSettings, temp ogame.Temperature) ogame.ProductionParams {
+	return ogame.ProductionParams{
+		ResourcesBuildings: resBuildings,
+		ResourceSettings:   resSettings,
+		Researches:         researches,
+		Temperature:        temp,
+		UniverseSpeed:      b.serverData.Speed,
+		CharacterClass:     b.characterClass,
+		HasGeologist:       b.hasGeologist,
+		HasEngineer:        b.hasEngineer,
+		HasCommandingStaff: b.getCachedOfficers().CommandingStaffBonus,
+	}
 }
 
-func energyNeeded(resourcesBuildings ogame.ResourcesBuildings, resSettings ogame.ResourceSettings) int64 {
-	energyNeeded := int64(float64(ogame.MetalMine.EnergyConsumption(resourcesBuildings.MetalMine)) * (float64(resSettings.MetalMine) / 100))
-	energyNeeded += int64(float64(ogame.CrystalMine.EnergyConsumption(resourcesBuildings.CrystalMine)) * (float64(resSettings.CrystalMine) / 100))
-	energyNeeded += int64(float64(ogame.DeuteriumSynthesizer.EnergyConsumption(resourcesBuildings.DeuteriumSynthesizer)) * (float64(resSettings.DeuteriumSynthesizer) / 100))
-	return energyNeeded
+// getProductionParams returns all the production parameters of a planet
+func (b *OGame) getProductionParams(planetID ogame.PlanetID) (ogame.ProductionParams, error) {
+	planet, err := b.getPlanet(planetID)
+	if err != nil {
+		return ogame.ProductionParams{}, err
+	}
+	resBuildings, err := b.getResourcesBuildings(planetID.Celestial())
+	if err != nil {
+		return ogame.ProductionParams{}, err
+	}
+	resSettings, err := b.getResourceSettings(planetID)
+	if err != nil {
+		return ogame.ProductionParams{}, err
+	}
+	params := b.productionParams(resBuildings, b.getResearch(), resSettings, planet.Temperature)
+	params.Position = planet.Coordinate.Position
+	if ships, err := b.getShips(planetID.Celestial()); err == nil {
+		params.Crawlers = ships.Crawler
+	}
+	activeItems, _ := b.getActiveItems(planetID.Celestial())
+	params.ItemsBoost = ogame.ItemsProductionBoost(activeItems)
+	params.LfBonuses, _ = b.getLfBonuses(planetID.Celestial())
+	return params, nil
 }
 
-func productionRatio(temp ogame.Temperature, resourcesBuildings ogame.ResourcesBuildings, resSettings ogame.ResourceSettings, energyTechnology int64) float64 {
-	energyProduced := energyProduced(temp, resourcesBuildings, resSettings, energyTechnology)
-	energyNeeded := energyNeeded(resourcesBuildings, resSettings)
-	ratio := 1.0
-	if energyNeeded > energyProduced {
-		ratio = float64(energyProduced) / float64(energyNeeded)
+func (b *OGame) getResourcesProductionsBreakdown(planetID ogame.PlanetID) (ogame.ProductionBreakdown, error) {
+	params, err := b.getProductionParams(planetID)
+	if err != nil {
+		return ogame.ProductionBreakdown{}, err
 	}
-	return ratio
+	return ogame.GetProductionBreakdown(params), nil
 }
 
-func getProductions(resBuildings ogame.ResourcesBuildings, resSettings ogame.ResourceSettings, researches ogame.Researches, universeSpeed int64,
-	temp ogame.Temperature, globalRatio float64) ogame.Resources {
-	energyProduced := energyProduced(temp, resBuildings, resSettings, researches.EnergyTechnology)
-	energyNeeded := energyNeeded(resBuildings, resSettings)
-	metalSetting := float64(resSettings.MetalMine) / 100
-	crystalSetting := float64(resSettings.CrystalMine) / 100
-	deutSetting := float64(resSettings.DeuteriumSynthesizer) / 100
-	return ogame.Resources{
-		Metal:     ogame.MetalMine.Production(universeSpeed, metalSetting, globalRatio, researches.PlasmaTechnology, resBuildings.MetalMine),
-		Crystal:   ogame.CrystalMine.Production(universeSpeed, crystalSetting, globalRatio, researches.PlasmaTechnology, resBuildings.CrystalMine),
-		Deuterium: ogame.DeuteriumSynthesizer.Production(universeSpeed, temp.Mean(), deutSetting, globalRatio, researches.PlasmaTechnology, resBuildings.DeuteriumSynthesizer) - ogame.FusionReactor.GetFuelConsumption(universeSpeed, float64(resSettings.FusionReactor)/100, resBuildings.FusionReactor),
-		Energy:    energyProduced - energyNeeded,
+func (b *OGame) getUpgradeAdvices(planetID ogame.PlanetID, ratio ogame.Multiplier) ([]ogame.UpgradeAdvice, error) {
+	params, err := b.getProductionParams(planetID)
+	if err != nil {
+		return nil, err
+	}
+	var lfBuildings ogame.LfBuildings
+	if b.extractor.GetLifeformEnabled() {
+		if lfBuildings, err = b.getLfBuildings(planetID.Celestial()); err != nil {
+			return nil, err
+		}
 	}
+	return ogame.AdviseUpgrades(params, lfBuildings, ratio), nil
 }
 
 func (b *OGame) getResourcesProductions(planetID ogame.PlanetID) (ogame.Resources, error) {
-	planet, _ := b.getPlanet(planetID)
-	resBuildings, _ := b.getResourcesBuildings(planetID.Celestial())
-	researches := b.getResearch()
-	universeSpeed := b.serverData.Speed
-	resSettings, _ := b.getResourceSettings(planetID)
-	ratio := productionRatio(planet.Temperature, resBuildings, resSettings, researches.EnergyTechnology)
-	productions := getProductions(resBuildings, resSettings, researches, universeSpeed, planet.Temperature, ratio)
-	return productions, nil
+	breakdown, err := b.getResourcesProductionsBreakdown(planetID)
+	if err != nil {
+		return ogame.Resources{}, err
+	}
+	return breakdown.Total(), nil
 }
 
-func getResourcesProductionsLight(resBuildings ogame.ResourcesBuildings, researches ogame.Researches,
-	resSettings ogame.ResourceSettings, temp ogame.Temperature, universeSpeed int64) ogame.Resources {
-	ratio := productionRatio(temp, resBuildings, resSettings, researches.EnergyTechnology)
-	productions := getProductions(resBuildings, resSettings, researches, universeSpeed, temp, ratio)
-	return productions
+// getResourcesProductionsLight computes the production without fetching anything,
+// position, crawlers, items and lifeform bonuses are not known and not included (see ogame.GetProductionBreakdown).
+func (b *OGame) getResourcesProductionsLight(resBuildings ogame.ResourcesBuildings, researches ogame.Researches,
+	resSettings ogame.ResourceSettings, temp ogame.Temperature) ogame.Resources {
+	params := b.productionParams(resBuildings, researches, resSettings, temp)
+	return ogame.GetProductionBreakdown(params).Total()
 }
 
 func (b *OGame) getPublicIP() (string, error) {
@@ -4279,7 +4891,7 @@ func (b *OGame) IsDonutSystem() bool {
 	return b.isDonutSystem()
 }
 
-// ConstructionTime get duration to build something
+// ConstructionTime get duration to build something, without the lifeform bonuses (see LfConstructionTime)
 func (b *OGame) ConstructionTime(id ogame.ID, nbr int64, facilities ogame.Facilities) time.Duration {
 	return b.constructionTime(id, nbr, facilities)
 }
@@ -4315,6 +4927,11 @@ func (b *OGame) GetCachedPreferences() ogame.Preferences {
 	return b.CachedPreferences
 }
 
+// GetCachedOfficers returns cached officers status, remaining durations are updated with the time elapsed since they were extracted
+func (b *OGame) GetCachedOfficers() ogame.Officers {
+	return b.getCachedOfficers()
+}
+
 // SetVacationMode puts account in vacation mode
 func (b *OGame) SetVacationMode() error {
 	return b.WithPriority(taskRunner.Normal).SetVacationMode()
@@ -4373,6 +4990,40 @@ func (b *OGame) GetCelestials() ([]Celestial, error) {
 	return b.WithPriority(taskRunner.Normal).GetCelestials()
 }
 
+// GetCharacterClassSelection gets the character classes available and their dark matter price.
+// The class selection page is parsed with selectors that are not verified against a captured page.
+func (b *OGame) GetCharacterClassSelection() (ogame.CharacterClassSelection, error) {
+	return b.WithPriority(taskRunner.Normal).GetCharacterClassSelection()
+}
+
+// SelectCharacterClass selects (or switches to) a character class, paying dark matter if needed.
+// The endpoint is the one of the real pages, the price and token come from the unverified class selection page.
+func (b *OGame) SelectCharacterClass(class ogame.CharacterClass) error {
+	return b.WithPriority(taskRunner.Normal).SelectCharacterClass(class)
+}
+
+// DeselectCharacterClass removes the current character class
+func (b *OGame) DeselectCharacterClass() error {
+	return b.WithPriority(taskRunner.Normal).DeselectCharacterClass()
+}
+
+// GetAllianceClassSelection gets the alliance classes available and their dark matter price.
+// The alliance class selection tab is parsed with selectors that are not verified against a captured page.
+func (b *OGame) GetAllianceClassSelection() (ogame.AllianceClassSelection, error) {
+	return b.WithPriority(taskRunner.Normal).GetAllianceClassSelection()
+}
+
+// SelectAllianceClass selects the alliance class (alliance founder only).
+// Neither the endpoint nor the price and token parsing are verified against a captured page.
+func (b *OGame) SelectAllianceClass(class ogame.AllianceClass) error {
+	return b.WithPriority(taskRunner.Normal).SelectAllianceClass(class)
+}
+
+// GetOfficers gets the officers status and their remaining durations
+func (b *OGame) GetOfficers() (ogame.Officers, error) {
+	return b.WithPriority(taskRunner.Normal).GetOfficers()
+}
+
 // RecruitOfficer recruit an officer.
 // Typ 2: Commander, 3: Admiral, 4: Engineer, 5: Geologist, 6: Technocrat
 // Days: 7 or 90
@@ -4416,6 +5067,26 @@ func (b *OGame) SendMessage(playerID int64, message string) error {
 	return b.WithPriority(taskRunner.Normal).SendMessage(playerID, message)
 }
 
+// GetConversations gets the conversations of the chat
+func (b *OGame) GetConversations() ([]ogame.Conversation, error) {
+	return b.WithPriority(taskRunner.Normal).GetConversations()
+}
+
+// GetConversation gets the full history of the conversation with a player
+func (b *OGame) GetConversation(playerID int64) (ogame.Conversation, error) {
+	return b.WithPriority(taskRunner.Normal).GetConversation(playerID)
+}
+
+// GetAllianceConversation gets the last messages of an alliance conversation
+func (b *OGame) GetAllianceConversation(associationID int64) (ogame.Conversation, error) {
+	return b.WithPriority(taskRunner.Normal).GetAllianceConversation(associationID)
+}
+
+// ReplyToConversation sends a message to the player or the alliance of a conversation
+func (b *OGame) ReplyToConversation(conversation ogame.Conversation, message string) error {
+	return b.WithPriority(taskRunner.Normal).ReplyToConversation(conversation, message)
+}
+
 // SendMessageAlliance sends a message to associationID
 func (b *OGame) SendMessageAlliance(associationID int64, message string) error {
 	return b.WithPriority(taskRunner.Normal).SendMessageAlliance(associationID, message)
@@ -4483,6 +5154,11 @@ func (b *OGame) GetProduction(celestialID ogame.CelestialID) ([]ogame.Quantifiab
 	return b.WithPriority(taskRunner.Normal).GetProduction(celestialID)
 }
 
+// GetCachedLfBonuses returns the cached lifeform bonuses of the planet at coord (or of the planet of the moon at coord)
+func (b *OGame) GetCachedLfBonuses(coord ogame.Coordinate) ogame.LfBonuses {
+	return b.WithPriority(taskRunner.Normal).GetCachedLfBonuses(coord)
+}
+
 // GetCachedResearch returns cached researches
 func (b *OGame) GetCachedResearch() ogame.Researches {
 	return b.WithPriority(taskRunner.Normal).GetCachedResearch()
@@ -4573,6 +5249,37 @@ func (b *OGame) GetResourcesDetails(celestialID ogame.CelestialID) (ogame.Resour
 	return b.WithPriority(taskRunner.Normal).GetResourcesDetails(celestialID)
 }
 
+// GetResourceTrader gets the resource trader exchange rates
+func (b *OGame) GetResourceTrader(celestialID ogame.CelestialID) (ogame.ResourceTrader, error) {
+	return b.WithPriority(taskRunner.Normal).GetResourceTrader(celestialID)
+}
+
+// ExchangeResources exchanges the resources given for the resource to receive, at the resource trader rates
+func (b *OGame) ExchangeResources(celestialID ogame.CelestialID, give ogame.Resources, receive ogame.TradeResource) error {
+	return b.WithPriority(taskRunner.Normal).ExchangeResources(celestialID, give, receive)
+}
+
+// GetScrapMerchant gets the scrap merchant offer.
+// The scrap merchant page is parsed with selectors that are not verified against a captured page.
+func (b *OGame) GetScrapMerchant(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	return b.WithPriority(taskRunner.Normal).GetScrapMerchant(celestialID)
+}
+
+// BargainScrap pays dark matter to raise the scrap merchant percentage
+func (b *OGame) BargainScrap(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	return b.WithPriority(taskRunner.Normal).BargainScrap(celestialID)
+}
+
+// ScrapUnits sells ships/defenses to the scrap merchant, bargaining up to minPercentage with at most maxDarkMatter
+func (b *OGame) ScrapUnits(celestialID ogame.CelestialID, units []ogame.Quantifiable, minPercentage, maxDarkMatter int64) (ogame.Resources, error) {
+	return b.WithPriority(taskRunner.Normal).ScrapUnits(celestialID, units, minPercentage, maxDarkMatter)
+}
+
+// GetUnlockPlan gets the ordered list of upgrades needed on a celestial to unlock an ogame object
+func (b *OGame) GetUnlockPlan(celestialID ogame.CelestialID, id ogame.ID) (ogame.UnlockPlan, error) {
+	return b.WithPriority(taskRunner.Normal).GetUnlockPlan(celestialID, id)
+}
+
 // GetTechs gets a celestial supplies/facilities/ships/researches
 func (b *OGame) GetTechs(celestialID ogame.CelestialID) (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error) {
 	return b.WithPriority(taskRunner.Normal).GetTechs(celestialID)
@@ -4630,11 +5337,47 @@ func (b *OGame) CollectMarketplaceMessage(msg ogame.MarketplaceMessage) error {
 	return b.WithPriority(taskRunner.Normal).CollectMarketplaceMessage(msg)
 }
 
+// GetMessages gets a page of messages from any tab of the inbox, and the number of pages
+func (b *OGame) GetMessages(tabID ogame.MessagesTabID, page int64) ([]ogame.Message, int64, error) {
+	return b.WithPriority(taskRunner.Normal).GetMessages(tabID, page)
+}
+
+// GetMessagesSince gets the messages of a tab that are newer than msgID, newest first
+func (b *OGame) GetMessagesSince(tabID ogame.MessagesTabID, msgID int64) ([]ogame.Message, error) {
+	return b.WithPriority(taskRunner.Normal).GetMessagesSince(tabID, msgID)
+}
+
+// MarkMessageRead marks a message as read
+func (b *OGame) MarkMessageRead(tabID ogame.MessagesTabID, msgID int64) error {
+	return b.WithPriority(taskRunner.Normal).MarkMessageRead(tabID, msgID)
+}
+
+// SetMessageFavorite adds or removes a message from the favourites
+func (b *OGame) SetMessageFavorite(msgID int64, favorite bool) error {
+	return b.WithPriority(taskRunner.Normal).SetMessageFavorite(msgID, favorite)
+}
+
 // GetEspionageReportMessages gets the summary of each espionage reports
 func (b *OGame) GetEspionageReportMessages() ([]ogame.EspionageReportSummary, error) {
 	return b.WithPriority(taskRunner.Normal).GetEspionageReportMessages()
 }
 
+// GetEspionageActionMessages gets the messages received when foreign fleets spied on our celestials
+func (b *OGame) GetEspionageActionMessages() ([]ogame.EspionageActionMessage, error) {
+	return b.WithPriority(taskRunner.Normal).GetEspionageActionMessages()
+}
+
+// GetHarvestReports gets the reports of the debris fields harvested by recyclers and pathfinders,
+// ogame.ErrUnsupportedLanguage is returned when the reports of the server language can't be parsed
+func (b *OGame) GetHarvestReports() ([]ogame.HarvestReport, error) {
+	return b.WithPriority(taskRunner.Normal).GetHarvestReports()
+}
+
+// GetHarvestReportFor gets the harvest report of a recycle fleet
+func (b *OGame) GetHarvestReportFor(fleet ogame.Fleet) (ogame.HarvestReport, error) {
+	return b.WithPriority(taskRunner.Normal).GetHarvestReportFor(fleet)
+}
+
 // GetEspionageReport gets a detailed espionage report
 func (b *OGame) GetEspionageReport(msgID int64) (ogame.EspionageReport, error) {
 	return b.WithPriority(taskRunner.Normal).GetEspionageReport(msgID)
@@ -4655,13 +5398,27 @@ func (b *OGame) GetResourcesProductions(planetID ogame.PlanetID) (ogame.Resource
 	return b.WithPriority(taskRunner.Normal).GetResourcesProductions(planetID)
 }
 
-// GetResourcesProductionsLight gets the planet resources production
+// GetResourcesProductionsBreakdown gets the planet resources production per source
+func (b *OGame) GetResourcesProductionsBreakdown(planetID ogame.PlanetID) (ogame.ProductionBreakdown, error) {
+	return b.WithPriority(taskRunner.Normal).GetResourcesProductionsBreakdown(planetID)
+}
+
+// GetUpgradeAdvices gets the next production upgrades of a planet ranked by amortization time,
+// ratio is the trade ratio used to compare resources (3:2:1 when empty)
+func (b *OGame) GetUpgradeAdvices(planetID ogame.PlanetID, ratio ogame.Multiplier) ([]ogame.UpgradeAdvice, error) {
+	return b.WithPriority(taskRunner.Normal).GetUpgradeAdvices(planetID, ratio)
+}
+
+// GetResourcesProductionsLight estimates the planet resources production from the given buildings, researches,
+// settings and temperature, plus the universe speed, class and officers of the account.
+// The estimate is partial: the position, crawlers, active items and lifeform bonuses are not included,
+// use GetResourcesProductions, or ogame.GetProductionBreakdown with all the ogame.ProductionParams, to include them.
 func (b *OGame) GetResourcesProductionsLight(resBuildings ogame.ResourcesBuildings, researches ogame.Researches,
 	resSettings ogame.ResourceSettings, temp ogame.Temperature) ogame.Resources {
 	return b.WithPriority(taskRunner.Normal).GetResourcesProductionsLight(resBuildings, researches, resSettings, temp)
 }
 
-// FlightTime calculate flight time and fuel needed
+// FlightTime calculate flight time and fuel needed, with the cached lifeform bonuses of the origin planet
 func (b *OGame) FlightTime(origin, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64) {
 	return b.WithPriority(taskRunner.Normal).FlightTime(origin, destination, speed, ships, missionID)
 }
@@ -4690,6 +5447,12 @@ func (b *OGame) RegisterChatCallback(fn func(msg ogame.ChatMsg)) {
 	b.chatCallbacks = append(b.chatCallbacks, fn)
 }
 
+// RegisterConversationCallback register a callback that is called when a message is received in a conversation,
+// the conversation holds all the messages known by the bot.
+func (b *OGame) RegisterConversationCallback(fn func(conversation ogame.Conversation, msg ogame.ConversationMessage)) {
+	b.conversationCallbacks = append(b.conversationCallbacks, fn)
+}
+
 // RegisterAuctioneerCallback register a callback that is called when auctioneer packets are received
 func (b *OGame) RegisterAuctioneerCallback(fn func(packet any)) {
 	b.auctioneerCallbacks = append(b.auctioneerCallbacks, fn)
@@ -4804,6 +5567,76 @@ func (b *OGame) ActivateItem(ref string, celestialID ogame.CelestialID) error {
 	return b.WithPriority(taskRunner.Normal).ActivateItem(ref, celestialID)
 }
 
+// BuyItem buys an item from the dark matter shop
+func (b *OGame) BuyItem(ref string, celestialID ogame.CelestialID) error {
+	return b.WithPriority(taskRunner.Normal).BuyItem(ref, celestialID)
+}
+
+// GetLfSettings gets the lifeform settings of a planet.
+// The page is parsed with selectors that are not verified against a captured lifeform settings page.
+func (b *OGame) GetLfSettings(planetID ogame.PlanetID) (ogame.LfSettings, error) {
+	return b.WithPriority(taskRunner.Normal).GetLfSettings(planetID)
+}
+
+// GetLfBonuses gets the modifiers granted by the lifeform buildings and techs of a planet
+func (b *OGame) GetLfBonuses(planetID ogame.PlanetID) (ogame.LfBonuses, error) {
+	return b.WithPriority(taskRunner.Normal).GetLfBonuses(planetID)
+}
+
+// LfConstructionTime gets the duration to build anything (level of a building or tech, number of units) on a planet,
+// with the lifeform bonuses of the planet
+func (b *OGame) LfConstructionTime(planetID ogame.PlanetID, id ogame.ID, nbr int64) (time.Duration, error) {
+	return b.WithPriority(taskRunner.Normal).LfConstructionTime(planetID, id, nbr)
+}
+
+// LfFlightTime calculates the flight time and fuel consumption of ships leaving a planet, with the lifeform bonuses of the planet
+func (b *OGame) LfFlightTime(planetID ogame.PlanetID, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64, err error) {
+	return b.WithPriority(taskRunner.Normal).LfFlightTime(planetID, destination, speed, ships, missionID)
+}
+
+// LfCargo gets the total cargo of ships leaving a planet, with the lifeform bonuses of the planet
+func (b *OGame) LfCargo(planetID ogame.PlanetID, ships ogame.ShipsInfos) (int64, error) {
+	return b.WithPriority(taskRunner.Normal).LfCargo(planetID, ships)
+}
+
+// LfPrice gets the price of an object on a planet, with the research cost reduction of the planet for technologies
+func (b *OGame) LfPrice(planetID ogame.PlanetID, id ogame.ID, nbr int64) (ogame.Resources, error) {
+	return b.WithPriority(taskRunner.Normal).LfPrice(planetID, id, nbr)
+}
+
+// SelectLfSpecies selects (or switches) the lifeform species of a planet.
+// The selectLifeform endpoint is not verified against a captured lifeform settings page.
+func (b *OGame) SelectLfSpecies(planetID ogame.PlanetID, lfType ogame.LifeformType) error {
+	return b.WithPriority(taskRunner.Normal).SelectLfSpecies(planetID, lfType)
+}
+
+// SetLfResearchSlot sets the technology researched in a lifeform research slot of a planet.
+// The selectResearch endpoint is not verified against a captured lifeform settings page.
+func (b *OGame) SetLfResearchSlot(planetID ogame.PlanetID, slot int64, techID ogame.ID) error {
+	return b.WithPriority(taskRunner.Normal).SetLfResearchSlot(planetID, slot, techID)
+}
+
+// GetPlanetLayer gets the rename/relocate/abandon information of a celestial
+func (b *OGame) GetPlanetLayer(celestialID ogame.CelestialID) (ogame.PlanetLayer, error) {
+	return b.WithPriority(taskRunner.Normal).GetPlanetLayer(celestialID)
+}
+
+// RenameCelestial renames a planet or moon
+func (b *OGame) RenameCelestial(celestialID ogame.CelestialID, newName string) error {
+	return b.WithPriority(taskRunner.Normal).RenameCelestial(celestialID, newName)
+}
+
+// RelocatePlanet reserves the relocation of a planet to the given coordinate.
+// The planet only moves when the 24h countdown ends, if no fleet, construction or research is running then.
+func (b *OGame) RelocatePlanet(planetID ogame.PlanetID, coord ogame.Coordinate) error {
+	return b.WithPriority(taskRunner.Normal).RelocatePlanet(planetID, coord)
+}
+
+// GetRelocationStatus gets the relocation countdown of a planet and what would cancel it
+func (b *OGame) GetRelocationStatus(planetID ogame.PlanetID) (ogame.RelocationStatus, error) {
+	return b.WithPriority(taskRunner.Normal).GetRelocationStatus(planetID)
+}
+
 // BuyMarketplace buy an item on the marketplace
 func (b *OGame) BuyMarketplace(itemID int64, celestialID ogame.CelestialID) error {
 	return b.WithPriority(taskRunner.Normal).BuyMarketplace(itemID, celestialID)
@@ -8,8 +8,11 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"net/url"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func BenchmarkUserInfoRegex(b *testing.B) {
@@ -70,34 +73,6 @@ func TestWrapper(t *testing.T) {
 //	assert.Equal(t, Resources{Metal: 109444, Crystal: 41697, Deuterium: 16347, Energy: -5169}, prod)
 //}
 
-func TestProductionRatio(t *testing.T) {
-	ratio := productionRatio(
-		ogame.Temperature{-23, 17},
-		ogame.ResourcesBuildings{MetalMine: 29, CrystalMine: 16, DeuteriumSynthesizer: 26, SolarPlant: 29, FusionReactor: 13, SolarSatellite: 51},
-		ogame.ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100},
-		12,
-	)
-	assert.Equal(t, 1.0, ratio)
-}
-
-func TestEnergyNeeded(t *testing.T) {
-	needed := energyNeeded(
-		ogame.ResourcesBuildings{MetalMine: 29, CrystalMine: 16, DeuteriumSynthesizer: 26},
-		ogame.ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100},
-	)
-	assert.Equal(t, int64(4601+736+6198), needed)
-}
-
-func TestEnergyProduced(t *testing.T) {
-	produced := energyProduced(
-		ogame.Temperature{-23, 17},
-		ogame.ResourcesBuildings{SolarPlant: 29, FusionReactor: 13, SolarSatellite: 51},
-		ogame.ResourceSettings{SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100},
-		12,
-	)
-	assert.Equal(t, int64(9200+3002+1326), produced)
-}
-
 func TestExtractCargoCapacity(t *testing.T) {
 	pageHTMLBytes, _ := ioutil.ReadFile("../../samples/unversioned/sendfleet3.htm")
 	fleet3Doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTMLBytes))
@@ -211,6 +186,21 @@ func TestCalcFlightTime(t *testing.T) {
 	assert.Equal(t, int64(1), fuel)
 }
 
+func TestCalcLfFlightTime(t *testing.T) {
+	origin, destination := ogame.Coordinate{4, 116, 12, ogame.PlanetType}, ogame.Coordinate{3, 116, 12, ogame.PlanetType}
+	ships := ogame.ShipsInfos{LargeCargo: 1931}
+	techs := ogame.Researches{CombustionDrive: 18, ImpulseDrive: 15, HyperspaceDrive: 13}
+	secs, fuel := CalcLfFlightTime(origin, destination, 6, 499, true, true, 0.5, 1, 2, ships, techs, ogame.Discoverer, ogame.LfBonuses{})
+	assert.Equal(t, int64(5406), secs)
+	assert.Equal(t, int64(110336), fuel)
+
+	// +10% speed and -30% fuel consumption
+	bonuses := ogame.GetLfBonuses(ogame.LfBuildings{}, ogame.LfResearches{FusionDrives: 100, EfficiencyModule: 100}, 0)
+	secs, fuel = CalcLfFlightTime(origin, destination, 6, 499, true, true, 0.5, 1, 2, ships, techs, ogame.Discoverer, bonuses)
+	assert.Equal(t, int64(5312), secs)
+	assert.Equal(t, int64(79443), fuel)
+}
+
 func TestFixAttackEvents(t *testing.T) {
 	// Test when moon name matches
 	p1 := Planet{}
@@ -241,5 +231,84 @@ func TestVersion(t *testing.T) {
 }
 
 func TestFindSlowestSpeed(t *testing.T) {
-	assert.Equal(t, int64(8000), findSlowestSpeed(ogame.ShipsInfos{SmallCargo: 1, LargeCargo: 1}, ogame.Researches{CombustionDrive: 6}, false, false))
+	assert.Equal(t, int64(8000), findSlowestSpeed(ogame.ShipsInfos{SmallCargo: 1, LargeCargo: 1}, ogame.Researches{CombustionDrive: 6}, false, false, ogame.LfBonuses{}))
+}
+
+func TestFindHarvestReport(t *testing.T) {
+	arrival := time.Date(2020, 4, 22, 1, 12, 40, 0, time.UTC)
+	reports := []ogame.HarvestReport{
+		{ID: 3, Coordinate: ogame.Coordinate{1, 9, 7, ogame.DebrisType}, CreatedAt: arrival.Add(2 * time.Hour)},
+		{ID: 2, Coordinate: ogame.Coordinate{1, 9, 8, ogame.DebrisType}, CreatedAt: arrival},
+		{ID: 1, Coordinate: ogame.Coordinate{1, 9, 7, ogame.DebrisType}, CreatedAt: arrival.Add(time.Second)},
+	}
+	fleet := ogame.Fleet{Destination: ogame.Coordinate{1, 9, 7, ogame.DebrisType}, ArrivalTime: arrival}
+	report, ok := findHarvestReport(reports, fleet)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), report.ID)
+
+	fleet.ArrivalTime = arrival.Add(time.Hour)
+	_, ok = findHarvestReport(reports, fleet)
+	assert.False(t, ok)
+}
+
+func TestScrapPayload(t *testing.T) {
+	available := map[ogame.ID]int64{ogame.SmallCargoID: 10, ogame.RocketLauncherID: 5}
+	payload, toScrap, err := scrapPayload([]ogame.Quantifiable{{ID: ogame.SmallCargoID, Nbr: 4}, {ID: ogame.SmallCargoID, Nbr: 2}, {ID: ogame.RocketLauncherID, Nbr: 5}}, available)
+	assert.NoError(t, err)
+	assert.Equal(t, map[ogame.ID]int64{ogame.SmallCargoID: 6, ogame.RocketLauncherID: 5}, toScrap)
+	assert.Equal(t, "6", payload.Get("params[202]"))
+	assert.Equal(t, "5", payload.Get("params[401]"))
+
+	_, _, err = scrapPayload([]ogame.Quantifiable{{ID: ogame.SmallCargoID, Nbr: 11}}, available)
+	assert.Error(t, err)
+	_, _, err = scrapPayload([]ogame.Quantifiable{{ID: ogame.MetalMineID, Nbr: 1}}, available)
+	assert.Error(t, err)
+	_, _, err = scrapPayload(nil, available)
+	assert.Error(t, err)
+}
+
+func TestShouldBargainScrap(t *testing.T) {
+	merchant := ogame.ScrapMerchant{Percentage: 35, MaxPercentage: 75, BargainCost: 2000}
+	bargain, err := shouldBargainScrap(merchant, 50, 0, 5000)
+	assert.NoError(t, err)
+	assert.True(t, bargain)
+	bargain, _ = shouldBargainScrap(merchant, 50, 4000, 5000)
+	assert.False(t, bargain)
+	bargain, _ = shouldBargainScrap(merchant, 35, 0, 5000)
+	assert.False(t, bargain)
+	merchant.BargainCost = 0
+	_, err = shouldBargainScrap(merchant, 50, 0, 0)
+	assert.Equal(t, ogame.ErrScrapUnknownBargainCost, err)
+	merchant.Percentage = 75
+	bargain, err = shouldBargainScrap(merchant, 80, 0, 0)
+	assert.NoError(t, err)
+	assert.False(t, bargain)
+}
+
+func TestCharacterClassActionVals(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("../../samples/v7.1/en/shipyard.html")
+	for _, action := range []string{"selectClass", "deselectClass"} {
+		name := map[string]string{"selectClass": "selectCharacterClassEndpoint", "deselectClass": "deselectCharacterClassEndpoint"}[action]
+		m := regexp.MustCompile(`var ` + name + ` = "([^"]+)"`).FindSubmatch(pageHTMLBytes)
+		assert.Equal(t, 2, len(m))
+		endpoint, err := url.Parse(strings.ReplaceAll(strings.ReplaceAll(string(m[1]), `\/`, "/"), "CHARACTERCLASSID", "3"))
+		assert.NoError(t, err)
+		assert.Equal(t, endpoint.Query(), characterClassActionVals(ogame.Discoverer, action))
+	}
+}
+
+func TestRelocationFleets(t *testing.T) {
+	planet := ogame.Coordinate{Galaxy: 1, System: 2, Position: 3, Type: ogame.PlanetType}
+	fleets := []ogame.Fleet{
+		{ID: 1, Origin: planet, Destination: ogame.Coordinate{Galaxy: 4, System: 5, Position: 6, Type: ogame.PlanetType}},
+		{ID: 2, Origin: planet.Moon(), Destination: ogame.Coordinate{Galaxy: 4, System: 5, Position: 6, Type: ogame.PlanetType}},
+		{ID: 3, Origin: ogame.Coordinate{Galaxy: 4, System: 5, Position: 6, Type: ogame.PlanetType}, Destination: planet},
+	}
+	assert.Equal(t, []ogame.FleetID{1, 3}, relocationFleets(fleets, planet))
+}
+
+func TestAjaxStatusResponse(t *testing.T) {
+	assert.NoError(t, ajaxStatusResponse([]byte(`{"status":true,"newName":"Colony","errorbox":{"type":"fadeBox","text":"","failed":0}}`)))
+	err := ajaxStatusResponse([]byte(`{"status":false,"errorbox":{"type":"fadeBox","text":"This planet has already been reserved for a relocation.","failed":1}}`))
+	assert.EqualError(t, err, "This planet has already been reserved for a relocation.")
 }
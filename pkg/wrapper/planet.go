@@ -2,6 +2,7 @@ package wrapper
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/alaingilbert/ogame/pkg/ogame"
 )
@@ -138,12 +139,88 @@ func (p Planet) ActivateItem(ref string) error {
 	return p.ogame.ActivateItem(ref, p.ID.Celestial())
 }
 
+// BuyItem buys an item from the dark matter shop
+func (p Planet) BuyItem(ref string) error {
+	return p.ogame.BuyItem(ref, p.ID.Celestial())
+}
+
+// GetLfBonuses gets the modifiers granted by the lifeform buildings and techs of the planet
+func (p Planet) GetLfBonuses() (ogame.LfBonuses, error) {
+	return p.ogame.GetLfBonuses(p.ID)
+}
+
+// LfConstructionTime gets the duration to build anything (level of a building or tech, number of units) on the planet,
+// with the lifeform bonuses of the planet
+func (p Planet) LfConstructionTime(id ogame.ID, nbr int64) (time.Duration, error) {
+	return p.ogame.LfConstructionTime(p.ID, id, nbr)
+}
+
+// LfFlightTime calculates the flight time and fuel consumption of ships leaving the planet, with the lifeform bonuses of the planet
+func (p Planet) LfFlightTime(destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64, err error) {
+	return p.ogame.LfFlightTime(p.ID, destination, speed, ships, missionID)
+}
+
+// LfCargo gets the total cargo of ships leaving the planet, with the lifeform bonuses of the planet
+func (p Planet) LfCargo(ships ogame.ShipsInfos) (int64, error) {
+	return p.ogame.LfCargo(p.ID, ships)
+}
+
+// LfPrice gets the price of an object on the planet, with the research cost reduction of the planet for technologies
+func (p Planet) LfPrice(id ogame.ID, nbr int64) (ogame.Resources, error) {
+	return p.ogame.LfPrice(p.ID, id, nbr)
+}
+
+// GetLfSettings gets the lifeform settings of the planet
+func (p Planet) GetLfSettings() (ogame.LfSettings, error) {
+	return p.ogame.GetLfSettings(p.ID)
+}
+
+// SelectLfSpecies selects (or switches) the lifeform species of the planet
+func (p Planet) SelectLfSpecies(lfType ogame.LifeformType) error {
+	return p.ogame.SelectLfSpecies(p.ID, lfType)
+}
+
+// SetLfResearchSlot sets the technology researched in a lifeform research slot of the planet
+func (p Planet) SetLfResearchSlot(slot int64, techID ogame.ID) error {
+	return p.ogame.SetLfResearchSlot(p.ID, slot, techID)
+}
+
+// Relocate reserves the relocation of the planet to the given coordinate
+func (p Planet) Relocate(coord ogame.Coordinate) error {
+	return p.ogame.RelocatePlanet(p.ID, coord)
+}
+
+// GetRelocationStatus gets the relocation countdown of the planet and what would cancel it
+func (p Planet) GetRelocationStatus() (ogame.RelocationStatus, error) {
+	return p.ogame.GetRelocationStatus(p.ID)
+}
+
+// GetPlanetLayer gets the rename/relocate/abandon information of the planet
+func (p Planet) GetPlanetLayer() (ogame.PlanetLayer, error) {
+	return p.ogame.GetPlanetLayer(p.ID.Celestial())
+}
+
+// Rename the planet
+func (p Planet) Rename(newName string) error {
+	return p.ogame.RenameCelestial(p.ID.Celestial(), newName)
+}
+
 // GetResourcesProductions gets the resources production
 func (p Planet) GetResourcesProductions() (ogame.Resources, error) {
 	return p.ogame.GetResourcesProductions(p.ID)
 }
 
-// FlightTime calculate flight time and fuel needed
+// GetResourcesProductionsBreakdown gets the resources production per source
+func (p Planet) GetResourcesProductionsBreakdown() (ogame.ProductionBreakdown, error) {
+	return p.ogame.GetResourcesProductionsBreakdown(p.ID)
+}
+
+// GetUpgradeAdvices gets the next production upgrades of the planet ranked by amortization time
+func (p Planet) GetUpgradeAdvices(ratio ogame.Multiplier) ([]ogame.UpgradeAdvice, error) {
+	return p.ogame.GetUpgradeAdvices(p.ID, ratio)
+}
+
+// FlightTime calculate flight time and fuel needed, with the cached lifeform bonuses of the planet
 func (p Planet) FlightTime(destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64) {
 	return p.ogame.FlightTime(p.Coordinate, destination, speed, ships, missionID)
 }
@@ -163,6 +240,11 @@ func (p Planet) GetLfResearch(options ...Option) (ogame.LfResearches, error) {
 	return p.ogame.getLfResearch(p.ID.Celestial(), options...)
 }
 
+// GetUnlockPlan gets the ordered list of upgrades needed on the planet to unlock an ogame object
+func (p Planet) GetUnlockPlan(id ogame.ID) (ogame.UnlockPlan, error) {
+	return p.ogame.GetUnlockPlan(p.ID.Celestial(), id)
+}
+
 // GetTechs gets (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches)
 func (p Planet) GetTechs() (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error) {
 	return p.ogame.GetTechs(p.ID.Celestial())
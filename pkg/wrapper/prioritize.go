@@ -168,6 +168,48 @@ func (b *Prioritize) GetCelestials() ([]Celestial, error) {
 	return b.bot.getCelestials()
 }
 
+// GetCharacterClassSelection gets the character classes available and their dark matter price
+func (b *Prioritize) GetCharacterClassSelection() (ogame.CharacterClassSelection, error) {
+	b.begin("GetCharacterClassSelection")
+	defer b.done()
+	return b.bot.getCharacterClassSelection()
+}
+
+// SelectCharacterClass selects (or switches to) a character class, paying dark matter if needed
+func (b *Prioritize) SelectCharacterClass(class ogame.CharacterClass) error {
+	b.begin("SelectCharacterClass")
+	defer b.done()
+	return b.bot.selectCharacterClass(class)
+}
+
+// DeselectCharacterClass removes the current character class
+func (b *Prioritize) DeselectCharacterClass() error {
+	b.begin("DeselectCharacterClass")
+	defer b.done()
+	return b.bot.deselectCharacterClass()
+}
+
+// GetAllianceClassSelection gets the alliance classes available and their dark matter price
+func (b *Prioritize) GetAllianceClassSelection() (ogame.AllianceClassSelection, error) {
+	b.begin("GetAllianceClassSelection")
+	defer b.done()
+	return b.bot.getAllianceClassSelection()
+}
+
+// SelectAllianceClass selects the alliance class (alliance founder only)
+func (b *Prioritize) SelectAllianceClass(class ogame.AllianceClass) error {
+	b.begin("SelectAllianceClass")
+	defer b.done()
+	return b.bot.selectAllianceClass(class)
+}
+
+// GetOfficers gets the officers status and their remaining durations
+func (b *Prioritize) GetOfficers() (ogame.Officers, error) {
+	b.begin("GetOfficers")
+	defer b.done()
+	return b.bot.getOfficers()
+}
+
 // RecruitOfficer recruit an officer.
 // Typ 2: Commander, 3: Admiral, 4: Engineer, 5: Geologist, 6: Technocrat
 // Days: 7 or 90
@@ -220,6 +262,34 @@ func (b *Prioritize) SendMessageAlliance(associationID int64, message string) er
 	return b.bot.sendMessage(associationID, message, false)
 }
 
+// GetConversations gets the conversations of the chat
+func (b *Prioritize) GetConversations() ([]ogame.Conversation, error) {
+	b.begin("GetConversations")
+	defer b.done()
+	return b.bot.getConversations()
+}
+
+// GetConversation gets the full history of the conversation with a player
+func (b *Prioritize) GetConversation(playerID int64) (ogame.Conversation, error) {
+	b.begin("GetConversation")
+	defer b.done()
+	return b.bot.getConversation(playerID)
+}
+
+// GetAllianceConversation gets the last messages of an alliance conversation
+func (b *Prioritize) GetAllianceConversation(associationID int64) (ogame.Conversation, error) {
+	b.begin("GetAllianceConversation")
+	defer b.done()
+	return b.bot.getAllianceConversation(associationID)
+}
+
+// ReplyToConversation sends a message to the player or the alliance of a conversation
+func (b *Prioritize) ReplyToConversation(conversation ogame.Conversation, message string) error {
+	b.begin("ReplyToConversation")
+	defer b.done()
+	return b.bot.replyToConversation(conversation, message)
+}
+
 // GetFleets get the player's own fleets activities
 func (b *Prioritize) GetFleets(opts ...Option) ([]ogame.Fleet, ogame.Slots) {
 	b.begin("GetFleets")
@@ -306,6 +376,13 @@ func (b *Prioritize) GetProduction(celestialID ogame.CelestialID) ([]ogame.Quant
 	return b.bot.getProduction(celestialID)
 }
 
+// GetCachedLfBonuses gets the cached lifeform bonuses of the planet at coord (or of the planet of the moon at coord)
+func (b *Prioritize) GetCachedLfBonuses(coord ogame.Coordinate) ogame.LfBonuses {
+	b.begin("GetCachedLfBonuses")
+	defer b.done()
+	return b.bot.getCachedLfBonuses(coord)
+}
+
 // GetCachedResearch gets the player cached researches information
 func (b *Prioritize) GetCachedResearch() ogame.Researches {
 	b.begin("GetCachedResearch")
@@ -432,6 +509,48 @@ func (b *Prioritize) GetResourcesDetails(celestialID ogame.CelestialID) (ogame.R
 	return b.bot.getResourcesDetails(celestialID)
 }
 
+// GetResourceTrader gets the resource trader exchange rates
+func (b *Prioritize) GetResourceTrader(celestialID ogame.CelestialID) (ogame.ResourceTrader, error) {
+	b.begin("GetResourceTrader")
+	defer b.done()
+	return b.bot.getResourceTrader(celestialID)
+}
+
+// ExchangeResources exchanges the resources given for the resource to receive, at the resource trader rates
+func (b *Prioritize) ExchangeResources(celestialID ogame.CelestialID, give ogame.Resources, receive ogame.TradeResource) error {
+	b.begin("ExchangeResources")
+	defer b.done()
+	return b.bot.exchangeResources(celestialID, give, receive)
+}
+
+// GetScrapMerchant gets the scrap merchant offer
+func (b *Prioritize) GetScrapMerchant(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	b.begin("GetScrapMerchant")
+	defer b.done()
+	return b.bot.getScrapMerchant(celestialID)
+}
+
+// BargainScrap pays dark matter to raise the scrap merchant percentage
+func (b *Prioritize) BargainScrap(celestialID ogame.CelestialID) (ogame.ScrapMerchant, error) {
+	b.begin("BargainScrap")
+	defer b.done()
+	return b.bot.bargainScrap(celestialID)
+}
+
+// ScrapUnits sells ships/defenses to the scrap merchant, bargaining up to minPercentage with at most maxDarkMatter
+func (b *Prioritize) ScrapUnits(celestialID ogame.CelestialID, units []ogame.Quantifiable, minPercentage, maxDarkMatter int64) (ogame.Resources, error) {
+	b.begin("ScrapUnits")
+	defer b.done()
+	return b.bot.scrapUnits(celestialID, units, minPercentage, maxDarkMatter)
+}
+
+// GetUnlockPlan gets the ordered list of upgrades needed on a celestial to unlock an ogame object
+func (b *Prioritize) GetUnlockPlan(celestialID ogame.CelestialID, id ogame.ID) (ogame.UnlockPlan, error) {
+	b.begin("GetUnlockPlan")
+	defer b.done()
+	return b.bot.getUnlockPlan(celestialID, id)
+}
+
 // GetTechs gets a celestial supplies/facilities/ships/researches
 func (b *Prioritize) GetTechs(celestialID ogame.CelestialID) (ogame.ResourcesBuildings, ogame.Facilities, ogame.ShipsInfos, ogame.DefensesInfos, ogame.Researches, ogame.LfBuildings, error) {
 	b.begin("GetTechs")
@@ -483,6 +602,34 @@ func (b *Prioritize) GetEspionageReportFor(coord ogame.Coordinate) (ogame.Espion
 	return b.bot.getEspionageReportFor(coord)
 }
 
+// GetMessages gets a page of messages from any tab of the inbox, and the number of pages
+func (b *Prioritize) GetMessages(tabID ogame.MessagesTabID, page int64) ([]ogame.Message, int64, error) {
+	b.begin("GetMessages")
+	defer b.done()
+	return b.bot.getMessages(tabID, page)
+}
+
+// GetMessagesSince gets the messages of a tab that are newer than msgID, newest first
+func (b *Prioritize) GetMessagesSince(tabID ogame.MessagesTabID, msgID int64) ([]ogame.Message, error) {
+	b.begin("GetMessagesSince")
+	defer b.done()
+	return b.bot.getMessagesSince(tabID, msgID)
+}
+
+// MarkMessageRead marks a message as read
+func (b *Prioritize) MarkMessageRead(tabID ogame.MessagesTabID, msgID int64) error {
+	b.begin("MarkMessageRead")
+	defer b.done()
+	return b.bot.markMessageRead(tabID, msgID)
+}
+
+// SetMessageFavorite adds or removes a message from the favourites
+func (b *Prioritize) SetMessageFavorite(msgID int64, favorite bool) error {
+	b.begin("SetMessageFavorite")
+	defer b.done()
+	return b.bot.setMessageFavorite(msgID, favorite)
+}
+
 // GetEspionageReportMessages gets the summary of each espionage reports
 func (b *Prioritize) GetEspionageReportMessages() ([]ogame.EspionageReportSummary, error) {
 	b.begin("GetEspionageReportMessages")
@@ -490,6 +637,28 @@ func (b *Prioritize) GetEspionageReportMessages() ([]ogame.EspionageReportSummar
 	return b.bot.getEspionageReportMessages()
 }
 
+// GetEspionageActionMessages gets the messages received when foreign fleets spied on our celestials
+func (b *Prioritize) GetEspionageActionMessages() ([]ogame.EspionageActionMessage, error) {
+	b.begin("GetEspionageActionMessages")
+	defer b.done()
+	return b.bot.getEspionageActionMessages()
+}
+
+// GetHarvestReports gets the reports of the debris fields harvested by recyclers and pathfinders,
+// ogame.ErrUnsupportedLanguage is returned when the reports of the server language can't be parsed
+func (b *Prioritize) GetHarvestReports() ([]ogame.HarvestReport, error) {
+	b.begin("GetHarvestReports")
+	defer b.done()
+	return b.bot.getHarvestReports()
+}
+
+// GetHarvestReportFor gets the harvest report of a recycle fleet
+func (b *Prioritize) GetHarvestReportFor(fleet ogame.Fleet) (ogame.HarvestReport, error) {
+	b.begin("GetHarvestReportFor")
+	defer b.done()
+	return b.bot.getHarvestReportFor(fleet)
+}
+
 // CollectAllMarketplaceMessages collect all marketplace messages
 func (b *Prioritize) CollectAllMarketplaceMessages() error {
 	b.begin("CollectAllMarketplaceMessages")
@@ -547,22 +716,41 @@ func (b *Prioritize) GetResourcesProductions(planetID ogame.PlanetID) (ogame.Res
 	return b.bot.getResourcesProductions(planetID)
 }
 
-// GetResourcesProductionsLight gets the planet resources production
+// GetResourcesProductionsBreakdown gets the planet resources production per source
+func (b *Prioritize) GetResourcesProductionsBreakdown(planetID ogame.PlanetID) (ogame.ProductionBreakdown, error) {
+	b.begin("GetResourcesProductionsBreakdown")
+	defer b.done()
+	return b.bot.getResourcesProductionsBreakdown(planetID)
+}
+
+// GetUpgradeAdvices gets the next production upgrades of a planet ranked by amortization time
+func (b *Prioritize) GetUpgradeAdvices(planetID ogame.PlanetID, ratio ogame.Multiplier) ([]ogame.UpgradeAdvice, error) {
+	b.begin("GetUpgradeAdvices")
+	defer b.done()
+	return b.bot.getUpgradeAdvices(planetID, ratio)
+}
+
+// GetResourcesProductionsLight estimates the planet resources production from the given buildings, researches,
+// settings and temperature, plus the universe speed, class and officers of the account.
+// The estimate is partial: the position, crawlers, active items and lifeform bonuses are not included,
+// use GetResourcesProductions, or ogame.GetProductionBreakdown with all the ogame.ProductionParams, to include them.
 func (b *Prioritize) GetResourcesProductionsLight(resBuildings ogame.ResourcesBuildings, researches ogame.Researches,
 	resSettings ogame.ResourceSettings, temp ogame.Temperature) ogame.Resources {
 	b.begin("GetResourcesProductionsLight")
 	defer b.done()
-	return getResourcesProductionsLight(resBuildings, researches, resSettings, temp, b.bot.serverData.Speed)
+	return b.bot.getResourcesProductionsLight(resBuildings, researches, resSettings, temp)
 }
 
-// FlightTime calculate flight time and fuel needed
+// FlightTime calculate flight time and fuel needed, with the cached lifeform bonuses of the origin planet
+// (loaded the first time, no bonus if origin is not one of our celestials)
 func (b *Prioritize) FlightTime(origin, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64) {
 	b.begin("FlightTime")
 	defer b.done()
 	researches := b.bot.getCachedResearch()
-	return CalcFlightTime(origin, destination, b.bot.serverData.Galaxies, b.bot.serverData.Systems,
+	return CalcLfFlightTime(origin, destination, b.bot.serverData.Galaxies, b.bot.serverData.Systems,
 		b.bot.serverData.DonutGalaxy, b.bot.serverData.DonutSystem, b.bot.serverData.GlobalDeuteriumSaveFactor,
-		float64(speed)/10, GetFleetSpeedForMission(b.bot.serverData, missionID), ships, researches, b.bot.characterClass)
+		float64(speed)/10, GetFleetSpeedForMission(b.bot.serverData, missionID), ships, researches, b.bot.characterClass,
+		b.bot.getCachedLfBonuses(origin))
 }
 
 // Phalanx scan a coordinate from a moon to get fleets information
@@ -694,6 +882,99 @@ func (b *Prioritize) ActivateItem(ref string, celestialID ogame.CelestialID) err
 	return b.bot.activateItem(ref, celestialID)
 }
 
+// BuyItem buys an item from the dark matter shop
+func (b *Prioritize) BuyItem(ref string, celestialID ogame.CelestialID) error {
+	b.begin("BuyItem")
+	defer b.done()
+	return b.bot.buyItem(ref, celestialID)
+}
+
+// GetLfBonuses gets the modifiers granted by the lifeform buildings and techs of a planet
+func (b *Prioritize) GetLfBonuses(planetID ogame.PlanetID) (ogame.LfBonuses, error) {
+	b.begin("GetLfBonuses")
+	defer b.done()
+	return b.bot.getLfBonuses(planetID.Celestial())
+}
+
+// LfConstructionTime gets the duration to build anything (level of a building or tech, number of units) on a planet,
+// with the lifeform bonuses of the planet
+func (b *Prioritize) LfConstructionTime(planetID ogame.PlanetID, id ogame.ID, nbr int64) (time.Duration, error) {
+	b.begin("LfConstructionTime")
+	defer b.done()
+	return b.bot.lfConstructionTime(planetID.Celestial(), id, nbr)
+}
+
+// LfFlightTime calculates the flight time and fuel consumption of ships leaving a planet, with the lifeform bonuses of the planet
+func (b *Prioritize) LfFlightTime(planetID ogame.PlanetID, destination ogame.Coordinate, speed ogame.Speed, ships ogame.ShipsInfos, missionID ogame.MissionID) (secs, fuel int64, err error) {
+	b.begin("LfFlightTime")
+	defer b.done()
+	return b.bot.lfFlightTime(planetID, destination, speed, ships, missionID)
+}
+
+// LfCargo gets the total cargo of ships leaving a planet, with the lifeform bonuses of the planet
+func (b *Prioritize) LfCargo(planetID ogame.PlanetID, ships ogame.ShipsInfos) (int64, error) {
+	b.begin("LfCargo")
+	defer b.done()
+	return b.bot.lfCargo(planetID, ships)
+}
+
+// LfPrice gets the price of an object on a planet, with the research cost reduction of the planet for technologies
+func (b *Prioritize) LfPrice(planetID ogame.PlanetID, id ogame.ID, nbr int64) (ogame.Resources, error) {
+	b.begin("LfPrice")
+	defer b.done()
+	return b.bot.lfPrice(planetID, id, nbr)
+}
+
+// GetLfSettings gets the lifeform settings of a planet
+func (b *Prioritize) GetLfSettings(planetID ogame.PlanetID) (ogame.LfSettings, error) {
+	b.begin("GetLfSettings")
+	defer b.done()
+	return b.bot.getLfSettings(planetID.Celestial())
+}
+
+// SelectLfSpecies selects (or switches) the lifeform species of a planet
+func (b *Prioritize) SelectLfSpecies(planetID ogame.PlanetID, lfType ogame.LifeformType) error {
+	b.begin("SelectLfSpecies")
+	defer b.done()
+	return b.bot.selectLfSpecies(planetID.Celestial(), lfType)
+}
+
+// SetLfResearchSlot sets the technology researched in a lifeform research slot of a planet
+func (b *Prioritize) SetLfResearchSlot(planetID ogame.PlanetID, slot int64, techID ogame.ID) error {
+	b.begin("SetLfResearchSlot")
+	defer b.done()
+	return b.bot.setLfResearchSlot(planetID.Celestial(), slot, techID)
+}
+
+// GetPlanetLayer gets the rename/relocate/abandon information of a celestial
+func (b *Prioritize) GetPlanetLayer(celestialID ogame.CelestialID) (ogame.PlanetLayer, error) {
+	b.begin("GetPlanetLayer")
+	defer b.done()
+	return b.bot.getPlanetLayer(celestialID)
+}
+
+// RenameCelestial renames a planet or moon
+func (b *Prioritize) RenameCelestial(celestialID ogame.CelestialID, newName string) error {
+	b.begin("RenameCelestial")
+	defer b.done()
+	return b.bot.renameCelestial(celestialID, newName)
+}
+
+// RelocatePlanet reserves the relocation of a planet to the given coordinate.
+// The planet only moves when the 24h countdown ends, if no fleet, construction or research is running then.
+func (b *Prioritize) RelocatePlanet(planetID ogame.PlanetID, coord ogame.Coordinate) error {
+	b.begin("RelocatePlanet")
+	defer b.done()
+	return b.bot.relocatePlanet(planetID.Celestial(), coord)
+}
+
+// GetRelocationStatus gets the relocation countdown of a planet and what would cancel it
+func (b *Prioritize) GetRelocationStatus(planetID ogame.PlanetID) (ogame.RelocationStatus, error) {
+	b.begin("GetRelocationStatus")
+	defer b.done()
+	return b.bot.getRelocationStatus(planetID.Celestial())
+}
+
 // BuyMarketplace buy an item on the marketplace
 func (b *Prioritize) BuyMarketplace(itemID int64, celestialID ogame.CelestialID) error {
 	b.begin("BuyMarketplace")